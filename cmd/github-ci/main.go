@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/reugn/github-ci/internal/cmd"
 )
 
@@ -9,5 +11,5 @@ var version = "dev"
 
 func main() {
 	cmd.SetVersion(version)
-	cmd.Execute()
+	os.Exit(cmd.Execute())
 }
@@ -2,10 +2,12 @@ package actions
 
 import "sync"
 
-// CacheStats holds statistics about cache usage.
+// CacheStats holds statistics about cache usage and the GitHub API rate limit.
 type CacheStats struct {
-	Hits   int64 // Number of cache hits
-	Misses int64 // Number of cache misses (API calls made)
+	Hits          int64 // Number of cache hits
+	Misses        int64 // Number of cache misses (API calls made)
+	RateLimit     int   // Requests allowed per hour (0 if unknown)
+	RateRemaining int   // Requests remaining in the current rate limit window
 }
 
 // Cache stores cached results for version lookups to avoid duplicate API calls
@@ -16,6 +16,9 @@ import (
 
 const (
 	timeout = 10 * time.Second
+	// defaultMaxRetryWait is the longest wait before retrying a rate-limited API call,
+	// unless set with SetMaxRetryWait.
+	defaultMaxRetryWait = time.Minute
 	// GitHubTokenEnvVar is the environment variable for GitHub authentication.
 	GitHubTokenEnvVar = "GITHUB_TOKEN" //nolint:gosec // Not a credential, just env var name
 )
@@ -28,10 +31,14 @@ type tagInfo struct {
 
 // Client implements the Resolver interface.
 type Client struct {
-	ctx        context.Context
-	github     *github.Client
-	cache      *Cache
-	clientOnce sync.Once
+	ctx          context.Context
+	github       *github.Client
+	cache        *Cache
+	clientOnce   sync.Once
+	maxRetryWait time.Duration
+	sleep        sleepFunc
+	rateMu       sync.Mutex
+	rate         github.Rate // Last rate limit reported by GitHub
 }
 
 // Ensure Client implements Resolver
@@ -44,17 +51,16 @@ func NewClient() *Client {
 
 // NewClientWithContext creates a new Client instance with the provided context.
 func NewClientWithContext(ctx context.Context) *Client {
-	return &Client{
-		ctx:   ctx,
-		cache: NewCache(),
-	}
+	return NewClientWithCache(ctx, NewCache())
 }
 
 // NewClientWithCache creates a Client with a shared cache.
 func NewClientWithCache(ctx context.Context, cache *Cache) *Client {
 	return &Client{
-		ctx:   ctx,
-		cache: cache,
+		ctx:          ctx,
+		cache:        cache,
+		maxRetryWait: defaultMaxRetryWait,
+		sleep:        sleepContext,
 	}
 }
 
@@ -79,9 +85,15 @@ func (c *Client) ClearCache() {
 	c.cache.Clear()
 }
 
-// GetCacheStats returns the cache usage statistics.
+// GetCacheStats returns the cache usage statistics and the remaining rate limit budget.
 func (c *Client) GetCacheStats() CacheStats {
-	return c.cache.Stats()
+	stats := c.cache.Stats()
+
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	stats.RateLimit = c.rate.Limit
+	stats.RateRemaining = c.rate.Remaining
+	return stats
 }
 
 // paginateTags iterates through all repository tags, calling fn for each.
@@ -91,7 +103,13 @@ func (c *Client) paginateTags(owner, repo string, fn func(*github.RepositoryTag)
 	opts := &github.ListOptions{PerPage: 100}
 
 	for {
-		tags, resp, err := client.Repositories.ListTags(c.ctx, owner, repo, opts)
+		var tags []*github.RepositoryTag
+		var resp *github.Response
+		err := c.withRetry(func() (*github.Response, error) {
+			var err error
+			tags, resp, err = client.Repositories.ListTags(c.ctx, owner, repo, opts)
+			return resp, err
+		})
 		if err != nil {
 			return fmt.Errorf("failed to fetch tags: %w", err)
 		}
@@ -129,14 +147,25 @@ func (c *Client) GetCommitHash(owner, repo, ref string) (string, error) {
 
 	client := c.getGitHubClient()
 
+	getRef := func(name string) (*github.Reference, error) {
+		var gitRef *github.Reference
+		err := c.withRetry(func() (*github.Response, error) {
+			var resp *github.Response
+			var err error
+			gitRef, resp, err = client.Git.GetRef(c.ctx, owner, repo, name)
+			return resp, err
+		})
+		return gitRef, err
+	}
+
 	// Try as a tag first
-	gitRef, _, err := client.Git.GetRef(c.ctx, owner, repo, "refs/tags/"+ref)
+	gitRef, err := getRef("refs/tags/" + ref)
 	if err == nil && gitRef.Object != nil {
 		return gitRef.Object.GetSHA(), nil
 	}
 
 	// Fall back to a branch
-	gitRef, _, err = client.Git.GetRef(c.ctx, owner, repo, "refs/heads/"+ref)
+	gitRef, err = getRef("refs/heads/" + ref)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch ref %s: %w", ref, err)
 	}
@@ -147,6 +176,27 @@ func (c *Client) GetCommitHash(owner, repo, ref string) (string, error) {
 	return gitRef.Object.GetSHA(), nil
 }
 
+// FileExists reports whether a file exists in the repository at the given ref.
+// A missing file, ref, or repository is reported as false rather than an error.
+func (c *Client) FileExists(owner, repo, path, ref string) (bool, error) {
+	client := c.getGitHubClient()
+	opts := &github.RepositoryContentGetOptions{Ref: ref}
+
+	var resp *github.Response
+	err := c.withRetry(func() (*github.Response, error) {
+		var err error
+		_, _, resp, err = client.Repositories.GetContents(c.ctx, owner, repo, path, opts)
+		return resp, err
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch %s at %s: %w", path, ref, err)
+	}
+	return true, nil
+}
+
 // GetLatestVersion fetches the latest compatible tag and commit hash.
 // Results are cached.
 func (c *Client) GetLatestVersion(owner, repo, currentVersion, versionConstraint string) (string, string, error) {
@@ -220,7 +270,13 @@ func (c *Client) GetLatestVersionUnconstrained(owner, repo string) (string, stri
 // tryGetLatestRelease attempts to get the latest version via GitHub Releases API.
 // Returns the tag name, commit hash, and true if successful.
 func (c *Client) tryGetLatestRelease(owner, repo string) (string, string, bool) {
-	release, _, err := c.getGitHubClient().Repositories.GetLatestRelease(c.ctx, owner, repo)
+	var release *github.RepositoryRelease
+	err := c.withRetry(func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		release, resp, err = c.getGitHubClient().Repositories.GetLatestRelease(c.ctx, owner, repo)
+		return resp, err
+	})
 	if err != nil || release == nil || release.TagName == nil {
 		return "", "", false
 	}
@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dockerScheme is the uses prefix of steps that run a Docker container image.
+const dockerScheme = "docker://"
+
+// DockerImage represents a parsed "docker://[registry/]image[:tag][@digest]" reference.
+type DockerImage struct {
+	Registry   string // Registry host (e.g., "ghcr.io"); empty for Docker Hub
+	Repository string // Image repository (e.g., "library/alpine" or "alpine")
+	Tag        string // Image tag (e.g., "3.19"); empty if not set
+	Digest     string // Content digest (e.g., "sha256:..."); empty if not set
+}
+
+// Name returns the image name without tag or digest ([registry/]repository).
+func (d *DockerImage) Name() string {
+	if d.Registry != "" {
+		return d.Registry + "/" + d.Repository
+	}
+	return d.Repository
+}
+
+// IsPinned returns true if the image is pinned to an immutable content digest.
+func (d *DockerImage) IsPinned() bool {
+	return d.Digest != ""
+}
+
+// IsDockerUses checks if a uses value references a Docker image (docker://...).
+func IsDockerUses(uses string) bool {
+	return strings.HasPrefix(uses, dockerScheme)
+}
+
+// ParseDockerUses parses "docker://[registry/]image[:tag][@digest]" into DockerImage.
+func ParseDockerUses(uses string) (*DockerImage, error) {
+	if !IsDockerUses(uses) {
+		return nil, fmt.Errorf("invalid docker image reference: %s", uses)
+	}
+	ref := strings.TrimPrefix(uses, dockerScheme)
+
+	image := &DockerImage{}
+	if atIdx := strings.Index(ref, "@"); atIdx != -1 {
+		ref, image.Digest = ref[:atIdx], ref[atIdx+1:]
+	}
+
+	// A colon after the last slash separates the tag; earlier ones belong to a registry port
+	if colonIdx := strings.LastIndex(ref, ":"); colonIdx > strings.LastIndex(ref, "/") {
+		ref, image.Tag = ref[:colonIdx], ref[colonIdx+1:]
+	}
+
+	// The first component is a registry if it looks like a host (e.g., "ghcr.io", "localhost:5000")
+	if slashIdx := strings.Index(ref, "/"); slashIdx != -1 {
+		host := ref[:slashIdx]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			image.Registry, ref = host, ref[slashIdx+1:]
+		}
+	}
+	image.Repository = ref
+
+	if image.Repository == "" {
+		return nil, fmt.Errorf("invalid docker image reference: %s", uses)
+	}
+	return image, nil
+}
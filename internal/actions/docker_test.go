@@ -0,0 +1,92 @@
+package actions
+
+import "testing"
+
+const testDigest = "sha256:4bcff63911fcb4448bd4fdacec207030997caf25e9bea4045fa6c8c44de311d1"
+
+func TestParseDockerUses(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        DockerImage
+		wantName    string
+		wantPinned  bool
+		expectError bool
+	}{
+		{
+			name:     "image without tag",
+			input:    "docker://alpine",
+			want:     DockerImage{Repository: "alpine"},
+			wantName: "alpine",
+		},
+		{
+			name:     "image with latest tag",
+			input:    "docker://alpine:latest",
+			want:     DockerImage{Repository: "alpine", Tag: "latest"},
+			wantName: "alpine",
+		},
+		{
+			name:     "namespaced image with version tag",
+			input:    "docker://library/node:18",
+			want:     DockerImage{Repository: "library/node", Tag: "18"},
+			wantName: "library/node",
+		},
+		{
+			name:       "image pinned by digest",
+			input:      "docker://alpine@" + testDigest,
+			want:       DockerImage{Repository: "alpine", Digest: testDigest},
+			wantName:   "alpine",
+			wantPinned: true,
+		},
+		{
+			name:       "registry image with tag and digest",
+			input:      "docker://ghcr.io/owner/tool:1.2@sha256:abc",
+			want:       DockerImage{Registry: "ghcr.io", Repository: "owner/tool", Tag: "1.2", Digest: "sha256:abc"},
+			wantName:   "ghcr.io/owner/tool",
+			wantPinned: true,
+		},
+		{
+			name:     "registry with port",
+			input:    "docker://localhost:5000/tool:dev",
+			want:     DockerImage{Registry: "localhost:5000", Repository: "tool", Tag: "dev"},
+			wantName: "localhost:5000/tool",
+		},
+		{
+			name:     "registry with port and no tag",
+			input:    "docker://registry.example.com:5000/team/tool",
+			want:     DockerImage{Registry: "registry.example.com:5000", Repository: "team/tool"},
+			wantName: "registry.example.com:5000/team/tool",
+		},
+		{
+			name:        "not a docker reference",
+			input:       "actions/checkout@v4",
+			expectError: true,
+		},
+		{
+			name:        "empty image",
+			input:       "docker://",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, err := ParseDockerUses(tt.input)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseDockerUses(%q) error = %v, expectError %v", tt.input, err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			if *image != tt.want {
+				t.Errorf("ParseDockerUses(%q) = %+v, want %+v", tt.input, *image, tt.want)
+			}
+			if got := image.Name(); got != tt.wantName {
+				t.Errorf("Name() = %q, want %q", got, tt.wantName)
+			}
+			if got := image.IsPinned(); got != tt.wantPinned {
+				t.Errorf("IsPinned() = %v, want %v", got, tt.wantPinned)
+			}
+		})
+	}
+}
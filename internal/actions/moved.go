@@ -0,0 +1,24 @@
+package actions
+
+import "strings"
+
+// movedActions maps archived or renamed actions to a maintained successor.
+// Keys are lowercase, since GitHub owner and repository names are case-insensitive.
+var movedActions = map[string]string{
+	"actions/create-release":           "softprops/action-gh-release",
+	"actions/upload-release-asset":     "softprops/action-gh-release",
+	"actions/setup-ruby":               "ruby/setup-ruby",
+	"actions/setup-elixir":             "erlef/setup-beam",
+	"actions/setup-haskell":            "haskell-actions/setup",
+	"haskell/actions/setup":            "haskell-actions/setup",
+	"actions-rs/toolchain":             "dtolnay/rust-toolchain",
+	"github/super-linter":              "super-linter/super-linter",
+	"crazy-max/ghaction-docker-buildx": "docker/setup-buildx-action",
+}
+
+// Successor returns the maintained replacement for an archived or renamed action,
+// identified by its normalized name (owner/repo or owner/repo/path).
+func Successor(name string) (string, bool) {
+	successor, ok := movedActions[strings.ToLower(name)]
+	return successor, ok
+}
@@ -7,11 +7,14 @@ import (
 	"github.com/reugn/github-ci/internal/version"
 )
 
-// ActionInfo represents a parsed GitHub Action reference.
+// reusableWorkflowDir is the repository directory reusable workflows are called from.
+const reusableWorkflowDir = ".github/workflows/"
+
+// ActionInfo represents a parsed GitHub Action or reusable workflow reference.
 type ActionInfo struct {
 	Owner string
 	Repo  string
-	Path  string // Subdirectory path for composite actions (e.g., "upload-sarif")
+	Path  string // Subdirectory path for composite actions (e.g., "upload-sarif") or workflow file path
 	Ref   string // Git reference: tag (e.g., "v2"), commit hash, or branch name
 }
 
@@ -28,6 +31,13 @@ func (a *ActionInfo) FormatUses(ref string) string {
 	return a.Name() + "@" + ref
 }
 
+// IsReusableWorkflow reports whether the reference is a reusable workflow call
+// (e.g., "owner/repo/.github/workflows/ci.yml@v1") rather than an action.
+func (a *ActionInfo) IsReusableWorkflow() bool {
+	return strings.HasPrefix(a.Path, reusableWorkflowDir) &&
+		(strings.HasSuffix(a.Path, ".yml") || strings.HasSuffix(a.Path, ".yaml"))
+}
+
 // IsAtLatest checks if the current ref points to the latest version.
 // Works for hashes, tags, and major versions.
 func (a *ActionInfo) IsAtLatest(latestTag, latestHash string) bool {
@@ -67,7 +77,12 @@ func (a *ActionInfo) NeedsFormatChange(desiredFormat string) bool {
 // ParseActionUses parses "owner/repo@ref" or "owner/repo/path@ref" into ActionInfo.
 // For composite actions like "github/codeql-action/upload-sarif@v2", the repo
 // is extracted as "codeql-action" and path as "upload-sarif".
+// Docker image references (docker://...) are rejected; use ParseDockerUses for them.
 func ParseActionUses(uses string) (*ActionInfo, error) {
+	if IsDockerUses(uses) {
+		return nil, fmt.Errorf("invalid action format: %s is a docker image", uses)
+	}
+
 	atIdx := strings.LastIndex(uses, "@")
 	if atIdx == -1 {
 		return nil, fmt.Errorf("invalid action format: %s", uses)
@@ -121,6 +136,11 @@ func IsCommitHash(ref string) bool {
 	return true
 }
 
+// IsLatestRef checks if ref is the mutable "latest" literal (e.g., "owner/repo@latest").
+func IsLatestRef(ref string) bool {
+	return strings.EqualFold(ref, "latest")
+}
+
 // IsMajorVersionOnly checks if ref is only a major version (e.g., "v3" or "3").
 func IsMajorVersionOnly(ref string) bool {
 	ref = version.Normalize(ref)
@@ -52,11 +52,24 @@ func TestParseActionUses(t *testing.T) {
 			wantPath:  "assume-role",
 			wantRef:   "v4",
 		},
+		{
+			name:      "reusable workflow",
+			input:     "octo-org/shared/.github/workflows/ci.yml@v1",
+			wantOwner: "octo-org",
+			wantRepo:  "shared",
+			wantPath:  ".github/workflows/ci.yml",
+			wantRef:   "v1",
+		},
 		{
 			name:        "missing @",
 			input:       "actions/checkout",
 			expectError: true,
 		},
+		{
+			name:        "docker image with digest",
+			input:       "docker://alpine@sha256:4bcff63911fcb4448bd4fdacec207030997caf25e9bea4045fa6c8c44de311d1",
+			expectError: true,
+		},
 		{
 			name:        "invalid format",
 			input:       "invalid",
@@ -134,6 +147,29 @@ func TestIsCommitHash(t *testing.T) {
 	}
 }
 
+func TestIsLatestRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"lowercase", "latest", true},
+		{"mixed case", "Latest", true},
+		{"major version", "v3", false},
+		{"prefixed", "latest-v2", false},
+		{"empty string", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IsLatestRef(tt.input)
+			if result != tt.expected {
+				t.Errorf("IsLatestRef(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestIsMajorVersionOnly(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -211,6 +247,32 @@ func TestActionInfo_FormatUses(t *testing.T) {
 	}
 }
 
+func TestActionInfo_IsReusableWorkflow(t *testing.T) {
+	tests := []struct {
+		uses string
+		want bool
+	}{
+		{"octo-org/shared/.github/workflows/ci.yml@v1", true},
+		{"octo-org/shared/.github/workflows/release.yaml@main", true},
+		{"actions/checkout@v4", false},
+		{"github/codeql-action/upload-sarif@v2", false},
+		{"octo-org/shared/.github/workflows/README.md@v1", false},
+		{"octo-org/shared/actions/.github/workflows/ci.yml@v1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uses, func(t *testing.T) {
+			info, err := ParseActionUses(tt.uses)
+			if err != nil {
+				t.Fatalf("ParseActionUses(%q) error = %v", tt.uses, err)
+			}
+			if got := info.IsReusableWorkflow(); got != tt.want {
+				t.Errorf("IsReusableWorkflow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestActionInfo_IsAtLatest(t *testing.T) {
 	latestTag := "v4.2.1"
 	latestHash := "abc1234567890123456789012345678901234567"
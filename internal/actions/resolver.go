@@ -7,5 +7,6 @@ type Resolver interface {
 	GetLatestVersionUnconstrained(owner, repo string) (string, string, error)
 	GetTagForCommit(owner, repo, commitHash string) (string, error)
 	GetLatestMinorVersion(owner, repo, majorVersion string) (string, string, error)
+	FileExists(owner, repo, path, ref string) (bool, error)
 	GetCacheStats() CacheStats
 }
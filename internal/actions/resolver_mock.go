@@ -7,6 +7,7 @@ type MockResolver struct {
 	GetLatestVersionUnconstrFunc func(owner, repo string) (string, string, error)
 	GetTagForCommitFunc          func(owner, repo, commitHash string) (string, error)
 	GetLatestMinorVersionFunc    func(owner, repo, majorVersion string) (string, string, error)
+	FileExistsFunc               func(owner, repo, path, ref string) (bool, error)
 }
 
 // Ensure MockResolver implements Resolver
@@ -47,6 +48,13 @@ func (m *MockResolver) GetLatestMinorVersion(owner, repo, majorVersion string) (
 	return "", "", nil
 }
 
+func (m *MockResolver) FileExists(owner, repo, path, ref string) (bool, error) {
+	if m.FileExistsFunc != nil {
+		return m.FileExistsFunc(owner, repo, path, ref)
+	}
+	return true, nil
+}
+
 func (m *MockResolver) GetCacheStats() CacheStats {
 	return CacheStats{} // Mock always returns zero stats
 }
@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/go-github/v80/github"
+)
+
+const (
+	// maxRetries is the maximum number of retries for a rate-limited API call.
+	maxRetries = 3
+	// baseRetryDelay is the first backoff delay when GitHub doesn't say how long to wait.
+	baseRetryDelay = 2 * time.Second
+)
+
+// sleepFunc waits for d or until ctx is done.
+type sleepFunc func(ctx context.Context, d time.Duration) error
+
+// sleepContext waits for d, returning early with the context error if ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetMaxRetryWait sets the longest the client waits before retrying a rate-limited call.
+// Calls whose rate limit resets later than that fail immediately. Zero disables retries.
+func (c *Client) SetMaxRetryWait(d time.Duration) {
+	c.maxRetryWait = d
+}
+
+// withRetry runs call, retrying it while GitHub reports a primary or secondary
+// rate limit that resets within the maximum retry wait.
+func (c *Client) withRetry(call func() (*github.Response, error)) error {
+	for attempt := 0; ; attempt++ {
+		resp, err := call()
+		if resp != nil {
+			c.recordRate(resp.Rate)
+		}
+		if err == nil {
+			return nil
+		}
+
+		delay, ok := retryDelay(err, attempt, c.maxRetryWait, time.Now())
+		if !ok || attempt >= maxRetries {
+			return err
+		}
+		if err := c.sleep(c.ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying after err, and false if err
+// is not a rate limit error or the wait would exceed maxWait.
+func retryDelay(err error, attempt int, maxWait time.Duration, now time.Time) (time.Duration, bool) {
+	if maxWait <= 0 {
+		return 0, false
+	}
+
+	var delay time.Duration
+
+	var rateErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	switch {
+	case errors.As(err, &rateErr):
+		// Primary rate limit: nothing succeeds until the window resets
+		delay = rateErr.Rate.Reset.Sub(now) + time.Second
+	case errors.As(err, &abuseErr) && abuseErr.RetryAfter != nil:
+		delay = *abuseErr.RetryAfter
+	case errors.As(err, &abuseErr):
+		// Secondary rate limit without Retry-After: exponential backoff with jitter
+		delay = baseRetryDelay << attempt
+		delay += rand.N(delay / 2)
+		delay = min(delay, maxWait)
+	default:
+		return 0, false
+	}
+
+	if delay > maxWait {
+		return 0, false
+	}
+	return max(delay, 0), true
+}
+
+// recordRate stores the latest rate limit reported by GitHub.
+func (c *Client) recordRate(rate github.Rate) {
+	if rate.Limit == 0 {
+		return
+	}
+
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	c.rate = rate
+}
@@ -0,0 +1,143 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v80/github"
+)
+
+// testResponse returns an HTTP response for constructing go-github errors.
+func testResponse(status int) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/repos/o/r/tags"}},
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	retryAfter := 30 * time.Second
+	rateErr := func(reset time.Duration) error {
+		return &github.RateLimitError{
+			Rate:     github.Rate{Limit: 60, Reset: github.Timestamp{Time: now.Add(reset)}},
+			Response: testResponse(http.StatusForbidden),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		attempt  int
+		maxWait  time.Duration
+		minDelay time.Duration
+		maxDelay time.Duration
+		retry    bool
+	}{
+		{"primary limit resets soon", rateErr(10 * time.Second), 0, time.Minute, 11 * time.Second, 11 * time.Second, true},
+		{"primary limit resets too late", rateErr(time.Hour), 0, time.Minute, 0, 0, false},
+		{"primary limit already reset", rateErr(-time.Minute), 0, time.Minute, 0, 0, true},
+		{
+			"secondary limit with retry-after",
+			&github.AbuseRateLimitError{Response: testResponse(http.StatusForbidden), RetryAfter: &retryAfter},
+			0, time.Minute, retryAfter, retryAfter, true,
+		},
+		{
+			"secondary limit backs off exponentially",
+			&github.AbuseRateLimitError{Response: testResponse(http.StatusForbidden)},
+			2, time.Minute, 8 * time.Second, 12 * time.Second, true,
+		},
+		{
+			"secondary limit backoff is capped",
+			&github.AbuseRateLimitError{Response: testResponse(http.StatusForbidden)},
+			10, time.Minute, time.Minute, time.Minute, true,
+		},
+		{"retries disabled", rateErr(time.Second), 0, 0, 0, 0, false},
+		{"other error", errors.New("not found"), 0, time.Minute, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := retryDelay(tt.err, tt.attempt, tt.maxWait, now)
+			if retry != tt.retry {
+				t.Fatalf("retryDelay() retry = %v, want %v", retry, tt.retry)
+			}
+			if delay < tt.minDelay || delay > tt.maxDelay {
+				t.Errorf("retryDelay() delay = %v, want between %v and %v", delay, tt.minDelay, tt.maxDelay)
+			}
+		})
+	}
+}
+
+func TestClient_WithRetry(t *testing.T) {
+	retryAfter := time.Second
+	abuseErr := &github.AbuseRateLimitError{Response: testResponse(http.StatusForbidden), RetryAfter: &retryAfter}
+
+	tests := []struct {
+		name          string
+		failures      int
+		expectedCalls int
+		expectErr     bool
+	}{
+		{"succeeds first time", 0, 1, false},
+		{"succeeds after retries", 2, 3, false},
+		{"gives up after max retries", 10, maxRetries + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithContext(context.Background())
+			var slept []time.Duration
+			client.sleep = func(_ context.Context, d time.Duration) error {
+				slept = append(slept, d)
+				return nil
+			}
+
+			calls := 0
+			err := client.withRetry(func() (*github.Response, error) {
+				calls++
+				resp := &github.Response{Rate: github.Rate{Limit: 5000, Remaining: 5000 - calls}}
+				if calls <= tt.failures {
+					return resp, abuseErr
+				}
+				return resp, nil
+			})
+
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("withRetry() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("withRetry() made %d call(s), want %d", calls, tt.expectedCalls)
+			}
+			if !slices.Equal(slept, slices.Repeat([]time.Duration{retryAfter}, calls-1)) {
+				t.Errorf("withRetry() slept %v", slept)
+			}
+
+			stats := client.GetCacheStats()
+			if stats.RateLimit != 5000 || stats.RateRemaining != 5000-calls {
+				t.Errorf("GetCacheStats() rate = %d/%d, want %d/5000", stats.RateRemaining, stats.RateLimit, 5000-calls)
+			}
+		})
+	}
+}
+
+func TestClient_WithRetry_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClientWithContext(ctx)
+	retryAfter := time.Hour
+	client.SetMaxRetryWait(2 * time.Hour)
+
+	err := client.withRetry(func() (*github.Response, error) {
+		return nil, &github.AbuseRateLimitError{Response: testResponse(http.StatusForbidden), RetryAfter: &retryAfter}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("withRetry() error = %v, want context.Canceled", err)
+	}
+}
@@ -3,59 +3,89 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"time"
 
+	"github.com/reugn/github-ci/internal/actions"
 	"github.com/reugn/github-ci/internal/config"
-	"github.com/reugn/github-ci/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Common flags shared across commands
-	pathFlag   string
-	configFlag string
+	pathFlag    string
+	configFlag  string
+	timeoutFlag time.Duration
 )
 
+// configFlagUsage is the --config help for commands that load the configuration,
+// which by default is searched upward from the current directory.
+const configFlagUsage = "Path to configuration file (default: nearest " + config.DefaultConfigFileName +
+	" or " + config.AltConfigFileName + ", searched upward and stopping at the repository root)"
+
 // addCommonFlags adds common flags (path and config) to a command.
 func addCommonFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&pathFlag, "path", "p", ".github/workflows", "Path to workflow directory or file")
-	cmd.Flags().StringVarP(&configFlag, "config", "c", ".github-ci.yaml", "Path to configuration file")
+	cmd.Flags().StringVarP(&configFlag, "config", "c", "", configFlagUsage)
+}
+
+// addTimeoutFlag adds the --timeout flag to commands that run with a timeout context.
+func addTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&timeoutFlag, "timeout", 0,
+		"Maximum duration for the command, e.g. 30s or 2m (overrides run.timeout)")
+}
+
+// validateTimeoutFlag returns an error if --timeout was set to a non-positive duration.
+func validateTimeoutFlag(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("timeout") && timeoutFlag <= 0 {
+		return fmt.Errorf("invalid timeout %s (must be positive)", timeoutFlag)
+	}
+	return nil
+}
+
+// configFileOrDefault returns the --config path, or the default file name if not set.
+// An empty --config lets LoadConfig apply its precedence rules (e.g., the
+// GITHUB_CI_CONFIG environment variable), so only use this for a concrete file path.
+func configFileOrDefault() string {
+	if configFlag == "" {
+		return config.DefaultConfigFileName
+	}
+	return configFlag
 }
 
-// createTimeoutContext creates a context with timeout from config.
+// createTimeoutContext creates a context with timeout from the --timeout flag or config.
 // Returns the context and a cancel function that must be called to release resources.
 func createTimeoutContext(configFile string) (context.Context, context.CancelFunc) {
 	cfg, _ := config.LoadConfig(configFile)
-	timeout := config.DefaultTimeout
-	if cfg != nil {
-		timeout = cfg.GetTimeout()
-	}
-	return context.WithTimeout(context.Background(), timeout)
+	return context.WithTimeout(context.Background(), resolveTimeout(timeoutFlag, cfg))
 }
 
-// loadWorkflows loads workflows from the specified path, which can be a directory or a file.
-func loadWorkflows(path string) ([]*workflow.Workflow, error) {
-	info, err := os.Stat(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to access path %s: %w", path, err)
+// resolveTimeout returns the timeout to use with precedence: override > config > default.
+// A non-positive override means the flag was not set.
+func resolveTimeout(override time.Duration, cfg *config.Config) time.Duration {
+	if override > 0 {
+		return override
 	}
-
-	if info.IsDir() {
-		return workflow.LoadWorkflows(path)
+	if cfg != nil {
+		return cfg.GetTimeout()
 	}
+	return config.DefaultTimeout
+}
 
-	// Single file
-	wf, err := workflow.LoadWorkflow(path)
-	if err != nil {
-		return nil, err
+// printCacheStats prints GitHub API cache statistics to w if any calls were made,
+// including the remaining rate limit budget when GitHub reported it.
+func printCacheStats(w io.Writer, stats actions.CacheStats) {
+	if stats.Hits+stats.Misses == 0 {
+		return
 	}
-	return []*workflow.Workflow{wf}, nil
+	fmt.Fprintf(w, "\n%s\n", formatCacheStats(stats))
 }
 
-// printCacheStats prints GitHub API cache statistics if any calls were made.
-func printCacheStats(hits, misses int64) {
-	total := hits + misses
-	if total > 0 {
-		fmt.Printf("\nGitHub API: %d call(s), %d from cache\n", misses, hits)
+// formatCacheStats formats GitHub API cache statistics as a single line.
+func formatCacheStats(stats actions.CacheStats) string {
+	line := fmt.Sprintf("GitHub API: %d call(s), %d from cache", stats.Misses, stats.Hits)
+	if stats.RateLimit > 0 {
+		line += fmt.Sprintf(" (rate limit: %d/%d remaining)", stats.RateRemaining, stats.RateLimit)
 	}
+	return line
 }
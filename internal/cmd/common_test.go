@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestResolveTimeout(t *testing.T) {
+	cfg := &config.Config{Run: &config.RunConfig{Timeout: "2m"}}
+
+	tests := []struct {
+		name     string
+		override time.Duration
+		cfg      *config.Config
+		expected time.Duration
+	}{
+		{"flag overrides config", 30 * time.Second, cfg, 30 * time.Second},
+		{"config without flag", 0, cfg, 2 * time.Minute},
+		{"default without flag or config", 0, nil, config.DefaultTimeout},
+		{"default with empty config", 0, &config.Config{}, config.DefaultTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveTimeout(tt.override, tt.cfg); got != tt.expected {
+				t.Errorf("resolveTimeout() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateTimeoutContext_FlagAbortsSlowOperation(t *testing.T) {
+	t.Chdir(t.TempDir())
+	timeoutFlag = 20 * time.Millisecond
+	t.Cleanup(func() { timeoutFlag = 0 })
+
+	ctx, cancel := createTimeoutContext("")
+	defer cancel()
+
+	start := time.Now()
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow operation was not aborted by --timeout")
+	}
+
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("ctx.Err() = %v, want %v", ctx.Err(), context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("operation aborted after %v, want well under the config default", elapsed)
+	}
+}
+
+func TestValidateTimeoutFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"not set", nil, false},
+		{"positive", []string{"--timeout", "30s"}, false},
+		{"zero", []string{"--timeout", "0s"}, true},
+		{"negative", []string{"--timeout", "-1m"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() { timeoutFlag = 0 })
+			cmd := &cobra.Command{}
+			addTimeoutFlag(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			err := validateTimeoutFlag(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTimeoutFlag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
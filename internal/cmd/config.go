@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration",
+	Long: `Print the configuration in effect as YAML, with every unset value filled in
+with its default: the run timeout and exit code, all linter settings, the
+severity of each linter, and the upgrade settings.
+
+The configuration is loaded the same way as for lint: from --config, the
+GITHUB_CI_CONFIG environment variable, or the nearest .github-ci.yaml or
+.github-ci.yml in the current directory or its parents. A header comment lists
+the linters that will run.`,
+	Args:         cobra.NoArgs,
+	RunE:         runConfigShow,
+	SilenceUsage: true,
+}
+
+func init() {
+	configShowCmd.Flags().StringVarP(&configFlag, "config", "c", "", configFlagUsage)
+	configCmd.AddCommand(configShowCmd)
+}
+
+func runConfigShow(_ *cobra.Command, _ []string) error {
+	cfg, err := config.LoadConfig(configFlag)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := printConfig(os.Stdout, cfg); err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+// printConfig writes the resolved config to w as YAML, preceded by a comment
+// listing the enabled linters.
+func printConfig(w io.Writer, cfg *config.Config) error {
+	data, err := yaml.Marshal(cfg.Resolved())
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var enabled []string
+	for _, name := range config.AllLinters() {
+		if cfg.IsLinterEnabled(name) {
+			enabled = append(enabled, name)
+		}
+	}
+	fmt.Fprintf(w, "# Enabled linters: %s\n", strings.Join(enabled, ", "))
+	_, err = w.Write(data)
+	return err
+}
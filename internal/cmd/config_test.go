@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+)
+
+func TestPrintConfig(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Linters.Default = "none"
+	cfg.Linters.Enable = []string{config.LinterStyle, config.LinterHosts}
+
+	var buf bytes.Buffer
+	if err := printConfig(&buf, cfg); err != nil {
+		t.Fatalf("printConfig() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"# Enabled linters: style, hosts\n",
+		"timeout: 5m0s",
+		"issues-exit-code: 1",
+		"min-name-length: 3",
+		"format: tag",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("printConfig() output is missing %q:\n%s", want, output)
+		}
+	}
+}
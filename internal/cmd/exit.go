@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes of the CLI. When lint finds issues, it exits with the configured
+// run.issues-exit-code instead (default: 1).
+const (
+	exitOK       = 0 // the command succeeded and lint found no issues
+	exitUsage    = 2 // invalid flags, arguments, or configuration
+	exitInternal = 3 // loading workflows, the GitHub API, or writing output failed
+)
+
+// exitError is an error that ends the command with a specific exit code.
+// An exitError without an underlying error only sets the exit code, since the
+// outcome was already reported (e.g., the issues found by lint).
+type exitError struct {
+	code int
+	err  error
+}
+
+// Error implements error.
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit code %d", e.code)
+	}
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// internalError marks err as a failure of the tool rather than of its input, so
+// the command exits with exitInternal.
+func internalError(err error) error {
+	return &exitError{code: exitInternal, err: err}
+}
+
+// exitCode returns the exit code for an error returned by a command: exitOK for nil,
+// the code of a wrapped exitError, and exitUsage otherwise, since errors that are not
+// marked come from validating flags, arguments, and configuration.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return exitUsage
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/spf13/pflag"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: exitOK},
+		{name: "unmarked error", err: errors.New("invalid format"), want: exitUsage},
+		{name: "internal error", err: internalError(errors.New("network")), want: exitInternal},
+		{name: "wrapped internal error", err: fmt.Errorf("failed: %w", internalError(errors.New("io"))),
+			want: exitInternal},
+		{name: "issues", err: &exitError{code: 7}, want: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_LintExitCodes(t *testing.T) {
+	workflowsDir := t.TempDir()
+	clean := testutil.CreateWorkflow(t, workflowsDir, "clean.yml",
+		"on: push\npermissions: read-all\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+	missing := testutil.CreateWorkflow(t, workflowsDir, "missing.yml",
+		"on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+
+	permissionsOnly := testutil.CreateConfig(t, t.TempDir(), "linters:\n  default: none\n  enable:\n    - permissions\n")
+	customExitCode := testutil.CreateConfig(t, t.TempDir(),
+		"linters:\n  default: none\n  enable:\n    - permissions\nrun:\n  issues-exit-code: 7\n")
+	invalidConfig := testutil.CreateConfig(t, t.TempDir(), "linters:\n  default: some\n")
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "clean", args: []string{clean, "--config", permissionsOnly}, want: exitOK},
+		{name: "issues", args: []string{missing, "--config", permissionsOnly}, want: 1},
+		{name: "custom issues exit code", args: []string{missing, "--config", customExitCode}, want: 7},
+		{name: "invalid flag value", args: []string{clean, "--format", "xml"}, want: exitUsage},
+		{name: "unknown flag", args: []string{clean, "--no-such-flag"}, want: exitUsage},
+		{name: "invalid config", args: []string{clean, "--config", invalidConfig}, want: exitUsage},
+		{name: "missing path", args: []string{filepath.Join(workflowsDir, "missing"), "--config", permissionsOnly},
+			want: exitInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(resetLintFlags)
+			rootCmd.SetArgs(append([]string{"lint", "--quiet", "--no-api"}, tt.args...))
+			t.Cleanup(func() { rootCmd.SetArgs(nil) })
+
+			if got := Execute(); got != tt.want {
+				t.Errorf("Execute() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// resetLintFlags restores the lint flags to their defaults after a command run.
+func resetLintFlags() {
+	lintCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			_ = slice.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
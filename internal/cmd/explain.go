@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [linter | rule-id]",
+	Short: "Describe a rule and how to fix its issues",
+	Long: `Print why the issues reported by a rule matter, how to fix them, and an
+example of a workflow before and after the fix. The rule ID is the one in the
+rule_id field of JSON output, e.g., "format/line-length" or "syntax/invalid-yaml".
+
+With a linter name, as shown in parentheses in lint output (e.g., "permissions"),
+list the rules the linter reports. Without an argument, list the linters with a
+short description of each.`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runExplain,
+	SilenceUsage: true,
+}
+
+func runExplain(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		printRuleList(os.Stdout)
+		return nil
+	}
+
+	if rule, ok := linter.LookupRule(args[0]); ok {
+		printRule(os.Stdout, rule)
+		return nil
+	}
+	if slices.Contains(ruleNames(), args[0]) {
+		printLinterRules(os.Stdout, args[0])
+		return nil
+	}
+	return fmt.Errorf("unknown linter or rule %q (must be one of %v, or a rule ID such as %q)",
+		args[0], ruleNames(), "format/line-length")
+}
+
+// ruleNames returns the names of all linters, plus the syntax rule for invalid YAML.
+func ruleNames() []string {
+	return append(config.AllLinters(), linter.SyntaxLinter)
+}
+
+// printRuleList writes each linter name with its short description to w.
+func printRuleList(w io.Writer) {
+	names := ruleNames()
+	width := 0
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+	for _, name := range names {
+		fmt.Fprintf(w, "%-*s  %s\n", width, name, linter.Description(name))
+	}
+	fmt.Fprintln(w, "\nRun 'github-ci explain <linter>' to list the rules of a linter.")
+}
+
+// printLinterRules writes the description and default severity of a linter, followed by
+// the ID and description of each rule it reports, to w.
+func printLinterRules(w io.Writer, linterName string) {
+	fmt.Fprintf(w, "%s: %s\n", linterName, linter.Description(linterName))
+	fmt.Fprintf(w, "Default severity: %s\n", defaultSeverity(linterName))
+
+	rules := linter.LinterRules(linterName)
+	width := 0
+	for _, rule := range rules {
+		width = max(width, len(rule.ID))
+	}
+	fmt.Fprintln(w, "\nRules:")
+	for _, rule := range rules {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, rule.ID, rule.Description)
+	}
+	fmt.Fprintln(w, "\nRun 'github-ci explain <rule-id>' for details.")
+}
+
+// printRule writes the description, default severity, rationale, fix, and example of
+// a rule to w.
+func printRule(w io.Writer, rule linter.Rule) {
+	fmt.Fprintf(w, "%s: %s\n", rule.ID, rule.Description)
+	fmt.Fprintf(w, "Linter: %s\n", rule.Linter)
+	fmt.Fprintf(w, "Default severity: %s\n", defaultSeverity(rule.Linter))
+	writeSection(w, "Why it matters:", rule.Rationale)
+	writeSection(w, "How to fix:", rule.Fix)
+	writeSection(w, "Before:", rule.Before)
+	writeSection(w, "After:", rule.After)
+	if rule.DocsURL != "" {
+		fmt.Fprintf(w, "\nSee %s\n", rule.DocsURL)
+	}
+}
+
+// defaultSeverity returns the severity of a linter's issues with the default config.
+// Invalid YAML is always an error.
+func defaultSeverity(linterName string) string {
+	if linterName == linter.SyntaxLinter {
+		return config.SeverityError
+	}
+	return config.NewDefaultConfig().GetSeverity(linterName)
+}
+
+// writeSection writes a header followed by text indented by two spaces.
+func writeSection(w io.Writer, header, text string) {
+	fmt.Fprintf(w, "\n%s\n", header)
+	for line := range strings.SplitSeq(text, "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+}
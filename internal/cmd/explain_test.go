@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+func TestPrintRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		ruleID   string
+		expected []string
+	}{
+		{
+			name:   "rule",
+			ruleID: "injection/run",
+			expected: []string{
+				"injection/run: Untrusted expression expanded in a run script\n",
+				"Linter: injection\n",
+				"Default severity: error\n",
+				"\nWhy it matters:\n  Expressions are expanded before the shell runs",
+				"\nBefore:\n  - run: echo \"${{ github.event.issue.title }}\"\n",
+				"\nAfter:\n  - run: echo \"$TITLE\"\n    env:\n",
+				"\nSee https://reugn.github.io/github-ci/linters/injection\n",
+			},
+		},
+		{
+			name:     "syntax",
+			ruleID:   "syntax/invalid-yaml",
+			expected: []string{"syntax/invalid-yaml: Workflow file that is not valid YAML\n", "Default severity: error\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := linter.LookupRule(tt.ruleID)
+			if !ok {
+				t.Fatalf("LookupRule(%q) found no rule", tt.ruleID)
+			}
+
+			var buf bytes.Buffer
+			printRule(&buf, rule)
+			output := buf.String()
+			for _, want := range tt.expected {
+				if !strings.Contains(output, want) {
+					t.Errorf("printRule() output is missing %q:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintRuleList(t *testing.T) {
+	var buf bytes.Buffer
+	printRuleList(&buf)
+
+	output := buf.String()
+	for _, want := range []string{
+		"versions           Actions using version tags instead of commit hashes\n",
+		"syntax             Workflow files that are not valid YAML or name unknown linters in markers\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("printRuleList() output is missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintLinterRules(t *testing.T) {
+	var buf bytes.Buffer
+	printLinterRules(&buf, config.LinterFormat)
+
+	output := buf.String()
+	for _, want := range []string{
+		"format: Formatting issues (indentation, line length, trailing whitespace)\n",
+		"Default severity: warning\n",
+		"\nRules:\n",
+		"  format/line-length          Line longer than the configured maximum length\n",
+		"  format/trailing-whitespace  Line with trailing whitespace\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("printLinterRules() output is missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunExplain_UnknownLinter(t *testing.T) {
+	err := runExplain(explainCmd, []string{"unknown"})
+	if err == nil || !strings.Contains(err.Error(), `unknown linter or rule "unknown"`) {
+		t.Errorf("runExplain() error = %v, want unknown linter", err)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+// githubReporter writes the remaining issues as GitHub Actions workflow commands,
+// which a workflow run shows as annotations on the lines of the workflow files.
+type githubReporter struct {
+	w    io.Writer
+	info io.Writer
+}
+
+// Report implements Reporter.
+func (r *githubReporter) Report(result *LintResult) error {
+	writeFixStats(r.info, result)
+	writeAnnotations(r.w, result.Issues)
+	fmt.Fprintln(r.w, formatIssueSummary(result.Issues))
+	return nil
+}
+
+// writeAnnotations writes an annotation workflow command per issue to w, e.g.,
+// "::error file=.github/workflows/ci.yml,line=8,title=permissions::Job 'build' is ...".
+func writeAnnotations(w io.Writer, issues []*linter.Issue) {
+	for _, issue := range issues {
+		props := []string{"file=" + escapeAnnotationProperty(filepath.ToSlash(issue.Path()))}
+		if issue.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", issue.Line))
+			if issue.Column > 0 {
+				props = append(props, fmt.Sprintf("col=%d", issue.Column))
+			}
+		}
+		props = append(props, "title="+escapeAnnotationProperty(issue.Linter))
+
+		fmt.Fprintf(w, "::%s %s::%s\n", annotationLevel(issue.Severity), strings.Join(props, ","),
+			escapeAnnotationData(issue.Message))
+	}
+}
+
+// annotationLevel maps an issue severity to a workflow command.
+func annotationLevel(severity string) string {
+	switch severity {
+	case config.SeverityWarning:
+		return "warning"
+	case config.SeverityInfo:
+		return "notice"
+	default:
+		return "error"
+	}
+}
+
+// escapeAnnotationData escapes the message of a workflow command, which ends at a newline.
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command, which also
+// ends at a comma or colon.
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+func TestWriteAnnotations(t *testing.T) {
+	issues := []*linter.Issue{
+		{File: "ci.yml", FullPath: ".github/workflows/ci.yml", Line: 0, Linter: config.LinterPermissions,
+			Severity: config.SeverityError, Message: "Workflow is missing permissions configuration"},
+		{File: "ci.yml", FullPath: ".github/workflows/ci.yml", Line: 15, Column: 9, Linter: config.LinterVersions,
+			Severity: config.SeverityWarning, Message: "Action uses version tag 'v3'"},
+		{File: "a,b.yml", Line: 3, Linter: config.LinterStyle, Severity: config.SeverityInfo,
+			Message: "100% of steps\nare unnamed"},
+	}
+
+	var buf bytes.Buffer
+	writeAnnotations(&buf, issues)
+
+	expected := "::error file=.github/workflows/ci.yml,title=permissions::" +
+		"Workflow is missing permissions configuration\n" +
+		"::warning file=.github/workflows/ci.yml,line=15,col=9,title=versions::Action uses version tag 'v3'\n" +
+		"::notice file=a%2Cb.yml,line=3,title=style::100%25 of steps%0Aare unnamed\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("writeAnnotations() output:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestGitHubReporter_Report(t *testing.T) {
+	result := &LintResult{
+		Fix:        true,
+		Passes:     1,
+		CacheStats: actions.CacheStats{Misses: 1},
+		Fixed:      []*linter.Issue{{File: "ci.yml", Line: 8, Linter: config.LinterVersions, Message: "Pinned"}},
+		Issues: []*linter.Issue{{File: "ci.yml", FullPath: "ci.yml", Line: 3, Linter: config.LinterStyle,
+			Severity: config.SeverityWarning, Message: "Step is missing a name"}},
+	}
+
+	var out, info bytes.Buffer
+	reporter, err := newReporter(formatGitHub, &out, &info)
+	if err != nil {
+		t.Fatalf("newReporter() error = %v", err)
+	}
+	if err := reporter.Report(result); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	// Fixed issues aren't annotated; fix statistics go to info
+	wantOut := "::warning file=ci.yml,line=3,title=style::Step is missing a name\n1 issue(s) (warning: 1).\n"
+	if got := out.String(); got != wantOut {
+		t.Errorf("Report() output = %q, want %q", got, wantOut)
+	}
+	if got := info.String(); !strings.HasPrefix(got, "\nGitHub API: 1 call(s), 0 from cache\n") {
+		t.Errorf("Report() info = %q, want cache statistics", got)
+	}
+}
@@ -3,9 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"unicode"
 
+	"github.com/reugn/github-ci/internal/actions"
 	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/osutil"
+	"github.com/reugn/github-ci/internal/version"
 	"github.com/reugn/github-ci/internal/workflow"
 	"github.com/spf13/cobra"
 )
@@ -13,6 +16,9 @@ import (
 var (
 	updateFlag   bool
 	defaultsFlag bool
+	fromFlag     string
+	forceFlag    bool
+	commentsFlag bool
 )
 
 var initCmd = &cobra.Command{
@@ -23,31 +29,58 @@ var initCmd = &cobra.Command{
 If the configuration file already exists:
   - Without --update: fails with an error
   - With --update: adds any new actions found in workflows to the config
+  - With --force: replaces it with a newly generated config
 
 Use --defaults to include all linter settings and scan workflows to discover
-actions with default version constraints.`,
+actions with default version constraints.
+
+Use --comments to write all default settings, including the run settings, with a
+comment above each one explaining what it does. It implies --defaults.
+
+Use --from to discover actions from an existing workflow directory, with each
+constraint pinned to the major version currently in use (e.g., "@v4" becomes
+"^4.0.0").`,
 	RunE:         runInit,
 	SilenceUsage: true,
 }
 
 func init() {
 	addCommonFlags(initCmd)
+	// init writes the config file, so it isn't searched for in parent directories
+	initCmd.Flags().Lookup("config").Usage = "Path to configuration file (default \"" +
+		config.DefaultConfigFileName + "\")"
 	initCmd.Flags().BoolVarP(&updateFlag, "update", "u", false,
 		"Update existing config with new actions from workflows")
 	initCmd.Flags().BoolVarP(&defaultsFlag, "defaults", "d", false,
 		"Include all linter settings and discover actions from workflows")
+	initCmd.Flags().StringVar(&fromFlag, "from", "",
+		"Discover actions from workflows in this directory, pinned to their current major versions")
+	initCmd.Flags().BoolVarP(&forceFlag, "force", "f", false,
+		"Overwrite an existing config file")
+	initCmd.Flags().BoolVar(&commentsFlag, "comments", false,
+		"Write all default settings with a comment explaining each (implies --defaults)")
 }
 
 func runInit(_ *cobra.Command, _ []string) error {
-	configExists := osutil.FileExists(configFlag)
+	if forceFlag && updateFlag {
+		return fmt.Errorf("--force cannot be combined with --update")
+	}
+	if commentsFlag && updateFlag {
+		return fmt.Errorf("--comments cannot be combined with --update")
+	}
+
+	configFile := configFileOrDefault()
+	// With --force, an existing config is replaced as if it didn't exist
+	configExists := osutil.FileExists(configFile) && !forceFlag
 
 	// Check if config exists and we're not updating
 	if configExists && !updateFlag {
-		return fmt.Errorf("config file %s already exists (use --update to add new actions)", configFlag)
+		return fmt.Errorf("config file %s already exists (use --update to add new actions or --force to overwrite)",
+			configFile)
 	}
 
 	// Load or create the configuration file
-	cfg, err := resolveConfig(configExists)
+	cfg, err := resolveConfig(configFile, configExists)
 	if err != nil {
 		return err
 	}
@@ -59,25 +92,29 @@ func runInit(_ *cobra.Command, _ []string) error {
 	}
 
 	// Save the config
-	if err := config.SaveConfig(cfg, configFlag); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
+	if err := saveConfig(cfg, configFile); err != nil {
+		return internalError(fmt.Errorf("failed to save config: %w", err))
 	}
 
 	// Print the result
-	printResult(configExists, newActions)
+	printResult(configFile, configExists, newActions)
 
 	return nil
 }
 
 // resolveConfig returns the appropriate config based on flags and file existence.
-func resolveConfig(exists bool) (*config.Config, error) {
+func resolveConfig(configFile string, exists bool) (*config.Config, error) {
 	switch {
 	case exists:
-		cfg, err := config.LoadConfig(configFlag)
+		cfg, err := config.LoadConfig(configFile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load existing config: %w", err)
 		}
 		return cfg, nil
+	case commentsFlag:
+		cfg := config.NewFullDefaultConfig()
+		cfg.Run = config.DefaultRunConfig()
+		return cfg, nil
 	case defaultsFlag:
 		return config.NewFullDefaultConfig(), nil
 	default:
@@ -85,33 +122,47 @@ func resolveConfig(exists bool) (*config.Config, error) {
 	}
 }
 
+// saveConfig writes the config to configFile, with explanatory comments if --comments is set.
+func saveConfig(cfg *config.Config, configFile string) error {
+	if commentsFlag {
+		return config.SaveCommentedConfig(cfg, configFile)
+	}
+	return config.SaveConfig(cfg, configFile)
+}
+
 // printResult outputs the init command result.
-func printResult(configExists bool, newActions []string) {
+func printResult(configFile string, configExists bool, newActions []string) {
 	switch {
 	case configExists && len(newActions) > 0:
-		fmt.Printf("✓ Updated %s with %d new action(s):\n", configFlag, len(newActions))
+		fmt.Printf("✓ Updated %s with %d new action(s):\n", configFile, len(newActions))
 		for _, action := range newActions {
 			fmt.Printf("  - %s\n", action)
 		}
 	case configExists:
 		fmt.Printf("✓ No new actions found in workflows\n")
 	case len(newActions) > 0:
-		fmt.Printf("✓ Created %s with %d action(s)\n", configFlag, len(newActions))
+		fmt.Printf("✓ Created %s with %d action(s)\n", configFile, len(newActions))
 	default:
-		fmt.Printf("✓ Created %s\n", configFlag)
+		fmt.Printf("✓ Created %s\n", configFile)
 	}
 }
 
-// scanActions discovers actions from workflows when --defaults or --update is set.
+// scanActions discovers actions from workflows when --defaults, --comments, --update, or
+// --from is set. The --from directory takes precedence over --path.
 func scanActions(cfg *config.Config, configExists bool) ([]string, error) {
-	if !defaultsFlag && !updateFlag {
+	if !defaultsFlag && !commentsFlag && !updateFlag && fromFlag == "" {
 		return nil, nil
 	}
 
-	workflows, err := loadWorkflows(pathFlag)
+	path := pathFlag
+	if fromFlag != "" {
+		path = fromFlag
+	}
+
+	workflows, err := workflow.LoadPath(path)
 	if err != nil {
-		if configExists {
-			return nil, fmt.Errorf("failed to load workflows: %w", err)
+		if configExists || fromFlag != "" {
+			return nil, internalError(fmt.Errorf("failed to load workflows: %w", err))
 		}
 		return nil, nil
 	}
@@ -139,7 +190,7 @@ func discoverActions(cfg *config.Config, workflows []*workflow.Workflow) []strin
 
 			// Add action if not already configured
 			if cfg.Upgrade.Actions[name].Constraint == "" {
-				cfg.SetActionConfig(name, config.DefaultActionConfig)
+				cfg.SetActionConfig(name, actionConfigFor(action.Uses))
 				newActions = append(newActions, name)
 			}
 		}
@@ -147,3 +198,24 @@ func discoverActions(cfg *config.Config, workflows []*workflow.Workflow) []strin
 
 	return newActions
 }
+
+// actionConfigFor returns the config for a newly discovered action. With --from, the
+// constraint is pinned to the major version in use; hashes and branches get the default.
+func actionConfigFor(uses string) config.ActionConfig {
+	if fromFlag == "" {
+		return config.DefaultActionConfig
+	}
+
+	info, err := actions.ParseActionUses(uses)
+	if err != nil || !isVersionRef(info.Ref) {
+		return config.DefaultActionConfig
+	}
+
+	return config.ActionConfig{Constraint: fmt.Sprintf("^%d.0.0", version.ExtractMajor(info.Ref))}
+}
+
+// isVersionRef reports whether ref is a version tag such as "v4" or "v4.1.2".
+func isVersionRef(ref string) bool {
+	ref = version.Normalize(ref)
+	return ref != "" && unicode.IsDigit(rune(ref[0])) && !actions.IsCommitHash(ref)
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/testutil"
+)
+
+func TestActionConfigFor(t *testing.T) {
+	fromFlag = ".github/workflows"
+	t.Cleanup(func() { fromFlag = "" })
+
+	tests := []struct {
+		name     string
+		uses     string
+		expected string
+	}{
+		{"major tag", "actions/checkout@v4", "^4.0.0"},
+		{"full tag", "actions/setup-go@v5.2.0", "^5.0.0"},
+		{"tag without prefix", "owner/repo@2.1", "^2.0.0"},
+		{"composite action", "github/codeql-action/upload-sarif@v3", "^3.0.0"},
+		{"commit hash", "actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab", config.DefaultActionConfig.Constraint},
+		{"branch", "owner/repo@main", config.DefaultActionConfig.Constraint},
+		{"no ref", "owner/repo", config.DefaultActionConfig.Constraint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := actionConfigFor(tt.uses).Constraint; got != tt.expected {
+				t.Errorf("actionConfigFor(%q) = %q, want %q", tt.uses, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunInit_From(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowsDir := filepath.Join(tmpDir, "workflows")
+	if err := os.Mkdir(workflowsDir, 0750); err != nil {
+		t.Fatalf("Mkdir() error = %v", err)
+	}
+	testutil.CreateWorkflow(t, workflowsDir, "ci.yml", `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5.2.0
+`)
+	testutil.CreateWorkflow(t, workflowsDir, "release.yml", `on: push
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: goreleaser/goreleaser-action@8e5e7e5ab8b370d6c329ec480221332ada57f0ab
+`)
+
+	configFlag = filepath.Join(tmpDir, config.DefaultConfigFileName)
+	fromFlag = workflowsDir
+	t.Cleanup(func() {
+		configFlag = ""
+		fromFlag = ""
+	})
+
+	if err := runInit(nil, nil); err != nil {
+		t.Fatalf("runInit() error = %v", err)
+	}
+
+	cfg, err := config.LoadConfig(configFlag)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"actions/checkout":             "^4.0.0",
+		"actions/setup-go":             "^5.0.0",
+		"goreleaser/goreleaser-action": config.DefaultActionConfig.Constraint,
+	}
+	if len(cfg.Upgrade.Actions) != len(expected) {
+		t.Errorf("config has %d action(s), want %d", len(cfg.Upgrade.Actions), len(expected))
+	}
+	for name, constraint := range expected {
+		if got := cfg.Upgrade.Actions[name].Constraint; got != constraint {
+			t.Errorf("constraint for %s = %q, want %q", name, got, constraint)
+		}
+	}
+}
+
+func TestRunInit_FromMissingDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFlag = filepath.Join(tmpDir, config.DefaultConfigFileName)
+	fromFlag = filepath.Join(tmpDir, "missing")
+	t.Cleanup(func() {
+		configFlag = ""
+		fromFlag = ""
+	})
+
+	if err := runInit(nil, nil); err == nil {
+		t.Error("runInit() with missing --from directory should fail")
+	}
+}
+
+func TestRunInit_Force(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFlag = testutil.CreateConfig(t, tmpDir, "run:\n  issues-exit-code: 9\n")
+	pathFlag = filepath.Join(tmpDir, "missing")
+	t.Cleanup(func() {
+		configFlag = ""
+		pathFlag = ".github/workflows"
+		forceFlag = false
+		updateFlag = false
+	})
+
+	if err := runInit(nil, nil); err == nil {
+		t.Fatal("runInit() should refuse to overwrite an existing config")
+	}
+
+	forceFlag = true
+	updateFlag = true
+	if err := runInit(nil, nil); err == nil {
+		t.Error("runInit() should reject --force with --update")
+	}
+
+	updateFlag = false
+	if err := runInit(nil, nil); err != nil {
+		t.Fatalf("runInit() --force error = %v", err)
+	}
+
+	cfg, err := config.LoadConfig(configFlag)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := cfg.GetIssuesExitCode(); got != 1 {
+		t.Errorf("GetIssuesExitCode() after --force = %d, want 1", got)
+	}
+}
+
+func TestRunInit_Comments(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFlag = filepath.Join(tmpDir, config.DefaultConfigFileName)
+	pathFlag = filepath.Join(tmpDir, "missing")
+	t.Cleanup(func() {
+		configFlag = ""
+		pathFlag = ".github/workflows"
+		commentsFlag = false
+		updateFlag = false
+	})
+
+	commentsFlag = true
+	updateFlag = true
+	if err := runInit(nil, nil); err == nil {
+		t.Error("runInit() should reject --comments with --update")
+	}
+
+	updateFlag = false
+	if err := runInit(nil, nil); err != nil {
+		t.Fatalf("runInit() --comments error = %v", err)
+	}
+
+	data, err := os.ReadFile(configFlag)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	for _, want := range []string{"# Maximum duration of a command", "timeout: 5m0s", "# Minimum characters in"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("config does not contain %q:\n%s", want, data)
+		}
+	}
+
+	cfg, err := config.LoadConfig(configFlag)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Linters.Settings == nil || cfg.Linters.Settings.Style == nil {
+		t.Error("config from --comments should include all linter settings")
+	}
+}
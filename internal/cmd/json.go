@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+// jsonReport is the top-level document written by --format json.
+type jsonReport struct {
+	Issues  []*linter.Issue  `json:"issues"` // Issues remaining after any fixes
+	Fixed   []*linter.Issue  `json:"fixed"`  // Issues resolved by --fix (empty without --fix)
+	Summary jsonSummary      `json:"summary"`
+	Actions []*actionSummary `json:"actions,omitempty"` // Pin status per action, with --actions-summary
+}
+
+// jsonSummary aggregates counts for the remaining issues.
+type jsonSummary struct {
+	Total      int            `json:"total"`
+	Fixed      int            `json:"fixed"`
+	ByLinter   map[string]int `json:"by_linter"`
+	BySeverity map[string]int `json:"by_severity"`
+}
+
+// writeJSON serializes fixed and remaining issues as a JSON report to w.
+// The actions section is included only when summaries is non-nil.
+func writeJSON(w io.Writer, fixed, issues []*linter.Issue, summaries []*actionSummary) error {
+	report := jsonReport{
+		Issues: nonNilIssues(issues),
+		Fixed:  nonNilIssues(fixed),
+		Summary: jsonSummary{
+			Total:      len(issues),
+			Fixed:      len(fixed),
+			ByLinter:   make(map[string]int),
+			BySeverity: make(map[string]int),
+		},
+		Actions: summaries,
+	}
+
+	for _, issue := range issues {
+		report.Summary.ByLinter[issue.Linter]++
+		report.Summary.BySeverity[issue.Severity]++
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// nonNilIssues returns issues, or an empty slice so it encodes as [] rather than null.
+func nonNilIssues(issues []*linter.Issue) []*linter.Issue {
+	if issues == nil {
+		return []*linter.Issue{}
+	}
+	return issues
+}
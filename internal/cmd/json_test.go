@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+func TestWriteJSON(t *testing.T) {
+	fixed := []*linter.Issue{
+		{File: "ci.yml", Line: 8, Linter: config.LinterFormat, Message: "Line has trailing whitespace"},
+	}
+	issues := []*linter.Issue{
+		{File: "ci.yml", Line: 0, Linter: config.LinterPermissions, Message: "Workflow is missing permissions configuration"},
+		{File: "ci.yml", Line: 3, Linter: config.LinterStyle, Message: "Step is missing a name"},
+		{File: "ci.yml", Line: 9, Linter: config.LinterStyle, Message: "Step is missing a name"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, fixed, issues, nil); err != nil {
+		t.Fatalf("writeJSON() error = %v", err)
+	}
+
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if len(report.Issues) != 3 || len(report.Fixed) != 1 {
+		t.Errorf("issues/fixed lengths = %d/%d, want 3/1", len(report.Issues), len(report.Fixed))
+	}
+	if report.Summary.Total != 3 || report.Summary.Fixed != 1 {
+		t.Errorf("summary total/fixed = %d/%d, want 3/1", report.Summary.Total, report.Summary.Fixed)
+	}
+	if got := report.Summary.ByLinter[config.LinterStyle]; got != 2 {
+		t.Errorf("summary by_linter[style] = %d, want 2", got)
+	}
+
+	first := report.Issues[0]
+	if first.File != "ci.yml" || first.Linter != config.LinterPermissions || first.Message == "" {
+		t.Errorf("first issue = %+v, want all fields populated", first)
+	}
+}
+
+func TestWriteJSON_EmptyArrays(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, nil, nil, nil); err != nil {
+		t.Fatalf("writeJSON() error = %v", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	for _, key := range []string{"issues", "fixed"} {
+		if string(raw[key]) != "[]" {
+			t.Errorf("%s = %s, want []", key, raw[key])
+		}
+	}
+}
@@ -1,19 +1,62 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/reugn/github-ci/internal/actions"
 	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/diffutil"
 	"github.com/reugn/github-ci/internal/linter"
 	"github.com/reugn/github-ci/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
-var fixFlag bool
+// Supported output formats for the lint command.
+const (
+	formatText   = "text"
+	formatJSON   = "json"
+	formatSARIF  = "sarif"
+	formatGitHub = "github"
+)
+
+// lintFormats lists the valid values for the --format flag.
+var lintFormats = []string{formatText, formatJSON, formatSARIF, formatGitHub}
+
+// stdinPath is the path argument that reads a workflow from stdin.
+const stdinPath = "-"
+
+// defaultStdinFilename names a workflow read from stdin when --stdin-filename is not set.
+const defaultStdinFilename = "<stdin>"
+
+var (
+	fixFlag             bool
+	formatFlag          string
+	maxPassesFlag       int
+	failOnFlag          string
+	baselineFlag        string
+	writeBaselineFlag   bool
+	noAPIFlag           bool
+	diffFlag            bool
+	actionsSummaryFlag  bool
+	stdinFilenameFlag   string
+	suggestUpgradesFlag bool
+	watchFlag           bool
+	onlyFlag            []string
+	skipFlag            []string
+	quietFlag           bool
+	verboseFlag         bool
+	groupByFileFlag     bool
+	maxIssuesFlag       int
+)
 
 var lintCmd = &cobra.Command{
-	Use:   "lint [path]",
+	Use:   "lint [path...]",
 	Short: "Lint GitHub Actions workflows",
 	Long: `Analyze workflows for common issues using configurable linters:
 - permissions: Missing permissions configuration
@@ -21,9 +64,24 @@ var lintCmd = &cobra.Command{
 - format: Formatting issues (indentation, line length, trailing whitespace)
 - secrets: Hardcoded secrets and sensitive information
 - injection: Shell injection vulnerabilities from untrusted input
-
-The path can be a directory (e.g., .github/workflows) or a specific workflow file.
-If no path is provided, defaults to .github/workflows.
+- style: Naming conventions and style best practices
+- runners: Deprecated or floating runner labels in runs-on
+- needs: Job needs referencing unknown jobs or forming cycles
+- hosts: Hardcoded internal IP addresses and hostnames (opt-in)
+- triggers: Unknown or risky events in the on trigger block
+- matrix: Matrix include/exclude entries with undefined axes or no jobs
+- pr-secrets: Secrets used in workflows triggered by pull_request (opt-in)
+- commands: Deprecated set-output, save-state, set-env, and add-path workflow commands
+- continue-on-error: Jobs and steps with continue-on-error: true
+- token-scopes: Token permissions missing scopes known actions need, or granting unused writes
+- cache-keys: Cache keys without a hashFiles(...) lockfile hash (opt-in)
+
+Each path can be a directory (e.g., .github/workflows), a specific workflow file,
+or a glob pattern (e.g., "**/*.yml"). Workflows matched by several paths are linted once.
+Files that are not workflows are skipped, so changed-file lists (e.g., from pre-commit)
+can be passed directly. If no path is provided, defaults to .github/workflows.
+Use "-" to read a single workflow from stdin, with --stdin-filename naming it in issues.
+With --watch, the command keeps running and re-lints whenever a workflow file changes.
 
 Configure enabled linters in .github-ci.yaml.`,
 	RunE:         runLint,
@@ -32,140 +90,465 @@ Configure enabled linters in .github-ci.yaml.`,
 
 func init() {
 	addCommonFlags(lintCmd)
+	addTimeoutFlag(lintCmd)
 	lintCmd.Flags().BoolVar(&fixFlag, "fix", false,
 		"Automatically fix issues by replacing version tags with commit hashes")
+	lintCmd.Flags().StringVar(&formatFlag, "format", formatText,
+		"Output format: text, json, sarif, or github (GitHub Actions annotations)")
+	lintCmd.Flags().IntVar(&maxPassesFlag, "max-passes", 1,
+		"Maximum number of fix passes with --fix, repeated until no fixable issues remain")
+	lintCmd.Flags().StringVar(&failOnFlag, "fail-on", config.SeverityInfo,
+		"Minimum severity that causes a non-zero exit code: error, warning, or info")
+	lintCmd.Flags().StringVar(&baselineFlag, "baseline", linter.DefaultBaselineFileName,
+		"Path to a baseline file of known issues to suppress")
+	lintCmd.Flags().BoolVar(&writeBaselineFlag, "write-baseline", false,
+		"Write all current issues to the baseline file and exit")
+	lintCmd.Flags().BoolVar(&diffFlag, "diff", false,
+		"Print the changes --fix would make as a unified diff without writing files")
+	lintCmd.Flags().BoolVar(&actionsSummaryFlag, "actions-summary", false,
+		"Print unique actions with how many usages are hash-pinned vs tag-pinned")
+	lintCmd.Flags().StringVar(&stdinFilenameFlag, "stdin-filename", "",
+		"File name to report for a workflow read from stdin (-), e.g. .github/workflows/ci.yml")
+	lintCmd.Flags().BoolVar(&suggestUpgradesFlag, "suggest-upgrades", false,
+		"Suggest the latest version allowed by each action's upgrade constraint in versions issues "+
+			"(overrides linters.settings.versions.suggest-upgrades)")
+	lintCmd.Flags().StringSliceVar(&onlyFlag, "only", nil,
+		"Run only these linters, comma-separated (overrides linters.enable and linters.disable)")
+	lintCmd.Flags().StringSliceVar(&skipFlag, "skip", nil,
+		"Skip these linters, comma-separated (--only wins for linters listed in both)")
+	lintCmd.Flags().BoolVar(&watchFlag, "watch", false,
+		"Keep running and re-lint whenever a workflow file under the paths changes")
+	lintCmd.Flags().BoolVar(&noAPIFlag, "no-api", false,
+		"Skip linters that call the GitHub API (versions) for fast, offline runs (overrides run.offline)")
+	lintCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false,
+		"Print nothing but errors; the exit code reports whether issues were found")
+	lintCmd.Flags().BoolVar(&verboseFlag, "verbose", false,
+		"Print the config source, the linters that ran, time per workflow, and GitHub API stats to stderr")
+	lintCmd.Flags().BoolVar(&groupByFileFlag, "group-by-file", false,
+		"Group text output under a header per file, with issues sorted by line")
+	lintCmd.Flags().IntVar(&maxIssuesFlag, "max-issues", 0,
+		"Print at most this many issues per section of the text output (0 = no limit); "+
+			"the summary and exit code still count all issues")
 }
 
-func runLint(_ *cobra.Command, args []string) error {
-	workflowsPath := pathFlag
-	if len(args) > 0 {
-		workflowsPath = args[0]
+func runLint(cmd *cobra.Command, args []string) error {
+	if !slices.Contains(lintFormats, formatFlag) {
+		return fmt.Errorf("invalid format %q (must be one of %v)", formatFlag, lintFormats)
+	}
+	if err := validateTimeoutFlag(cmd); err != nil {
+		return err
+	}
+	if maxPassesFlag < 1 {
+		return fmt.Errorf("invalid max-passes %d (must be at least 1)", maxPassesFlag)
+	}
+	if !config.IsValidSeverity(failOnFlag) {
+		return fmt.Errorf("invalid fail-on %q (must be one of %v)", failOnFlag, config.Severities())
+	}
+	if err := validateLinterNames("only", onlyFlag); err != nil {
+		return err
+	}
+	if err := validateLinterNames("skip", skipFlag); err != nil {
+		return err
+	}
+	if diffFlag && (fixFlag || quietFlag || formatFlag != formatText) {
+		return fmt.Errorf("--diff cannot be combined with --fix, --quiet, or --format")
+	}
+	if groupByFileFlag && (diffFlag || formatFlag != formatText) {
+		return fmt.Errorf("--group-by-file cannot be combined with --diff or --format")
+	}
+	if maxIssuesFlag < 0 {
+		return fmt.Errorf("invalid max-issues %d (must be at least 0)", maxIssuesFlag)
+	}
+	if maxIssuesFlag > 0 && (diffFlag || formatFlag != formatText) {
+		return fmt.Errorf("--max-issues cannot be combined with --diff or --format")
+	}
+	if actionsSummaryFlag && (diffFlag || formatFlag == formatSARIF || formatFlag == formatGitHub) {
+		return fmt.Errorf("--actions-summary cannot be combined with --diff, --format sarif, or --format github")
 	}
 
-	workflows, err := loadWorkflows(workflowsPath)
-	if err != nil {
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{pathFlag}
+	}
+
+	if watchFlag {
+		if err := validateWatchFlags(paths); err != nil {
+			return err
+		}
+		return watchLint(cmd.Context(), paths, configFlag)
+	}
+
+	// Files that aren't valid YAML are reported as issues rather than failing the run
+	workflows, err := loadLintPaths(cmd.InOrStdin(), paths)
+	parseErrs, onlyParse := workflow.ParseErrors(err)
+	if !onlyParse {
 		return fmt.Errorf("failed to load workflows: %w", err)
 	}
 
-	exitCode := doLint(workflows, configFlag)
-	if exitCode != 0 {
-		os.Exit(exitCode)
+	if code := doLint(workflows, parseErrs, configFlag); code != exitOK {
+		return &exitError{code: code}
+	}
+	return nil
+}
+
+// validateLinterNames checks that every name given to a linter selection flag is a known linter.
+func validateLinterNames(flag string, names []string) error {
+	for _, name := range names {
+		if !config.IsKnownLinter(name) {
+			return fmt.Errorf("unknown linter %q in --%s (must be one of %v)", name, flag, config.AllLinters())
+		}
 	}
 	return nil
 }
 
+// loadLintPaths loads the workflows to lint from paths, or a single workflow from stdin
+// if the only path is "-". A workflow from stdin is named after --stdin-filename.
+func loadLintPaths(stdin io.Reader, paths []string) ([]*workflow.Workflow, error) {
+	if !slices.Contains(paths, stdinPath) {
+		if stdinFilenameFlag != "" {
+			return nil, fmt.Errorf("--stdin-filename requires reading from stdin (%s)", stdinPath)
+		}
+		workflows, err := workflow.LoadPaths(paths)
+		if _, onlyParse := workflow.ParseErrors(err); !onlyParse {
+			err = internalError(err)
+		}
+		return workflows, err
+	}
+
+	if len(paths) > 1 {
+		return nil, fmt.Errorf("stdin (%s) cannot be combined with other paths", stdinPath)
+	}
+	if fixFlag {
+		return nil, fmt.Errorf("--fix cannot be used with stdin (%s); use --diff to preview fixes", stdinPath)
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, internalError(fmt.Errorf("failed to read stdin: %w", err))
+	}
+	name := stdinFilenameFlag
+	if name == "" {
+		name = defaultStdinFilename
+	}
+	wf, err := workflow.ParseWorkflow(name, data)
+	if err != nil {
+		return nil, err
+	}
+	if !wf.IsWorkflow() && !wf.IsCompositeAction() {
+		return nil, nil
+	}
+	return []*workflow.Workflow{wf}, nil
+}
+
 // doLint performs linting and returns the exit code.
-func doLint(workflows []*workflow.Workflow, configFile string) int {
-	ctx, cancel := createTimeoutContext(configFile)
-	defer cancel()
+func doLint(workflows []*workflow.Workflow, parseErrs []*workflow.ParseError, configFile string) int {
+	return lintWorkflows(context.Background(), workflows, parseErrs, configFile, nil)
+}
 
-	// Load config to get exit code setting
-	cfg, _ := config.LoadConfig(configFile)
+// lintWorkflows lints and reports workflows, along with an issue for each file in
+// parseErrs, and returns the exit code. The run is cancelled with parent or after the
+// configured timeout. GitHub API lookups go through cache, so they can be shared across
+// runs; a nil cache uses a new one.
+func lintWorkflows(parent context.Context, workflows []*workflow.Workflow, parseErrs []*workflow.ParseError,
+	configFile string, cache *actions.Cache) int {
+	cfg, err := config.LoadConfig(configFile)
+	if err == nil {
+		err = linter.CheckDisabledRules(cfg)
+	}
+	if err != nil {
+		printError("failed to load config: %v", err)
+		return exitUsage
+	}
 	issuesExitCode := cfg.GetIssuesExitCode()
 
-	l := linter.NewWithWorkflows(ctx, workflows, configFile)
+	ctx, cancel := context.WithTimeout(parent, resolveTimeout(timeoutFlag, cfg))
+	defer cancel()
+
+	if noAPIFlag {
+		cfg.SetOffline()
+	}
+	if suggestUpgradesFlag {
+		cfg.SetSuggestUpgrades()
+	}
+	cfg.SelectLinters(onlyFlag, skipFlag)
+	out, info := lintOutput()
+	printOfflineNote(info, linter.SkippedOffline(cfg))
+
+	l := linter.NewWithCache(ctx, workflows, cfg, cache)
+	l.SetParseErrors(parseErrs)
+
+	if writeBaselineFlag {
+		return doWriteBaseline(out, l)
+	}
+
+	baseline, err := linter.LoadBaseline(baselineFlag)
+	if err != nil {
+		printError("%v", err)
+		return exitInternal
+	}
+	l.SetBaseline(baseline)
 
 	issues, err := l.Lint()
 	if err != nil {
 		printError("failed to lint workflows: %v", err)
-		return 1
+		return exitInternal
+	}
+	if verboseFlag {
+		printVerbose(info, configFile, l)
 	}
 
-	if len(issues) == 0 {
-		fmt.Println("0 issues.")
-		return 0
+	if diffFlag {
+		return doLintDiff(l, issues, issuesExitCode)
+	}
+
+	reporter, err := newReporter(formatFlag, out, info)
+	if err != nil {
+		printError("%v", err)
+		return exitInternal
+	}
+
+	result, err := buildLintResult(l, workflows, issues)
+	if err != nil {
+		printError("%v", err)
+		return exitInternal
 	}
 
+	if err := reporter.Report(result); err != nil {
+		printError("failed to write %s output: %v", formatFlag, err)
+		return exitInternal
+	}
+
+	return exitCodeFor(result.Issues, issuesExitCode)
+}
+
+// buildLintResult applies fixes with --fix and collects the result to report.
+// Fixes are applied even without reported issues, since silent fixers never report any.
+// The actions summary is taken after fixing, so it reflects the fixed workflows.
+func buildLintResult(l *linter.WorkflowLinter, workflows []*workflow.Workflow,
+	issues []*linter.Issue) (*LintResult, error) {
+	result := &LintResult{Issues: issues, Fixable: hasFixableIssues(l, issues)}
+
 	if fixFlag {
-		return doLintWithFix(l, issues, issuesExitCode)
+		remainingIssues, passes, err := applyFixes(l, issues, maxPassesFlag)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Fix = true
+		result.Passes = passes
+		result.CacheStats = l.GetCacheStats()
+		result.Fixed, result.Issues = classifyIssues(issues, remainingIssues)
+	}
+
+	if actionsSummaryFlag {
+		summaries, err := summarizeActions(workflows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize actions: %w", err)
+		}
+		result.Actions = summaries
 	}
 
-	// Print all issues
-	printIssues("Issues:", issues)
+	return result, nil
+}
 
-	// Only suggest --fix if at least one issue can be auto-fixed
-	if hasFixableIssues(issues) {
-		fmt.Println("\nRun with --fix to automatically fix some issues")
+// lintOutput returns the writers for the lint report and for informational output,
+// which goes to stderr so structured output on stdout stays valid. With --quiet, both
+// discard everything, so only errors and the exit code report the result.
+func lintOutput() (out, info io.Writer) {
+	if quietFlag {
+		return io.Discard, io.Discard
 	}
+	return os.Stdout, os.Stderr
+}
 
-	printIssueSummary(len(issues))
-	return issuesExitCode
+// printOfflineNote reports enabled linters skipped in offline mode.
+func printOfflineNote(w io.Writer, skipped []string) {
+	if len(skipped) > 0 {
+		fmt.Fprintf(w, "Note: skipped network linters in offline mode: %s\n", strings.Join(skipped, ", "))
+	}
 }
 
-// doLintWithFix applies fixes and prints results in two sections.
-// Returns exit code 0 if all issues are fixed, issuesExitCode if some remain.
-func doLintWithFix(l *linter.WorkflowLinter, issues []*linter.Issue, issuesExitCode int) int {
-	// Apply fixes
-	if err := l.Fix(); err != nil {
-		printError("failed to fix workflows: %v", err)
-		return 1
+// printVerbose writes the config source, the linters that ran, the time taken per
+// workflow, and the GitHub API statistics, to help debug missing or slow checks.
+func printVerbose(w io.Writer, configFile string, l *linter.WorkflowLinter) {
+	source := config.Source(configFile)
+	if source == "" {
+		source = "built-in defaults"
 	}
+	fmt.Fprintf(w, "Config: %s\n", source)
 
-	// Re-lint to see what issues remain after fixing
-	remainingIssues, err := l.Lint()
-	if err != nil {
-		printError("failed to re-lint workflows: %v", err)
-		return 1
+	linters := l.EnabledLinters()
+	if len(linters) == 0 {
+		linters = []string{"none"}
 	}
+	fmt.Fprintf(w, "Linters: %s\n", strings.Join(linters, ", "))
 
-	fixed, unfixed := classifyIssues(issues, remainingIssues)
+	for _, timing := range l.Timings() {
+		fmt.Fprintf(w, "Linted %s in %s\n", timing.File, roundDuration(timing.Duration))
+	}
+	fmt.Fprintln(w, formatCacheStats(l.GetCacheStats()))
+}
 
-	printIssues("Fixed:", fixed)
-	printIssuesSeparator(fixed, unfixed)
-	printIssues("Issues:", unfixed)
+// roundDuration rounds d for display: to milliseconds, or to microseconds below a millisecond.
+func roundDuration(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(time.Millisecond)
+}
 
-	stats := l.GetCacheStats()
-	printCacheStats(stats.Hits, stats.Misses)
-	printIssueSummary(len(unfixed))
+// doWriteBaseline lints the workflows and records all current issues in the baseline file.
+func doWriteBaseline(w io.Writer, l *linter.WorkflowLinter) int {
+	issues, err := l.Lint()
+	if err != nil {
+		printError("failed to lint workflows: %v", err)
+		return exitInternal
+	}
 
-	if len(unfixed) > 0 {
-		return issuesExitCode
+	if err := linter.NewBaseline(issues).Save(baselineFlag); err != nil {
+		printError("failed to write baseline: %v", err)
+		return exitInternal
 	}
-	return 0
+
+	fmt.Fprintf(w, "✓ Wrote %d issue(s) to %s\n", len(issues), baselineFlag)
+	return exitOK
 }
 
-// printIssue prints a single issue with indentation.
-func printIssue(issue *linter.Issue) {
-	fmt.Printf("  %s\n", issue)
+// doLintDiff prints the changes fixes would make as unified diffs on stdout, leaving
+// the files untouched. The issue summary goes to stderr so the diff can be piped to patch.
+func doLintDiff(l *linter.WorkflowLinter, issues []*linter.Issue, issuesExitCode int) int {
+	fixes, err := l.ApplyFixes()
+	if err != nil {
+		printError("failed to fix workflows: %v", err)
+		return exitInternal
+	}
+
+	writeFixDiffs(os.Stdout, fixes)
+	fmt.Fprintln(os.Stderr, formatIssueSummary(issues))
+
+	return exitCodeFor(issues, issuesExitCode)
 }
 
-// printIssues prints a labeled section of issues.
-func printIssues(header string, issues []*linter.Issue) {
-	if len(issues) == 0 {
-		return
+// writeFixDiffs writes a unified diff for each fixed workflow.
+func writeFixDiffs(w io.Writer, fixes []*linter.FileFix) {
+	for _, fix := range fixes {
+		name := fix.Workflow.File
+		fmt.Fprint(w, diffutil.Unified(name, name, fix.Original, fix.Workflow.RawBytes))
 	}
+}
 
-	fmt.Println(header)
+// exitCodeFor returns issuesExitCode if any issue is at least as severe as
+// the --fail-on threshold, 0 otherwise.
+func exitCodeFor(issues []*linter.Issue, issuesExitCode int) int {
 	for _, issue := range issues {
-		printIssue(issue)
+		if config.SeverityAtLeast(issue.Severity, failOnFlag) {
+			return issuesExitCode
+		}
 	}
+	return exitOK
 }
 
-// printIssuesSeparator prints a blank line if all provided slices are non-empty.
-func printIssuesSeparator(slices ...[]*linter.Issue) {
-	for _, s := range slices {
-		if len(s) == 0 {
-			return
+// applyFixes runs the fix-then-lint loop up to maxPasses times, stopping early once
+// the issue set is stable or no fixable issues remain.
+// Returns the issues remaining after the last pass and the number of passes taken.
+func applyFixes(l *linter.WorkflowLinter, issues []*linter.Issue, maxPasses int) ([]*linter.Issue, int, error) {
+	remaining := issues
+	passes := 0
+
+	for passes < maxPasses {
+		if err := l.Fix(); err != nil {
+			return nil, passes, fmt.Errorf("failed to fix workflows: %w", err)
+		}
+		passes++
+
+		// Re-lint to see what issues remain after fixing
+		next, err := l.Lint()
+		if err != nil {
+			return nil, passes, fmt.Errorf("failed to re-lint workflows: %w", err)
+		}
+
+		stable := sameIssues(remaining, next)
+		remaining = next
+		if stable || !hasFixableIssues(l, remaining) {
+			break
+		}
+	}
+
+	return remaining, passes, nil
+}
+
+// sameIssues returns true if both slices contain the same set of issues.
+func sameIssues(a, b []*linter.Issue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	keys := make(map[string]int, len(a))
+	for _, issue := range a {
+		keys[issue.Key()]++
+	}
+	for _, issue := range b {
+		keys[issue.Key()]--
+		if keys[issue.Key()] < 0 {
+			return false
 		}
 	}
-	fmt.Println()
+	return true
 }
 
-// printIssueSummary prints the total issue count.
-func printIssueSummary(count int) {
-	fmt.Printf("\n%d issue(s).\n", count)
+// printFixPasses prints the number of fix passes taken when more than one was allowed.
+func printFixPasses(w io.Writer, passes int) {
+	if maxPassesFlag > 1 {
+		fmt.Fprintf(w, "\nApplied fixes in %d pass(es)\n", passes)
+	}
+}
+
+// formatIssueSummary returns the total issue count followed by the non-zero counts
+// per severity, e.g. "3 issue(s) (error: 1, warning: 2)."
+func formatIssueSummary(issues []*linter.Issue) string {
+	if len(issues) == 0 {
+		return "0 issue(s)."
+	}
+
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[issue.Severity]++
+	}
+
+	var parts []string
+	for _, severity := range config.Severities() {
+		if counts[severity] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", severity, counts[severity]))
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d issue(s).", len(issues))
+	}
+	return fmt.Sprintf("%d issue(s) (%s).", len(issues), strings.Join(parts, ", "))
 }
 
 // classifyIssues separates issues into fixed and unfixed based on what remains after fixing.
+// Issues are matched without their line numbers, since fixes can insert or remove lines;
+// unfixed issues are taken from remaining so they point at the fixed files.
 func classifyIssues(original, remaining []*linter.Issue) (fixed, unfixed []*linter.Issue) {
-	remainingKeys := make(map[string]bool)
+	originalCounts := make(map[string]int)
+	for _, issue := range original {
+		originalCounts[issue.BaselineKey()]++
+	}
+
+	remainingCounts := make(map[string]int)
 	for _, issue := range remaining {
-		remainingKeys[issue.Key()] = true
+		key := issue.BaselineKey()
+		if remainingCounts[key] < originalCounts[key] {
+			unfixed = append(unfixed, issue)
+		}
+		remainingCounts[key]++
 	}
 
 	for _, issue := range original {
-		if remainingKeys[issue.Key()] {
-			unfixed = append(unfixed, issue)
+		key := issue.BaselineKey()
+		if remainingCounts[key] > 0 {
+			remainingCounts[key]--
 		} else {
 			fixed = append(fixed, issue)
 		}
@@ -174,10 +557,10 @@ func classifyIssues(original, remaining []*linter.Issue) (fixed, unfixed []*lint
 	return fixed, unfixed
 }
 
-// hasFixableIssues returns true if any issue can be auto-fixed.
-func hasFixableIssues(issues []*linter.Issue) bool {
+// hasFixableIssues returns true if any issue can be auto-fixed by l.
+func hasFixableIssues(l *linter.WorkflowLinter, issues []*linter.Issue) bool {
 	for _, issue := range issues {
-		if linter.SupportsAutoFix(issue.Linter) {
+		if l.SupportsAutoFix(issue.Linter) {
 			return true
 		}
 	}
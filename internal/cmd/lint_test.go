@@ -0,0 +1,558 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestSameIssues(t *testing.T) {
+	a := &linter.Issue{File: "ci.yml", Line: 3, Linter: config.LinterFormat, Message: "Line has trailing whitespace"}
+	b := &linter.Issue{File: "ci.yml", Line: 5, Linter: config.LinterStyle, Message: "Step is missing a name"}
+	c := &linter.Issue{File: "ci.yml", Line: 7, Linter: config.LinterStyle, Message: "Step is missing a name"}
+
+	tests := []struct {
+		name     string
+		a, b     []*linter.Issue
+		expected bool
+	}{
+		{"both empty", nil, nil, true},
+		{"same order", []*linter.Issue{a, b}, []*linter.Issue{a, b}, true},
+		{"different order", []*linter.Issue{a, b}, []*linter.Issue{b, a}, true},
+		{"different length", []*linter.Issue{a, b}, []*linter.Issue{a}, false},
+		{"different issues", []*linter.Issue{a, b}, []*linter.Issue{a, c}, false},
+		{"duplicates", []*linter.Issue{a, a}, []*linter.Issue{a, b}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameIssues(tt.a, tt.b); got != tt.expected {
+				t.Errorf("sameIssues() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir, `linters:
+  default: none
+  enable:
+    - format
+`)
+
+	tests := []struct {
+		name           string
+		content        string
+		maxPasses      int
+		expectedPasses int
+		expectedIssues int
+	}{
+		{
+			name:           "stops once nothing fixable remains",
+			content:        "name: Test  \non: push\n\n\n\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			maxPasses:      5,
+			expectedPasses: 1,
+			expectedIssues: 0,
+		},
+		{
+			name:           "single pass by default",
+			content:        "name: Test  \non: push\n# " + strings.Repeat("x", 130) + "\njobs:\n",
+			maxPasses:      1,
+			expectedPasses: 1,
+			expectedIssues: 1,
+		},
+		{
+			name:           "stops when issues are stable",
+			content:        "name: Test  \non: push\n# " + strings.Repeat("x", 130) + "\njobs:\n",
+			maxPasses:      5,
+			expectedPasses: 2,
+			expectedIssues: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := testutil.CreateWorkflow(t, tmpDir, "ci.yml", tt.content)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			l := linter.NewWithWorkflows(context.Background(), []*workflow.Workflow{wf}, configPath)
+			issues, err := l.Lint()
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+
+			remaining, passes, err := applyFixes(l, issues, tt.maxPasses)
+			if err != nil {
+				t.Fatalf("applyFixes() error = %v", err)
+			}
+			if passes != tt.expectedPasses {
+				t.Errorf("passes = %d, want %d", passes, tt.expectedPasses)
+			}
+			if len(remaining) != tt.expectedIssues {
+				t.Errorf("remaining issues = %v, want %d", remaining, tt.expectedIssues)
+			}
+		})
+	}
+}
+
+func TestExitCodeFor(t *testing.T) {
+	errorIssue := &linter.Issue{Linter: config.LinterSecrets, Severity: config.SeverityError}
+	warningIssue := &linter.Issue{Linter: config.LinterStyle, Severity: config.SeverityWarning}
+	infoIssue := &linter.Issue{Linter: config.LinterFormat, Severity: config.SeverityInfo}
+
+	tests := []struct {
+		name     string
+		failOn   string
+		issues   []*linter.Issue
+		expected int
+	}{
+		{"no issues", config.SeverityInfo, nil, 0},
+		{"info threshold fails on info", config.SeverityInfo, []*linter.Issue{infoIssue}, 2},
+		{"warning threshold ignores info", config.SeverityWarning, []*linter.Issue{infoIssue}, 0},
+		{"warning threshold fails on warning", config.SeverityWarning, []*linter.Issue{infoIssue, warningIssue}, 2},
+		{"error threshold ignores warnings", config.SeverityError, []*linter.Issue{infoIssue, warningIssue}, 0},
+		{"error threshold fails on error", config.SeverityError, []*linter.Issue{warningIssue, errorIssue}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failOnFlag = tt.failOn
+			t.Cleanup(func() { failOnFlag = config.SeverityInfo })
+
+			if got := exitCodeFor(tt.issues, 2); got != tt.expected {
+				t.Errorf("exitCodeFor() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClassifyIssues(t *testing.T) {
+	issue := func(line int, linterName, message string) *linter.Issue {
+		return &linter.Issue{File: "ci.yml", Line: line, Linter: linterName, Message: message}
+	}
+	keys := func(issues []*linter.Issue) []string {
+		var result []string
+		for _, i := range issues {
+			result = append(result, i.Key())
+		}
+		return result
+	}
+
+	original := []*linter.Issue{
+		issue(8, config.LinterPermissions, "Job 'build' is missing permissions configuration"),
+		issue(15, config.LinterVersions, "pinned"),
+		issue(22, config.LinterFormat, "too long"),
+		issue(30, config.LinterFormat, "too long"),
+	}
+	// Fixing permissions inserted a line, shifting the remaining issues down
+	remaining := []*linter.Issue{
+		issue(23, config.LinterFormat, "too long"),
+		issue(31, config.LinterFormat, "too long"),
+		issue(40, config.LinterStyle, "introduced by a fix"),
+	}
+
+	fixed, unfixed := classifyIssues(original, remaining)
+
+	if want := keys(original[:2]); !slices.Equal(keys(fixed), want) {
+		t.Errorf("classifyIssues() fixed = %v, want %v", keys(fixed), want)
+	}
+	if want := keys(remaining[:2]); !slices.Equal(keys(unfixed), want) {
+		t.Errorf("classifyIssues() unfixed = %v, want %v", keys(unfixed), want)
+	}
+}
+
+func TestFormatIssueSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		issues   []*linter.Issue
+		expected string
+	}{
+		{"no issues", nil, "0 issue(s)."},
+		{
+			name: "mixed severities",
+			issues: []*linter.Issue{
+				{Severity: config.SeverityWarning},
+				{Severity: config.SeverityError},
+				{Severity: config.SeverityWarning},
+			},
+			expected: "3 issue(s) (error: 1, warning: 2).",
+		},
+		{
+			name:     "info only",
+			issues:   []*linter.Issue{{Severity: config.SeverityInfo}},
+			expected: "1 issue(s) (info: 1).",
+		},
+		{
+			name:     "unknown severity",
+			issues:   []*linter.Issue{{}},
+			expected: "1 issue(s).",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatIssueSummary(tt.issues); got != tt.expected {
+				t.Errorf("formatIssueSummary() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDoLint_Baseline(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir, `linters:
+  default: none
+  enable:
+    - style
+    - permissions
+`)
+	baselineFlag = filepath.Join(tmpDir, linter.DefaultBaselineFileName)
+	t.Cleanup(func() {
+		baselineFlag = linter.DefaultBaselineFileName
+		writeBaselineFlag = false
+	})
+
+	path := testutil.CreateWorkflow(t, tmpDir, "ci.yml", "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+	load := func() []*workflow.Workflow {
+		wf, err := workflow.LoadWorkflow(path)
+		if err != nil {
+			t.Fatalf("LoadWorkflow() error = %v", err)
+		}
+		return []*workflow.Workflow{wf}
+	}
+
+	if code := doLint(load(), nil, configPath); code != 1 {
+		t.Fatalf("doLint() without baseline = %d, want 1", code)
+	}
+
+	writeBaselineFlag = true
+	if code := doLint(load(), nil, configPath); code != 0 {
+		t.Fatalf("doLint() --write-baseline = %d, want 0", code)
+	}
+	writeBaselineFlag = false
+
+	// Known issues are suppressed, even after unrelated lines shift them
+	testutil.CreateWorkflow(t, tmpDir, "ci.yml", "\n\non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+	if code := doLint(load(), nil, configPath); code != 0 {
+		t.Errorf("doLint() with baseline = %d, want 0", code)
+	}
+
+	// New issues are still reported
+	testutil.CreateWorkflow(t, tmpDir, "ci.yml", "on: push\njobs:\n  j1:\n    runs-on: ubuntu-latest\n")
+	if code := doLint(load(), nil, configPath); code != 1 {
+		t.Errorf("doLint() with new issue = %d, want 1", code)
+	}
+}
+
+func TestDoLint_Quiet(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir, "linters:\n  default: none\n  enable:\n    - permissions\n")
+	path := testutil.CreateWorkflow(t, tmpDir, "ci.yml", "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+
+	quietFlag = true
+	t.Cleanup(func() { quietFlag = false })
+
+	out, info := lintOutput()
+	if out != io.Discard || info != io.Discard {
+		t.Errorf("lintOutput() with --quiet = %v, %v, want io.Discard", out, info)
+	}
+
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	// The exit code still reports the issues
+	if code := doLint([]*workflow.Workflow{wf}, nil, configPath); code != 1 {
+		t.Errorf("doLint() --quiet = %d, want 1", code)
+	}
+}
+
+func TestDoLint_MaxIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir, "linters:\n  default: none\n  enable:\n    - permissions\n")
+	path := testutil.CreateWorkflow(t, tmpDir, "ci.yml",
+		"on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n  test:\n    runs-on: ubuntu-latest\n")
+
+	maxIssuesFlag = 1
+	quietFlag = true
+	t.Cleanup(func() {
+		maxIssuesFlag = 0
+		quietFlag = false
+	})
+
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	// Issues that are not printed still count toward the exit code
+	if code := doLint([]*workflow.Workflow{wf}, nil, configPath); code != 1 {
+		t.Errorf("doLint() --max-issues = %d, want 1", code)
+	}
+}
+
+func TestDoLint_FixSilentFixer(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir, `linters:
+  default: none
+  enable:
+    - format
+run:
+  silent-fixers:
+    - format
+`)
+	path := testutil.CreateWorkflow(t, tmpDir, "ci.yml",
+		"name: Test  \non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+
+	fixFlag = true
+	t.Cleanup(func() { fixFlag = false })
+
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	if code := doLint([]*workflow.Workflow{wf}, nil, configPath); code != 0 {
+		t.Errorf("doLint() --fix = %d, want 0", code)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(data), "Test  \n") {
+		t.Errorf("silent fixer left trailing whitespace:\n%s", data)
+	}
+}
+
+func TestPrintVerbose(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir,
+		"linters:\n  default: none\n  enable:\n    - style\n    - permissions\n")
+	path := testutil.CreateWorkflow(t, tmpDir, "ci.yml", "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	l := linter.NewWithConfig(context.Background(), []*workflow.Workflow{wf}, cfg)
+	if _, err := l.Lint(); err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	printVerbose(&buf, configPath, l)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	expected := []string{
+		"Config: " + configPath,
+		"Linters: permissions, style",
+		"Linted " + path + " in ",
+		"GitHub API: 0 call(s), 0 from cache",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("printVerbose() wrote:\n%s\nwant %d lines", buf.String(), len(expected))
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d = %q, want prefix %q", i+1, lines[i], prefix)
+		}
+	}
+}
+
+func TestDoLint_MixedFileArgsNoAPI(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir, `linters:
+  default: none
+  enable:
+    - versions
+    - permissions
+`)
+	noAPIFlag = true
+	t.Cleanup(func() { noAPIFlag = false })
+
+	ci := testutil.CreateWorkflow(t, tmpDir, "ci.yml", `on: push
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+`)
+	readme := testutil.CreateWorkflow(t, tmpDir, "README.md", "# Readme\n")
+	action := testutil.CreateWorkflow(t, tmpDir, "action.yml", "name: Action\nruns:\n  using: node20\n")
+
+	workflows, err := workflow.LoadPaths([]string{readme, ci, action})
+	if err != nil {
+		t.Fatalf("LoadPaths() error = %v", err)
+	}
+	if len(workflows) != 1 {
+		t.Fatalf("LoadPaths() loaded %d workflows, want 1", len(workflows))
+	}
+
+	// The version tag would be reported by the versions linter, which --no-api skips
+	if code := doLint(workflows, nil, configPath); code != 0 {
+		t.Errorf("doLint() --no-api = %d, want 0", code)
+	}
+}
+
+func TestWriteFixDiffs(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir, `linters:
+  default: none
+  enable:
+    - format
+`)
+	content := "name: Test  \non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"
+	path := testutil.CreateWorkflow(t, tmpDir, "ci.yml", content)
+
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	fixes, err := linter.NewWithWorkflows(context.Background(), []*workflow.Workflow{wf}, configPath).ApplyFixes()
+	if err != nil {
+		t.Fatalf("ApplyFixes() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	writeFixDiffs(&buf, fixes)
+
+	expected := "--- " + path + "\n+++ " + path + "\n" +
+		"@@ -1,4 +1,4 @@\n-name: Test  \n+name: Test\n on: push\n jobs:\n   build:\n"
+	if buf.String() != expected {
+		t.Errorf("writeFixDiffs() =\n%s\nwant:\n%s", buf.String(), expected)
+	}
+
+	onDisk, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(onDisk) != content {
+		t.Error("writeFixDiffs() should not modify the workflow file")
+	}
+}
+
+func TestValidateLinterNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"known linters", []string{config.LinterInjection, config.LinterHosts}, false},
+		{"unknown linter", []string{config.LinterStyle, "typo"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateLinterNames("only", tt.names); (err != nil) != tt.wantErr {
+				t.Errorf("validateLinterNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadLintPaths_Stdin(t *testing.T) {
+	const content = "on: push\njobs:\n  j1:\n    runs-on: ubuntu-latest\n"
+	t.Cleanup(func() {
+		stdinFilenameFlag = ""
+		fixFlag = false
+	})
+
+	tests := []struct {
+		name          string
+		paths         []string
+		stdinFilename string
+		fix           bool
+		wantFile      string
+		wantErr       string
+	}{
+		{name: "default name", paths: []string{"-"}, wantFile: "<stdin>"},
+		{
+			name:          "stdin filename",
+			paths:         []string{"-"},
+			stdinFilename: ".github/workflows/ci.yml",
+			wantFile:      ".github/workflows/ci.yml",
+		},
+		{name: "combined with other paths", paths: []string{"-", "ci.yml"}, wantErr: "cannot be combined"},
+		{name: "with fix", paths: []string{"-"}, fix: true, wantErr: "--fix cannot be used with stdin"},
+		{
+			name:          "stdin filename without stdin",
+			paths:         []string{t.TempDir()},
+			stdinFilename: "ci.yml",
+			wantErr:       "--stdin-filename requires reading from stdin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdinFilenameFlag = tt.stdinFilename
+			fixFlag = tt.fix
+
+			workflows, err := loadLintPaths(strings.NewReader(content), tt.paths)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadLintPaths() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadLintPaths() error = %v", err)
+			}
+			if len(workflows) != 1 || workflows[0].File != tt.wantFile {
+				t.Fatalf("loadLintPaths() = %v, want one workflow named %s", workflows, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestLoadLintPaths_StdinFilenameInIssues(t *testing.T) {
+	stdinFilenameFlag = ".github/workflows/ci.yml"
+	t.Cleanup(func() { stdinFilenameFlag = "" })
+
+	workflows, err := loadLintPaths(strings.NewReader("on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"),
+		[]string{"-"})
+	if err != nil {
+		t.Fatalf("loadLintPaths() error = %v", err)
+	}
+
+	cfg := &config.Config{Linters: &config.LinterConfig{Default: "none", Enable: []string{config.LinterPermissions}}}
+	issues, err := linter.NewWithConfig(context.Background(), workflows, cfg).Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Lint() returned %d issues, want 1", len(issues))
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, nil, issues, nil); err != nil {
+		t.Fatalf("writeJSON() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"file": "ci.yml"`) ||
+		!strings.Contains(buf.String(), `"path": ".github/workflows/ci.yml"`) {
+		t.Errorf("writeJSON() output does not name the stdin file:\n%s", buf.String())
+	}
+	if got := issues[0].String(); !strings.HasPrefix(got, ".github/workflows/ci.yml:") {
+		t.Errorf("Issue.String() = %q, want it to start with .github/workflows/ci.yml", got)
+	}
+}
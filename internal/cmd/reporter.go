@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+// LintResult is the outcome of a lint run, handed to a Reporter for output.
+type LintResult struct {
+	Issues     []*linter.Issue    // Issues remaining after any fixes
+	Fixed      []*linter.Issue    // Issues resolved by --fix (empty without --fix)
+	Fix        bool               // Whether fixes were applied
+	Fixable    bool               // Whether any reported issue can be auto-fixed
+	Passes     int                // Number of fix passes taken
+	CacheStats actions.CacheStats // GitHub API cache statistics
+	Actions    []*actionSummary   // Pin status per action, with --actions-summary
+}
+
+// Reporter writes a lint result in a single output format.
+type Reporter interface {
+	Report(result *LintResult) error
+}
+
+// newReporter returns the reporter for an output format. Structured formats write
+// the document to out and informational output (e.g., cache statistics) to info,
+// so the document stays valid.
+func newReporter(format string, out, info io.Writer) (Reporter, error) {
+	switch format {
+	case formatText:
+		return &textReporter{w: out, groupByFile: groupByFileFlag, maxIssues: maxIssuesFlag}, nil
+	case formatJSON:
+		return &jsonReporter{w: out, info: info}, nil
+	case formatSARIF:
+		return &sarifReporter{w: out, info: info, version: rootCmd.Version}, nil
+	case formatGitHub:
+		return &githubReporter{w: out, info: info}, nil
+	}
+	return nil, fmt.Errorf("invalid format %q (must be one of %v)", format, lintFormats)
+}
+
+// textReporter writes human-readable sections of fixed and remaining issues.
+// With groupByFile, the issues of each section are listed under a header per file.
+// With maxIssues above zero, each section lists at most that many issues.
+type textReporter struct {
+	w           io.Writer
+	groupByFile bool
+	maxIssues   int
+}
+
+// Report implements Reporter.
+func (r *textReporter) Report(result *LintResult) error {
+	if len(result.Issues) == 0 && len(result.Fixed) == 0 {
+		fmt.Fprintln(r.w, "0 issues.")
+	} else {
+		r.reportIssues(result)
+	}
+
+	if result.Actions != nil {
+		writeActionsSummary(r.w, result.Actions)
+	}
+	return nil
+}
+
+// reportIssues writes the issue sections, statistics, and the issue summary line.
+func (r *textReporter) reportIssues(result *LintResult) {
+	if result.Fix {
+		r.writeIssues("Fixed:", result.Fixed)
+		if len(result.Fixed) > 0 && len(result.Issues) > 0 {
+			fmt.Fprintln(r.w)
+		}
+	}
+	r.writeIssues("Issues:", result.Issues)
+
+	if result.Fix {
+		printCacheStats(r.w, result.CacheStats)
+		printFixPasses(r.w, result.Passes)
+	} else if result.Fixable {
+		// Only suggest --fix if at least one issue can be auto-fixed
+		fmt.Fprintln(r.w, "\nRun with --fix to automatically fix some issues")
+	}
+
+	fmt.Fprintf(r.w, "\n%s\n", formatIssueSummary(result.Issues))
+}
+
+// writeIssues writes a labeled section of issues, or nothing if there are none.
+// Issues beyond maxIssues are counted in a notice instead of being listed.
+func (r *textReporter) writeIssues(header string, issues []*linter.Issue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	shown := firstIssues(issues, r.maxIssues)
+	fmt.Fprintln(r.w, header)
+	if r.groupByFile {
+		writeIssuesByFile(r.w, shown)
+	} else {
+		for _, issue := range shown {
+			fmt.Fprintf(r.w, "  %s\n", issue)
+		}
+	}
+	if hidden := len(issues) - len(shown); hidden > 0 {
+		fmt.Fprintf(r.w, "  ... and %d more issue(s)\n", hidden)
+	}
+}
+
+// firstIssues returns the first limit issues sorted by path and line, or all issues
+// if limit is zero or not exceeded.
+func firstIssues(issues []*linter.Issue, limit int) []*linter.Issue {
+	if limit <= 0 || len(issues) <= limit {
+		return issues
+	}
+	sorted := slices.Clone(issues)
+	slices.SortStableFunc(sorted, func(a, b *linter.Issue) int {
+		return cmp.Or(cmp.Compare(a.Path(), b.Path()), cmp.Compare(a.Line, b.Line))
+	})
+	return sorted[:limit]
+}
+
+// writeIssuesByFile writes the issues under a header per file with the file's issue count.
+// Files are sorted by path and the issues of each file by line.
+func writeIssuesByFile(w io.Writer, issues []*linter.Issue) {
+	byFile := make(map[string][]*linter.Issue)
+	for _, issue := range issues {
+		path := issue.Path()
+		byFile[path] = append(byFile[path], issue)
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(byFile)) {
+		fileIssues := byFile[path]
+		slices.SortStableFunc(fileIssues, func(a, b *linter.Issue) int {
+			return cmp.Compare(a.Line, b.Line)
+		})
+
+		fmt.Fprintf(w, "  %s (%d issue(s))\n", path, len(fileIssues))
+		for _, issue := range fileIssues {
+			fmt.Fprintf(w, "    %s\n", issueWithoutPath(issue))
+		}
+	}
+}
+
+// issueWithoutPath formats an issue like Issue.String, without the file path.
+func issueWithoutPath(issue *linter.Issue) string {
+	if issue.Line > 0 && issue.Column > 0 {
+		return fmt.Sprintf("%d:%d: (%s) %s", issue.Line, issue.Column, issue.Linter, issue.Message)
+	}
+	if issue.Line > 0 {
+		return fmt.Sprintf("%d: (%s) %s", issue.Line, issue.Linter, issue.Message)
+	}
+	return fmt.Sprintf("(%s) %s", issue.Linter, issue.Message)
+}
+
+// jsonReporter writes the result as a JSON document.
+type jsonReporter struct {
+	w    io.Writer
+	info io.Writer
+}
+
+// Report implements Reporter.
+func (r *jsonReporter) Report(result *LintResult) error {
+	writeFixStats(r.info, result)
+	return writeJSON(r.w, result.Fixed, result.Issues, result.Actions)
+}
+
+// sarifReporter writes the remaining issues as a SARIF log.
+type sarifReporter struct {
+	w       io.Writer
+	info    io.Writer
+	version string
+}
+
+// Report implements Reporter.
+func (r *sarifReporter) Report(result *LintResult) error {
+	writeFixStats(r.info, result)
+	return writeSARIF(r.w, result.Issues, r.version)
+}
+
+// writeFixStats writes cache statistics and fix passes to w if fixes were applied.
+func writeFixStats(w io.Writer, result *LintResult) {
+	if result.Fix {
+		printCacheStats(w, result.CacheStats)
+		printFixPasses(w, result.Passes)
+	}
+}
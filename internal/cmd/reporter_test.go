@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+func TestTextReporter(t *testing.T) {
+	pinned := &linter.Issue{File: "ci.yml", Line: 15, Linter: config.LinterVersions,
+		Message: "Action actions/checkout@v3 uses version tag 'v3' instead of commit hash", Severity: config.SeverityWarning}
+	missing := &linter.Issue{File: "ci.yml", Line: 8, Linter: config.LinterPermissions,
+		Message: "Job 'build' is missing permissions configuration", Severity: config.SeverityError}
+	unnamed := &linter.Issue{File: "ci.yml", Line: 22, Linter: config.LinterStyle,
+		Message: "Step is missing a name", Severity: config.SeverityWarning}
+
+	tests := []struct {
+		name     string
+		result   *LintResult
+		expected string
+	}{
+		{
+			name:     "no issues",
+			result:   &LintResult{},
+			expected: "0 issues.\n",
+		},
+		{
+			name:   "issues with fixable hint",
+			result: &LintResult{Issues: []*linter.Issue{missing, pinned}, Fixable: true},
+			expected: "Issues:\n" +
+				"  ci.yml:8: (permissions) Job 'build' is missing permissions configuration\n" +
+				"  ci.yml:15: (versions) Action actions/checkout@v3 uses version tag 'v3' instead of commit hash\n" +
+				"\nRun with --fix to automatically fix some issues\n" +
+				"\n2 issue(s) (error: 1, warning: 1).\n",
+		},
+		{
+			name:   "issues without fixable hint",
+			result: &LintResult{Issues: []*linter.Issue{unnamed}},
+			expected: "Issues:\n" +
+				"  ci.yml:22: (style) Step is missing a name\n" +
+				"\n1 issue(s) (warning: 1).\n",
+		},
+		{
+			name: "fixed and remaining",
+			result: &LintResult{
+				Fix:        true,
+				Passes:     1,
+				Fixed:      []*linter.Issue{pinned},
+				Issues:     []*linter.Issue{unnamed},
+				CacheStats: actions.CacheStats{Misses: 2, Hits: 1},
+			},
+			expected: "Fixed:\n" +
+				"  ci.yml:15: (versions) Action actions/checkout@v3 uses version tag 'v3' instead of commit hash\n" +
+				"\nIssues:\n" +
+				"  ci.yml:22: (style) Step is missing a name\n" +
+				"\nGitHub API: 2 call(s), 1 from cache\n" +
+				"\n1 issue(s) (warning: 1).\n",
+		},
+		{
+			name:   "all fixed",
+			result: &LintResult{Fix: true, Passes: 1, Fixed: []*linter.Issue{pinned}},
+			expected: "Fixed:\n" +
+				"  ci.yml:15: (versions) Action actions/checkout@v3 uses version tag 'v3' instead of commit hash\n" +
+				"\n0 issue(s).\n",
+		},
+		{
+			name:     "actions summary",
+			result:   &LintResult{Actions: []*actionSummary{}},
+			expected: "0 issues.\n\nNo actions found.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (&textReporter{w: &buf}).Report(tt.result); err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+			if got := buf.String(); got != tt.expected {
+				t.Errorf("Report() output:\n%s\nwant:\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTextReporter_GroupByFile(t *testing.T) {
+	deployStep := &linter.Issue{File: "deploy.yml", Line: 30, Linter: config.LinterStyle,
+		Message: "Step is missing a name", Severity: config.SeverityWarning}
+	ciPinned := &linter.Issue{File: "ci.yml", Line: 15, Linter: config.LinterVersions,
+		Message: "Action actions/checkout@v3 uses version tag 'v3' instead of commit hash", Severity: config.SeverityWarning}
+	ciMissing := &linter.Issue{File: "ci.yml", Line: 8, Linter: config.LinterPermissions,
+		Message: "Job 'build' is missing permissions configuration", Severity: config.SeverityError}
+	ciFormat := &linter.Issue{File: "ci.yml", Line: 3, Column: 7, Linter: config.LinterFormat,
+		Message: "Trailing whitespace", Severity: config.SeverityInfo}
+
+	tests := []struct {
+		name     string
+		result   *LintResult
+		expected string
+	}{
+		{
+			name:   "sorted by file and line",
+			result: &LintResult{Issues: []*linter.Issue{deployStep, ciPinned, ciMissing, ciFormat}},
+			expected: "Issues:\n" +
+				"  ci.yml (3 issue(s))\n" +
+				"    3:7: (format) Trailing whitespace\n" +
+				"    8: (permissions) Job 'build' is missing permissions configuration\n" +
+				"    15: (versions) Action actions/checkout@v3 uses version tag 'v3' instead of commit hash\n" +
+				"  deploy.yml (1 issue(s))\n" +
+				"    30: (style) Step is missing a name\n" +
+				"\n4 issue(s) (error: 1, warning: 2, info: 1).\n",
+		},
+		{
+			name: "fixed and remaining",
+			result: &LintResult{
+				Fix:    true,
+				Passes: 1,
+				Fixed:  []*linter.Issue{ciPinned},
+				Issues: []*linter.Issue{deployStep},
+			},
+			expected: "Fixed:\n" +
+				"  ci.yml (1 issue(s))\n" +
+				"    15: (versions) Action actions/checkout@v3 uses version tag 'v3' instead of commit hash\n" +
+				"\nIssues:\n" +
+				"  deploy.yml (1 issue(s))\n" +
+				"    30: (style) Step is missing a name\n" +
+				"\n1 issue(s) (warning: 1).\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (&textReporter{w: &buf, groupByFile: true}).Report(tt.result); err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+			if got := buf.String(); got != tt.expected {
+				t.Errorf("Report() output:\n%s\nwant:\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTextReporter_MaxIssues(t *testing.T) {
+	deployStep := &linter.Issue{File: "deploy.yml", Line: 30, Linter: config.LinterStyle,
+		Message: "Step is missing a name", Severity: config.SeverityWarning}
+	ciPinned := &linter.Issue{File: "ci.yml", Line: 15, Linter: config.LinterVersions,
+		Message: "Action actions/checkout@v3 uses version tag 'v3' instead of commit hash", Severity: config.SeverityWarning}
+	ciMissing := &linter.Issue{File: "ci.yml", Line: 8, Linter: config.LinterPermissions,
+		Message: "Job 'build' is missing permissions configuration", Severity: config.SeverityError}
+	issues := []*linter.Issue{deployStep, ciPinned, ciMissing}
+
+	tests := []struct {
+		name        string
+		groupByFile bool
+		maxIssues   int
+		expected    string
+	}{
+		{
+			name:      "truncated",
+			maxIssues: 2,
+			expected: "Issues:\n" +
+				"  ci.yml:8: (permissions) Job 'build' is missing permissions configuration\n" +
+				"  ci.yml:15: (versions) Action actions/checkout@v3 uses version tag 'v3' instead of commit hash\n" +
+				"  ... and 1 more issue(s)\n" +
+				"\n3 issue(s) (error: 1, warning: 2).\n",
+		},
+		{
+			name:        "truncated by file",
+			groupByFile: true,
+			maxIssues:   1,
+			expected: "Issues:\n" +
+				"  ci.yml (1 issue(s))\n" +
+				"    8: (permissions) Job 'build' is missing permissions configuration\n" +
+				"  ... and 2 more issue(s)\n" +
+				"\n3 issue(s) (error: 1, warning: 2).\n",
+		},
+		{
+			name:      "within limit",
+			maxIssues: 3,
+			expected: "Issues:\n" +
+				"  deploy.yml:30: (style) Step is missing a name\n" +
+				"  ci.yml:15: (versions) Action actions/checkout@v3 uses version tag 'v3' instead of commit hash\n" +
+				"  ci.yml:8: (permissions) Job 'build' is missing permissions configuration\n" +
+				"\n3 issue(s) (error: 1, warning: 2).\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			reporter := &textReporter{w: &buf, groupByFile: tt.groupByFile, maxIssues: tt.maxIssues}
+			if err := reporter.Report(&LintResult{Issues: issues}); err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+			if got := buf.String(); got != tt.expected {
+				t.Errorf("Report() output:\n%s\nwant:\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStructuredReporters_InfoOutput(t *testing.T) {
+	result := &LintResult{
+		Fix:        true,
+		Passes:     1,
+		CacheStats: actions.CacheStats{Misses: 1},
+		Issues:     []*linter.Issue{{File: "ci.yml", Line: 3, Linter: config.LinterStyle, Message: "Step is missing a name"}},
+	}
+
+	for _, format := range []string{formatJSON, formatSARIF} {
+		t.Run(format, func(t *testing.T) {
+			var out, info bytes.Buffer
+			reporter, err := newReporter(format, &out, &info)
+			if err != nil {
+				t.Fatalf("newReporter() error = %v", err)
+			}
+			if err := reporter.Report(result); err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+
+			if !json.Valid(out.Bytes()) {
+				t.Errorf("Report() output is not valid JSON:\n%s", out.String())
+			}
+			if got, want := info.String(), "\nGitHub API: 1 call(s), 0 from cache\n"; got != want {
+				t.Errorf("Report() info = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestNewReporter_InvalidFormat(t *testing.T) {
+	if _, err := newReporter("xml", &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+		t.Error("newReporter() error = nil, want error for unknown format")
+	}
+}
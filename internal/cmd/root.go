@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -19,11 +20,16 @@ func SetVersion(version string) {
 	rootCmd.Version = version
 }
 
-func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+// Execute runs the command given on the command line and returns the exit code:
+// exitOK on success, the issues exit code if lint found issues, exitUsage for
+// invalid flags, arguments, or configuration, and exitInternal for other failures.
+func Execute() int {
+	err := rootCmd.Execute()
+	var exitErr *exitError
+	if err != nil && (!errors.As(err, &exitErr) || exitErr.err != nil) {
 		printError("%v", err)
-		os.Exit(1)
 	}
+	return exitCode(err)
 }
 
 // printError prints a formatted error message to stderr.
@@ -32,7 +38,10 @@ func printError(format string, args ...any) {
 }
 
 func init() {
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(explainCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(unpinCmd)
 	rootCmd.AddCommand(upgradeCmd)
 }
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "github-ci"
+	toolURI      = "https://github.com/reugn/github-ci"
+)
+
+// sarifLog is the top-level SARIF 2.1.0 document.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun describes a single invocation of the tool and its results.
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+// sarifTool describes the analysis tool.
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+// sarifDriver describes the tool component and the rules it can report.
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+// sarifRule describes a single rule; each check of a linter maps to one rule.
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription sarifMessage  `json:"shortDescription"`
+	FullDescription  *sarifMessage `json:"fullDescription,omitempty"`
+	Help             *sarifMessage `json:"help,omitempty"`
+	HelpURI          string        `json:"helpUri,omitempty"`
+}
+
+// sarifResult is a single reported issue.
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+// sarifMessage holds plain text for messages and descriptions.
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifLocation points a result at a file region.
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+// sarifPhysicalLocation identifies the artifact and region of a result.
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+// sarifArtifactLocation identifies the file containing a result.
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion identifies the line and, if known, the column of a result.
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// writeSARIF serializes issues as a SARIF 2.1.0 document to w.
+func writeSARIF(w io.Writer, issues []*linter.Issue, version string) error {
+	rules, ruleIndex := buildSARIFRules()
+
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		results = append(results, sarifResult{
+			RuleID:    issue.RuleID,
+			RuleIndex: ruleIndex[issue.RuleID],
+			Level:     sarifLevel(issue.Severity),
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(issue.Path())},
+					// SARIF regions are 1-based; file-level issues point at the first line
+					Region: sarifRegion{StartLine: max(issue.Line, 1), StartColumn: issue.Column},
+				},
+			}},
+		})
+	}
+
+	doc := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           toolName,
+				Version:        version,
+				InformationURI: toolURI,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// sarifLevel maps an issue severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case config.SeverityWarning:
+		return "warning"
+	case config.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// buildSARIFRules returns a rule per check, including the one for invalid YAML, and a lookup
+// of rule index by rule ID. Rules are described with the same metadata as explain prints.
+func buildSARIFRules() ([]sarifRule, map[string]int) {
+	ids := linter.RuleIDs()
+	rules := make([]sarifRule, 0, len(ids))
+	ruleIndex := make(map[string]int, len(ids))
+
+	for i, id := range ids {
+		details, _ := linter.LookupRule(id)
+		rules = append(rules, sarifRule{
+			ID:               id,
+			ShortDescription: sarifMessage{Text: details.Description},
+			FullDescription:  &sarifMessage{Text: details.Rationale},
+			Help:             &sarifMessage{Text: details.Fix},
+			HelpURI:          details.DocsURL,
+		})
+		ruleIndex[id] = i
+	}
+
+	return rules, ruleIndex
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	issues := []*linter.Issue{
+		{File: "ci.yml", Line: 0, Linter: config.LinterPermissions, RuleID: "permissions/missing", Severity: config.SeverityError,
+			Message: "Workflow is missing permissions configuration"},
+		{File: "ci.yml", Line: 15, Column: 9, Linter: config.LinterVersions, RuleID: "versions/tag-not-hash", Severity: config.SeverityWarning,
+			Message: "Action uses version tag"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, issues, "1.2.3"); err != nil {
+		t.Fatalf("writeSARIF() error = %v", err)
+	}
+
+	var doc sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if doc.Version != sarifVersion {
+		t.Errorf("version = %q, want %q", doc.Version, sarifVersion)
+	}
+	if len(doc.Runs) != 1 {
+		t.Fatalf("runs length = %d, want 1", len(doc.Runs))
+	}
+
+	run := doc.Runs[0]
+	if run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver version = %q, want %q", run.Tool.Driver.Version, "1.2.3")
+	}
+	// One rule per check, including the one for invalid YAML
+	if len(run.Tool.Driver.Rules) != len(linter.RuleIDs()) {
+		t.Errorf("rules length = %d, want %d", len(run.Tool.Driver.Rules), len(linter.RuleIDs()))
+	}
+	for _, rule := range run.Tool.Driver.Rules {
+		if rule.FullDescription == nil || rule.Help == nil {
+			t.Errorf("rule %q has no full description or help", rule.ID)
+		}
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("results length = %d, want 2", len(run.Results))
+	}
+
+	if ruleID := run.Results[1].RuleID; ruleID != "versions/tag-not-hash" {
+		t.Errorf("result ruleId = %q, want %q", ruleID, "versions/tag-not-hash")
+	}
+	for i, result := range run.Results {
+		rule := run.Tool.Driver.Rules[result.RuleIndex]
+		if rule.ID != result.RuleID {
+			t.Errorf("result %d ruleIndex points at %q, want %q", i, rule.ID, result.RuleID)
+		}
+	}
+
+	// File-level issues must still produce a valid 1-based region
+	if line := run.Results[0].Locations[0].PhysicalLocation.Region.StartLine; line != 1 {
+		t.Errorf("file-level issue startLine = %d, want 1", line)
+	}
+	if line := run.Results[1].Locations[0].PhysicalLocation.Region.StartLine; line != 15 {
+		t.Errorf("startLine = %d, want 15", line)
+	}
+	if column := run.Results[1].Locations[0].PhysicalLocation.Region.StartColumn; column != 9 {
+		t.Errorf("startColumn = %d, want 9", column)
+	}
+
+	// Levels follow issue severity
+	if level := run.Results[0].Level; level != "error" {
+		t.Errorf("error issue level = %q, want %q", level, "error")
+	}
+	if level := run.Results[1].Level; level != "warning" {
+		t.Errorf("warning issue level = %q, want %q", level, "warning")
+	}
+}
+
+func TestWriteSARIF_NoIssues(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, nil, ""); err != nil {
+		t.Fatalf("writeSARIF() error = %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	runs := doc["runs"].([]any)
+	results, ok := runs[0].(map[string]any)["results"].([]any)
+	if !ok || len(results) != 0 {
+		t.Errorf("results = %v, want empty array", runs[0].(map[string]any)["results"])
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// actionSummary counts the usages of a single action by how they are pinned.
+type actionSummary struct {
+	Name       string `json:"name"`
+	Uses       int    `json:"uses"`
+	HashPinned int    `json:"hash_pinned"` // Usages pinned to a commit hash
+	TagPinned  int    `json:"tag_pinned"`  // Usages pinned to a tag, branch, or other mutable ref
+}
+
+// summarizeActions aggregates action usages across workflows, sorted by name.
+// Names are compared case-insensitively; the first spelling found is reported.
+// Local and Docker actions are skipped since they have no ref to pin.
+func summarizeActions(workflows []*workflow.Workflow) ([]*actionSummary, error) {
+	byName := make(map[string]*actionSummary)
+
+	for _, wf := range workflows {
+		workflowActions, err := wf.FindActions()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find actions in %s: %w", wf.File, err)
+		}
+
+		for _, action := range workflowActions {
+			info, err := actions.ParseActionUses(action.Uses)
+			if err != nil {
+				continue
+			}
+
+			key := strings.ToLower(info.Name())
+			summary, ok := byName[key]
+			if !ok {
+				summary = &actionSummary{Name: info.Name()}
+				byName[key] = summary
+			}
+
+			summary.Uses++
+			if actions.IsCommitHash(info.Ref) {
+				summary.HashPinned++
+			} else {
+				summary.TagPinned++
+			}
+		}
+	}
+
+	summaries := make([]*actionSummary, 0, len(byName))
+	for _, summary := range byName {
+		summaries = append(summaries, summary)
+	}
+	slices.SortFunc(summaries, func(a, b *actionSummary) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return summaries, nil
+}
+
+// writeActionsSummary writes a table of unique actions with their pin status and a total line.
+func writeActionsSummary(w io.Writer, summaries []*actionSummary) {
+	if len(summaries) == 0 {
+		fmt.Fprintln(w, "\nNo actions found.")
+		return
+	}
+
+	var uses, hashPinned, tagPinned int
+	fmt.Fprintln(w, "\nActions:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  ACTION\tUSES\tHASH\tTAG")
+	for _, s := range summaries {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\n", s.Name, s.Uses, s.HashPinned, s.TagPinned)
+		uses += s.Uses
+		hashPinned += s.HashPinned
+		tagPinned += s.TagPinned
+	}
+	_ = tw.Flush()
+
+	fmt.Fprintf(w, "\n%d unique action(s), %d use(s): %d hash-pinned, %d tag-pinned\n",
+		len(summaries), uses, hashPinned, tagPinned)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestSummarizeActions(t *testing.T) {
+	tmpDir := t.TempDir()
+	testutil.CreateWorkflow(t, tmpDir, "ci.yml", `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab
+      - uses: actions/setup-go@v5
+      - uses: ./.github/actions/local
+`)
+	testutil.CreateWorkflow(t, tmpDir, "release.yml", `on: push
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: Actions/Checkout@v4
+      - uses: goreleaser/goreleaser-action@main
+`)
+
+	workflows, err := workflow.LoadPath(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadPath() error = %v", err)
+	}
+
+	summaries, err := summarizeActions(workflows)
+	if err != nil {
+		t.Fatalf("summarizeActions() error = %v", err)
+	}
+
+	expected := []actionSummary{
+		{Name: "actions/checkout", Uses: 2, HashPinned: 1, TagPinned: 1},
+		{Name: "actions/setup-go", Uses: 1, HashPinned: 0, TagPinned: 1},
+		{Name: "goreleaser/goreleaser-action", Uses: 1, HashPinned: 0, TagPinned: 1},
+	}
+	if len(summaries) != len(expected) {
+		t.Fatalf("summarizeActions() returned %d action(s), want %d", len(summaries), len(expected))
+	}
+	for i, want := range expected {
+		if *summaries[i] != want {
+			t.Errorf("summary %d = %+v, want %+v", i, *summaries[i], want)
+		}
+	}
+}
+
+func TestWriteActionsSummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		summaries []*actionSummary
+		expected  []string
+	}{
+		{
+			name:      "no actions",
+			summaries: nil,
+			expected:  []string{"No actions found."},
+		},
+		{
+			name: "table with totals",
+			summaries: []*actionSummary{
+				{Name: "actions/checkout", Uses: 2, HashPinned: 1, TagPinned: 1},
+				{Name: "actions/setup-go", Uses: 1, TagPinned: 1},
+			},
+			expected: []string{
+				"  ACTION            USES  HASH  TAG\n",
+				"  actions/checkout  2     1     1\n",
+				"  actions/setup-go  1     0     1\n",
+				"2 unique action(s), 3 use(s): 1 hash-pinned, 2 tag-pinned",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeActionsSummary(&buf, tt.summaries)
+			for _, want := range tt.expected {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("writeActionsSummary() output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestWriteJSON_ActionsSummary(t *testing.T) {
+	summaries := []*actionSummary{{Name: "actions/checkout", Uses: 1, HashPinned: 1}}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, nil, nil, summaries); err != nil {
+		t.Fatalf("writeJSON() error = %v", err)
+	}
+
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(report.Actions) != 1 || *report.Actions[0] != *summaries[0] {
+		t.Errorf("actions = %+v, want %+v", report.Actions, summaries)
+	}
+
+	buf.Reset()
+	if err := writeJSON(&buf, nil, nil, nil); err != nil {
+		t.Fatalf("writeJSON() error = %v", err)
+	}
+	if strings.Contains(buf.String(), `"actions"`) {
+		t.Errorf("writeJSON() without summaries should omit actions:\n%s", buf.String())
+	}
+}
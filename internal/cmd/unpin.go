@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/upgrader"
+	"github.com/reugn/github-ci/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin [path]",
+	Short: "Revert commit hash pins of actions to version tags",
+	Long: `Replace the commit hashes of actions pinned in workflows with their version tags,
+e.g., for debugging, and remove the version comments.
+
+The tag is taken from the version comment of each pin (e.g., "# v4.1.1"). Pins without
+one are looked up in the action's repository; pins no tag points to are skipped.
+
+The path can be a directory (e.g., .github/workflows) or a specific workflow file.
+If no path is provided, defaults to .github/workflows.`,
+	RunE:         runUnpin,
+	SilenceUsage: true,
+}
+
+func init() {
+	addCommonFlags(unpinCmd)
+	addTimeoutFlag(unpinCmd)
+	unpinCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would be unpinned without making changes")
+}
+
+func runUnpin(cmd *cobra.Command, args []string) error {
+	if err := validateTimeoutFlag(cmd); err != nil {
+		return err
+	}
+
+	workflowsPath := pathFlag
+	if len(args) > 0 {
+		workflowsPath = args[0]
+	}
+
+	if _, err := config.LoadConfig(configFlag); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	workflows, err := workflow.LoadPath(workflowsPath)
+	if err != nil {
+		return internalError(fmt.Errorf("failed to load workflows: %w", err))
+	}
+
+	ctx, cancel := createTimeoutContext(configFlag)
+	defer cancel()
+
+	upgrader := upgrader.NewWithWorkflows(ctx, workflows, configFlag)
+
+	if dryRunFlag {
+		if err := upgrader.UnpinDryRun(); err != nil {
+			return internalError(fmt.Errorf("failed to check pinned actions: %w", err))
+		}
+	} else {
+		if err := upgrader.Unpin(); err != nil {
+			return internalError(fmt.Errorf("failed to unpin workflows: %w", err))
+		}
+		fmt.Println("✓ Unpin completed successfully")
+	}
+
+	printCacheStats(os.Stdout, upgrader.GetCacheStats())
+
+	return nil
+}
@@ -2,12 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/upgrader"
+	"github.com/reugn/github-ci/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
-var dryRunFlag bool
+var (
+	dryRunFlag          bool
+	interactiveFlag     bool
+	saveConstraintsFlag bool
+)
 
 var upgradeCmd = &cobra.Command{
 	Use:   "upgrade [path]",
@@ -16,25 +23,50 @@ var upgradeCmd = &cobra.Command{
 Creates .github-ci.yaml config file if it doesn't exist.
 
 The path can be a directory (e.g., .github/workflows) or a specific workflow file.
-If no path is provided, defaults to .github/workflows.`,
+If no path is provided, defaults to .github/workflows.
+
+With --interactive, the command asks for each action with a newer version whether
+to upgrade it, skip it, or upgrade it pinned to the commit hash. Add
+--save-constraints to set the constraint of each upgraded action in the config
+to the new major version. When stdin is not a terminal, all actions are upgraded
+as without --interactive.`,
 	RunE:         runUpgrade,
 	SilenceUsage: true,
 }
 
 func init() {
 	addCommonFlags(upgradeCmd)
+	addTimeoutFlag(upgradeCmd)
 	upgradeCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would be updated without making changes")
+	upgradeCmd.Flags().BoolVarP(&interactiveFlag, "interactive", "i", false,
+		"Choose per action whether to upgrade, skip, or pin to the commit hash")
+	upgradeCmd.Flags().BoolVar(&saveConstraintsFlag, "save-constraints", false,
+		"With --interactive, save the new major version of each upgraded action as its constraint")
 }
 
-func runUpgrade(_ *cobra.Command, args []string) error {
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	if err := validateTimeoutFlag(cmd); err != nil {
+		return err
+	}
+	if interactiveFlag && dryRunFlag {
+		return fmt.Errorf("--interactive cannot be combined with --dry-run")
+	}
+	if saveConstraintsFlag && !interactiveFlag {
+		return fmt.Errorf("--save-constraints requires --interactive")
+	}
+
 	workflowsPath := pathFlag
 	if len(args) > 0 {
 		workflowsPath = args[0]
 	}
 
-	workflows, err := loadWorkflows(workflowsPath)
+	if _, err := config.LoadConfig(configFlag); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	workflows, err := workflow.LoadPath(workflowsPath)
 	if err != nil {
-		return fmt.Errorf("failed to load workflows: %w", err)
+		return internalError(fmt.Errorf("failed to load workflows: %w", err))
 	}
 
 	ctx, cancel := createTimeoutContext(configFlag)
@@ -42,19 +74,35 @@ func runUpgrade(_ *cobra.Command, args []string) error {
 
 	upgrader := upgrader.NewWithWorkflows(ctx, workflows, configFlag)
 
-	if dryRunFlag {
+	interactive := interactiveFlag && isTerminal(os.Stdin)
+	if interactiveFlag && !interactive {
+		fmt.Fprintln(os.Stderr, "Note: stdin is not a terminal; upgrading without prompts")
+	}
+
+	switch {
+	case dryRunFlag:
 		if err := upgrader.DryRun(); err != nil {
-			return fmt.Errorf("failed to check for upgrades: %w", err)
+			return internalError(fmt.Errorf("failed to check for upgrades: %w", err))
+		}
+	case interactive:
+		if err := upgrader.InteractiveUpgrade(os.Stdin, os.Stdout, saveConstraintsFlag); err != nil {
+			return internalError(fmt.Errorf("failed to upgrade workflows: %w", err))
 		}
-	} else {
+		fmt.Println("✓ Upgrade completed successfully")
+	default:
 		if err := upgrader.Upgrade(); err != nil {
-			return fmt.Errorf("failed to upgrade workflows: %w", err)
+			return internalError(fmt.Errorf("failed to upgrade workflows: %w", err))
 		}
 		fmt.Println("✓ Upgrade completed successfully")
 	}
 
-	stats := upgrader.GetCacheStats()
-	printCacheStats(stats.Hits, stats.Misses)
+	printCacheStats(os.Stdout, upgrader.GetCacheStats())
 
 	return nil
 }
+
+// isTerminal reports whether f is an interactive terminal rather than a pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/osutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// watchDebounce is how long files must stay unchanged before re-linting,
+// so a burst of writes (e.g., an editor saving) triggers a single run.
+const watchDebounce = 500 * time.Millisecond
+
+// clearScreen is the ANSI sequence that moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// validateWatchFlags checks that --watch is combined only with flags it supports.
+func validateWatchFlags(paths []string) error {
+	if slices.Contains(paths, stdinPath) {
+		return fmt.Errorf("--watch cannot be used with stdin (%s)", stdinPath)
+	}
+	if fixFlag || diffFlag || writeBaselineFlag || quietFlag {
+		return fmt.Errorf("--watch cannot be combined with --fix, --diff, --write-baseline, or --quiet")
+	}
+	if formatFlag != formatText {
+		return fmt.Errorf("--watch only supports --format %s", formatText)
+	}
+	return nil
+}
+
+// watchLint lints the workflows under paths, then re-lints them whenever a YAML file
+// is added, changed, or removed, until interrupted (Ctrl-C). GitHub API lookups are
+// cached across runs.
+func watchLint(ctx context.Context, paths []string, configFile string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cache := actions.NewCache()
+	w := &watcher{
+		paths:    paths,
+		debounce: watchDebounce,
+		lint: func() {
+			fmt.Fprint(os.Stdout, clearScreen)
+			relint(ctx, os.Stdout, paths, configFile, cache)
+		},
+	}
+
+	return w.watch(ctx)
+}
+
+// relint loads the workflows under paths and lints them, then prints the watch status.
+// Files that aren't valid YAML are reported as issues, and other load errors are printed;
+// either way the watch continues, so a broken file can be fixed.
+func relint(ctx context.Context, w io.Writer, paths []string, configFile string, cache *actions.Cache) {
+	workflows, err := workflow.LoadPaths(paths)
+	if parseErrs, onlyParse := workflow.ParseErrors(err); onlyParse {
+		lintWorkflows(ctx, workflows, parseErrs, configFile, cache)
+	} else {
+		printError("failed to load workflows: %v", err)
+	}
+	fmt.Fprintf(w, "\nWatching %s for changes (Ctrl-C to exit)...\n", strings.Join(paths, ", "))
+}
+
+// watcher runs lint once, then again each time a YAML file under paths is added,
+// changed, or removed, once no further change arrives for the debounce period.
+type watcher struct {
+	paths    []string
+	debounce time.Duration
+	lint     func()
+}
+
+// watch lints, then waits for file system events until ctx is done.
+func (w *watcher) watch(ctx context.Context) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return internalError(fmt.Errorf("failed to watch files: %w", err))
+	}
+	defer fsw.Close()
+
+	dirs, trees := watchDirs(w.paths)
+	for _, dir := range dirs {
+		if err := fsw.Add(dir); err != nil {
+			return internalError(fmt.Errorf("failed to watch %s: %w", dir, err))
+		}
+	}
+	for _, root := range trees {
+		addTree(fsw, root)
+	}
+
+	w.lint()
+
+	var lintAt <-chan time.Time // fires once the debounce period has passed, nil if no change is pending
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsw.Events:
+			if !ok {
+				return nil
+			}
+			if w.handleEvent(fsw, event, trees) {
+				lintAt = time.After(w.debounce)
+			}
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+			printError("watch: %v", err)
+		case <-lintAt:
+			lintAt = nil
+			w.lint()
+		}
+	}
+}
+
+// handleEvent returns true if event changes a YAML file under the watched paths.
+// Directories created under a watched tree are watched too, and count as a change
+// since files may have been written to them before the watch was added.
+func (w *watcher) handleEvent(fsw *fsnotify.Watcher, event fsnotify.Event, trees []string) bool {
+	if event.Has(fsnotify.Create) && slices.ContainsFunc(trees, func(root string) bool {
+		return isUnder(event.Name, root)
+	}) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			addTree(fsw, event.Name)
+			return true
+		}
+	}
+	return event.Op != fsnotify.Chmod && matchesPaths(w.paths, event.Name)
+}
+
+// watchDirs returns the directories to watch for changes to the YAML files under paths,
+// which can be directories, files, or glob patterns as for lint: each directory, the
+// parent directory of each file (editors often save by replacing the file), and the
+// directories a pattern can match in. Patterns with "**" return their root as a tree,
+// to be watched with all of its subdirectories. Paths that don't exist are skipped.
+func watchDirs(paths []string) (dirs []string, trees []string) {
+	add := func(dir string) {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() && !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		switch {
+		case strings.Contains(path, "**"):
+			if root := osutil.GlobRoot(path); !slices.Contains(trees, root) {
+				trees = append(trees, root)
+			}
+		case osutil.IsGlobPattern(path):
+			matches, _ := filepath.Glob(filepath.Dir(path))
+			for _, dir := range matches {
+				add(dir)
+			}
+		default:
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				add(path)
+			} else {
+				add(filepath.Dir(path))
+			}
+		}
+	}
+	return dirs, trees
+}
+
+// addTree watches root and its subdirectories, skipping .git. Directories that can't
+// be watched are skipped.
+func addTree(fsw *fsnotify.Watcher, root string) {
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil
+		}
+		if entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		_ = fsw.Add(path)
+		return nil
+	})
+}
+
+// matchesPaths returns true if name is a YAML file that lint would load from paths:
+// a file in one of the directories, one of the files, or a file matching a pattern.
+func matchesPaths(paths []string, name string) bool {
+	if !workflow.IsYAMLFile(name) {
+		return false
+	}
+	name = filepath.Clean(name)
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		if osutil.IsGlobPattern(path) {
+			if ok, _ := osutil.Match(path, name); ok {
+				return true
+			}
+			continue
+		}
+		if name == path || filepath.Dir(name) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnder returns true if path is root or inside it.
+func isUnder(path, root string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Debounce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ci.yml")
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("ci.yml", "on: push\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make(chan struct{}, 10)
+	w := &watcher{
+		paths:    []string{dir},
+		debounce: 200 * time.Millisecond,
+		lint:     func() { runs <- struct{}{} },
+	}
+	done := make(chan error)
+	go func() {
+		done <- w.watch(ctx)
+	}()
+
+	waitRun := func() {
+		t.Helper()
+		select {
+		case <-runs:
+		case <-time.After(5 * time.Second):
+			t.Fatal("lint did not run")
+		}
+	}
+	waitRun() // initial run
+
+	// A burst of writes triggers a single run
+	for i := range 3 {
+		write("ci.yml", fmt.Sprintf("on: push # %d\n", i))
+		time.Sleep(20 * time.Millisecond)
+	}
+	waitRun()
+	time.Sleep(500 * time.Millisecond)
+	if got := len(runs); got != 0 {
+		t.Fatalf("lint ran %d more time(s) after a burst of writes, want 0", got)
+	}
+
+	// Files lint doesn't load are ignored
+	write("README.md", "# CI\n")
+	time.Sleep(500 * time.Millisecond)
+	if got := len(runs); got != 0 {
+		t.Errorf("lint ran %d time(s) after a non-YAML change, want 0", got)
+	}
+
+	// Removing a workflow is a change
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	waitRun()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("watch() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watch() did not return after the context was cancelled")
+	}
+}
+
+func TestWatchDirs(t *testing.T) {
+	dir := t.TempDir()
+	workflows := filepath.Join(dir, ".github", "workflows")
+	if err := os.MkdirAll(workflows, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		paths     []string
+		wantDirs  []string
+		wantTrees []string
+	}{
+		{"directory", []string{workflows}, []string{workflows}, nil},
+		{"file", []string{filepath.Join(workflows, "ci.yml")}, []string{workflows}, nil},
+		{"glob", []string{filepath.Join(dir, ".github", "*", "*.yml")}, []string{workflows}, nil},
+		{"globstar", []string{filepath.Join(dir, "**", "*.yml")}, nil, []string{dir}},
+		{"missing", []string{filepath.Join(dir, "missing", "ci.yml")}, nil, nil},
+		{"duplicates", []string{workflows, filepath.Join(workflows, "ci.yml")}, []string{workflows}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dirs, trees := watchDirs(tt.paths)
+			if !slices.Equal(dirs, tt.wantDirs) || !slices.Equal(trees, tt.wantTrees) {
+				t.Errorf("watchDirs() = %v, %v, want %v, %v", dirs, trees, tt.wantDirs, tt.wantTrees)
+			}
+		})
+	}
+}
+
+func TestMatchesPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		file  string
+		want  bool
+	}{
+		{"file in directory", []string{".github/workflows"}, ".github/workflows/ci.yml", true},
+		{"file in subdirectory", []string{".github/workflows"}, ".github/workflows/nested/ci.yml", false},
+		{"non-YAML file", []string{".github/workflows"}, ".github/workflows/README.md", false},
+		{"same file", []string{".github/workflows/ci.yml"}, ".github/workflows/ci.yml", true},
+		{"other file", []string{".github/workflows/ci.yml"}, ".github/workflows/release.yml", false},
+		{"glob", []string{"**/*.yaml"}, "actions/build/action.yaml", true},
+		{"glob mismatch", []string{"**/*.yaml"}, "actions/build/action.yml", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesPaths(tt.paths, filepath.FromSlash(tt.file)); got != tt.want {
+				t.Errorf("matchesPaths(%v, %q) = %v, want %v", tt.paths, tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWatchFlags(t *testing.T) {
+	t.Cleanup(func() {
+		fixFlag = false
+		quietFlag = false
+		formatFlag = formatText
+	})
+
+	tests := []struct {
+		name    string
+		paths   []string
+		fix     bool
+		quiet   bool
+		format  string
+		wantErr bool
+	}{
+		{name: "directory", paths: []string{".github/workflows"}, format: formatText},
+		{name: "stdin", paths: []string{stdinPath}, format: formatText, wantErr: true},
+		{name: "fix", paths: []string{"."}, fix: true, format: formatText, wantErr: true},
+		{name: "quiet", paths: []string{"."}, quiet: true, format: formatText, wantErr: true},
+		{name: "json format", paths: []string{"."}, format: formatJSON, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixFlag = tt.fix
+			quietFlag = tt.quiet
+			formatFlag = tt.format
+			if err := validateWatchFlags(tt.paths); (err != nil) != tt.wantErr {
+				t.Errorf("validateWatchFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
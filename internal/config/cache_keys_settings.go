@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CacheKeysSettings contains settings for the cache-keys linter.
+type CacheKeysSettings struct {
+	RuleSettings `yaml:",inline"`
+
+	// Actions lists additional actions (owner/repo[/path]) whose key input must include
+	// a hashFiles(...) expression, besides actions/cache and its restore and save actions
+	Actions []string `yaml:"actions"`
+	// CheckSetupActions also checks the key or cache-key input of setup-* actions
+	// that don't disable caching (default: true). Unset means true.
+	CheckSetupActions *bool `yaml:"check-setup-actions,omitempty"`
+}
+
+// ShouldCheckSetupActions returns true if the key inputs of setup-* actions
+// should be checked.
+func (c *CacheKeysSettings) ShouldCheckSetupActions() bool {
+	return c == nil || c.CheckSetupActions == nil || *c.CheckSetupActions
+}
+
+// Validate checks CacheKeysSettings for invalid values.
+func (c *CacheKeysSettings) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, action := range c.Actions {
+		owner, repo, _ := strings.Cut(action, "/")
+		if owner == "" || repo == "" || strings.ContainsAny(action, "@ ") {
+			return fmt.Errorf("cache-keys.actions: invalid action %q (use owner/repo or owner/repo/path "+
+				"without a ref)", action)
+		}
+	}
+	return nil
+}
+
+// DefaultCacheKeysSettings returns the default cache-keys linter settings.
+func DefaultCacheKeysSettings() *CacheKeysSettings {
+	checkSetupActions := true
+	return &CacheKeysSettings{
+		Actions:           []string{},
+		CheckSetupActions: &checkSetupActions,
+	}
+}
+
+// GetCacheKeysSettings returns the cache-keys linter settings from config.
+func (c *Config) GetCacheKeysSettings() *CacheKeysSettings {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.CacheKeys != nil {
+		return c.Linters.Settings.CacheKeys
+	}
+	return DefaultCacheKeysSettings()
+}
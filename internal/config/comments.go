@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fieldComments maps the dotted path of each configuration key to the comment written
+// above it by MarshalCommented. Keys that are omitted when empty are described in the
+// comment of their parent, so users still learn they exist.
+var fieldComments = map[string]string{
+	"run": "General runtime settings.\n" +
+		"Also available: offline (skip linters that need the GitHub API) and\n" +
+		"silent-fixers (linters that only fix with --fix and never report issues).",
+	"run.timeout":          "Maximum duration of a command, e.g., 30s or 2m.",
+	"run.issues-exit-code": "Exit code when lint issues are found (1-255).",
+	"run.max-retry-wait":   "Longest wait before retrying a rate-limited GitHub API call.",
+
+	"linters": "Which linters run and how they are configured.",
+	"linters.default": "Baseline for enabled linters: all or none. Opt-in linters (hosts, pr-secrets,\n" +
+		"cache-keys) only run when listed in enable.",
+	"linters.enable":  "Linters to run in addition to the default.",
+	"linters.disable": "Linters to skip; takes precedence over enable.",
+	"linters.settings": "Per-linter settings.\n" +
+		"Also available: severity (error, warning, or info per linter, e.g., style: info)\n" +
+		"and concurrency (workflows linted in parallel, default: number of CPUs).\n" +
+		"The settings of every linter also accept disabled-rules, a list of rule IDs of\n" +
+		"the linter to skip (e.g., format/line-length); see github-ci explain <linter>.",
+
+	"linters.settings.versions": "versions: actions pinned to version tags instead of commit hashes.",
+	"linters.settings.versions.managed-comment": "Marker appended to the version comment of actions pinned by --fix,\n" +
+		"e.g., \"managed by github-ci\".",
+	"linters.settings.versions.suggest-upgrades": "Suggest the latest version allowed by the upgrade constraint " +
+		"(GitHub API).",
+	"linters.settings.versions.check-reusable-workflows": "Report called reusable workflows missing at the " +
+		"referenced ref (GitHub API).",
+	"linters.settings.versions.fix-pin-comments": "Make --fix add the tag as a comment to hash pins without one " +
+		"(GitHub API).",
+	"linters.settings.versions.allowed-owners": "Owners whose actions may be used, e.g., actions and github " +
+		"(empty = any owner).",
+
+	"linters.settings.permissions": "permissions: missing or overly broad token permissions.",
+	"linters.settings.permissions.warn-workflow-writes": "Warn on write access granted at the workflow level " +
+		"instead of per job.",
+	"linters.settings.permissions.warn-persist-credentials": "Warn when actions/checkout doesn't set " +
+		"persist-credentials: false.",
+	"linters.settings.permissions.fix-permissions": "Permissions inserted by --fix: read-all, {}, or an inline map " +
+		"such as {contents: read}.",
+
+	"linters.settings.format":                 "format: indentation, line length, and trailing whitespace.",
+	"linters.settings.format.indent-width":    "Spaces per indentation level, or auto to infer it from each file.",
+	"linters.settings.format.max-line-length": "Maximum line length.",
+	"linters.settings.format.check-block-scalars": "Report trailing whitespace inside block scalars such as " +
+		"run: | scripts.",
+
+	"linters.settings.style": "style: naming conventions and best practices.\n" +
+		"Also available: allowed-working-directories (paths outside the workspace steps may use)\n" +
+		"and full-history-workflows (workflow name or file patterns whose checkout steps\n" +
+		"must set fetch-depth, e.g., release*).",
+	"linters.settings.style.min-name-length": "Minimum characters in workflow, job, and step names.",
+	"linters.settings.style.max-name-length": "Maximum characters in workflow, job, and step names.",
+	"linters.settings.style.naming-convention": "Name casing: title (\"Build And Test\"), " +
+		"sentence (\"Build and test\"),\nor \"\" for none.",
+	"linters.settings.style.checkout-first":     "Warn if actions/checkout is not the first step.",
+	"linters.settings.style.require-step-names": "Require every step to have a name.",
+	"linters.settings.style.max-run-lines":      "Maximum lines in a run script (0 = disabled).",
+	"linters.settings.style.max-jobs":           "Maximum jobs in a workflow (0 = disabled).",
+	"linters.settings.style.distinct-workflow-names": "Require unique workflow names, and reusable workflow names " +
+		"that don't repeat the file name.",
+	"linters.settings.style.require-pipefail": "Warn on multi-line run scripts with pipes in a shell " +
+		"without pipefail.",
+	"linters.settings.style.warn-token-override": "Warn when env sets GITHUB_TOKEN to a custom value.",
+	"linters.settings.style.warn-constant-concurrency": "Warn on concurrency groups without expressions, " +
+		"which serialize all runs.",
+	"linters.settings.style.require-job-timeout": "Require jobs to set timeout-minutes.",
+	"linters.settings.style.max-job-timeout":     "Maximum timeout-minutes for a job (0 = disabled).",
+	"linters.settings.style.require-concurrency": "Suggest a concurrency group for push and pull_request workflows.",
+	"linters.settings.style.fix-step-key-order": "Make --fix reorder step keys: name, id, if, uses or run, " +
+		"with, env.",
+
+	"linters.settings.runners":        "runners: deprecated or floating runner labels.",
+	"linters.settings.runners.strict": "Warn on floating labels such as ubuntu-latest.",
+
+	"linters.settings.secrets": "secrets: hardcoded credentials.\n" +
+		"Also available: allow (values that are never reported, e.g., a test fixture).",
+	"linters.settings.secrets.min-entropy": "Entropy in bits per character above which a string is reported " +
+		"(0 = disabled).",
+	"linters.settings.secrets.min-length": "Minimum length of strings checked for entropy.",
+
+	"linters.settings.hosts":                "hosts (opt-in): internal IP addresses and hostnames.",
+	"linters.settings.hosts.private-ranges": "Report addresses in the RFC 1918 private ranges.",
+	"linters.settings.hosts.ranges":         "Additional CIDR ranges to report, e.g., 100.64.0.0/10.",
+	"linters.settings.hosts.domains":        "Domain suffixes to report, e.g., corp.example.com.",
+
+	"linters.settings.injection": "injection: untrusted input in run scripts.",
+	"linters.settings.injection.check-with-inputs": "Also report untrusted contexts passed to action inputs " +
+		"under with:.",
+
+	"linters.settings.continue-on-error": "continue-on-error: jobs and steps whose failures are ignored.",
+	"linters.settings.continue-on-error.allow-jobs": "Job IDs that may set continue-on-error: true, " +
+		"e.g., an experimental build.",
+
+	"linters.settings.cache-keys": "cache-keys (opt-in): cache keys without a hashFiles(...) expression.",
+	"linters.settings.cache-keys.actions": "Additional actions whose key input is checked, " +
+		"besides actions/cache.",
+	"linters.settings.cache-keys.check-setup-actions": "Also check the key or cache-key input of " +
+		"setup-* actions with caching.",
+
+	"upgrade": "Settings for the upgrade command.",
+	"upgrade.actions": "Version constraint per action: ^X.0.0 (same major), ~X.Y.0 (same minor),\n" +
+		"or \"\" (any newer version).",
+	"upgrade.format": "Version format written by upgrade: tag, hash, or major.",
+}
+
+// MarshalCommented marshals cfg to YAML with an explanatory comment above each key.
+func MarshalCommented(cfg *Config) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to marshal config file: %w", err)
+	}
+	addComments(&node, "")
+
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config file: %w", err)
+	}
+	return data, nil
+}
+
+// SaveCommentedConfig saves the configuration to the specified file, with an
+// explanatory comment above each key.
+func SaveCommentedConfig(cfg *Config, filename string) error {
+	if filename == "" {
+		filename = DefaultConfigFileName
+	}
+
+	data, err := MarshalCommented(cfg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, data, 0600)
+}
+
+// addComments sets the comment from fieldComments above each key of the mapping node,
+// recursing into nested mappings. path is the dotted path of the node.
+func addComments(node *yaml.Node, path string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		keyPath := joinKeyPath(path, key.Value)
+		if comment, ok := fieldComments[keyPath]; ok {
+			key.HeadComment = comment
+		}
+		addComments(node.Content[i+1], keyPath)
+	}
+}
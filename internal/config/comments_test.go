@@ -0,0 +1,76 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarshalCommented(t *testing.T) {
+	cfg := NewFullDefaultConfig()
+	cfg.Run = DefaultRunConfig()
+	cfg.SetActionConfig("actions/checkout", DefaultActionConfig)
+
+	data, err := MarshalCommented(cfg)
+	if err != nil {
+		t.Fatalf("MarshalCommented() error = %v", err)
+	}
+
+	// The generated file must load with the same values
+	loaded, err := parseConfig(data)
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v\n%s", err, data)
+	}
+	want, _ := yaml.Marshal(cfg)
+	got, _ := yaml.Marshal(loaded)
+	if string(got) != string(want) {
+		t.Errorf("loaded config =\n%s\nwant\n%s", got, want)
+	}
+
+	// Every key has a comment, except the action names under upgrade.actions
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	var missing []string
+	walkKeys(node.Content[0], "", func(key *yaml.Node, path string) {
+		if key.HeadComment == "" && !strings.HasPrefix(path, "upgrade.actions.") {
+			missing = append(missing, path)
+		}
+	})
+	if len(missing) > 0 {
+		t.Errorf("keys without a comment: %v", missing)
+	}
+}
+
+func TestFieldComments_KnownKeys(t *testing.T) {
+	for path := range fieldComments {
+		typ := reflect.TypeFor[Config]()
+		for key := range strings.SplitSeq(path, ".") {
+			for typ.Kind() == reflect.Pointer {
+				typ = typ.Elem()
+			}
+			field, ok := yamlFields(typ)[key]
+			if !ok {
+				t.Errorf("comment for %q: unknown key %q", path, key)
+				break
+			}
+			typ = field.Type
+		}
+	}
+}
+
+// walkKeys calls fn for each key of the mapping node and its nested mappings,
+// with the dotted path of the key.
+func walkKeys(node *yaml.Node, path string, fn func(key *yaml.Node, path string)) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyPath := joinKeyPath(path, node.Content[i].Value)
+		fn(node.Content[i], keyPath)
+		walkKeys(node.Content[i+1], keyPath, fn)
+	}
+}
@@ -2,7 +2,10 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"reflect"
 	"slices"
 	"strings"
 	"time"
@@ -12,8 +15,17 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// DefaultConfigFileName is the default name of the configuration file.
-const DefaultConfigFileName = ".github-ci.yaml"
+const (
+	// DefaultConfigFileName is the default name of the configuration file.
+	DefaultConfigFileName = ".github-ci.yaml"
+	// AltConfigFileName is the alternative name of the configuration file.
+	AltConfigFileName = ".github-ci.yml"
+	// ConfigEnvVar is the environment variable that may hold inline YAML configuration.
+	ConfigEnvVar = "GITHUB_CI_CONFIG"
+)
+
+// configFileNames lists the names LoadConfig looks for, in order of preference.
+var configFileNames = []string{DefaultConfigFileName, AltConfigFileName}
 
 // DefaultActionConfig is the default configuration for newly discovered actions.
 var DefaultActionConfig = ActionConfig{Constraint: defaultVersionConstraint}
@@ -41,8 +53,11 @@ func (c *Config) Validate() error {
 
 // RunConfig specifies general runtime settings.
 type RunConfig struct {
-	Timeout        string `yaml:"timeout"`          // Duration string (e.g., "2m", "30s")
-	IssuesExitCode int    `yaml:"issues-exit-code"` // Exit code when issues are found (default: 1)
+	Timeout        string   `yaml:"timeout"`                  // Duration string (e.g., "2m", "30s")
+	IssuesExitCode int      `yaml:"issues-exit-code"`         // Exit code when issues are found (default: 1)
+	SilentFixers   []string `yaml:"silent-fixers,omitempty"`  // Linters that only fix and never report issues
+	Offline        bool     `yaml:"offline,omitempty"`        // Skip linters that need the GitHub API
+	MaxRetryWait   string   `yaml:"max-retry-wait,omitempty"` // Longest wait before retrying a rate-limited API call
 }
 
 // Validate checks RunConfig for invalid values.
@@ -55,9 +70,23 @@ func (r *RunConfig) Validate() error {
 			return fmt.Errorf("invalid timeout %q: %w", r.Timeout, err)
 		}
 	}
+	if r.MaxRetryWait != "" {
+		if d, err := time.ParseDuration(r.MaxRetryWait); err != nil || d < 0 {
+			return fmt.Errorf("invalid max-retry-wait %q: must be a non-negative duration", r.MaxRetryWait)
+		}
+	}
 	if r.IssuesExitCode != 0 && (r.IssuesExitCode < 1 || r.IssuesExitCode > 255) {
 		return fmt.Errorf("issues-exit-code must be between 1 and 255, got %d", r.IssuesExitCode)
 	}
+	// Exit codes 2 and 3 report usage and internal errors, so issues must not share them
+	if r.IssuesExitCode == 2 || r.IssuesExitCode == 3 {
+		return fmt.Errorf("issues-exit-code %d is reserved for usage and internal errors", r.IssuesExitCode)
+	}
+	for _, name := range r.SilentFixers {
+		if !slices.Contains(allLinters, name) {
+			return fmt.Errorf("unknown linter %q in run.silent-fixers", name)
+		}
+	}
 	return nil
 }
 
@@ -66,8 +95,19 @@ const (
 	DefaultTimeout = 5 * time.Minute
 	// DefaultIssuesExitCode is the default exit code when lint issues are found.
 	DefaultIssuesExitCode = 1
+	// DefaultMaxRetryWait is the default longest wait before retrying a rate-limited API call.
+	DefaultMaxRetryWait = time.Minute
 )
 
+// DefaultRunConfig returns a RunConfig with every setting that has a default set to it.
+func DefaultRunConfig() *RunConfig {
+	return &RunConfig{
+		Timeout:        DefaultTimeout.String(),
+		IssuesExitCode: DefaultIssuesExitCode,
+		MaxRetryWait:   DefaultMaxRetryWait.String(),
+	}
+}
+
 // GetTimeout returns the configured timeout duration.
 // Returns DefaultTimeout if not configured or invalid.
 func (c *Config) GetTimeout() time.Duration {
@@ -91,32 +131,119 @@ func (c *Config) GetIssuesExitCode() int {
 	return c.Run.IssuesExitCode
 }
 
-// LoadConfig loads configuration from the specified file.
-// Returns defaults if file doesn't exist.
+// GetMaxRetryWait returns the longest time to wait before retrying a rate-limited
+// GitHub API call. Returns DefaultMaxRetryWait if not configured or invalid.
+func (c *Config) GetMaxRetryWait() time.Duration {
+	if c == nil || c.Run == nil || c.Run.MaxRetryWait == "" {
+		return DefaultMaxRetryWait
+	}
+	d, err := time.ParseDuration(c.Run.MaxRetryWait)
+	if err != nil || d < 0 {
+		return DefaultMaxRetryWait
+	}
+	return d
+}
+
+// LoadConfig loads configuration using the following precedence:
+//  1. filename, if non-empty and the file exists
+//  2. inline YAML from the GITHUB_CI_CONFIG environment variable
+//  3. the nearest config file found by FindConfigFile from the current directory,
+//     if filename is empty
+//  4. built-in defaults
 func LoadConfig(filename string) (*Config, error) {
+	switch source := Source(filename); source {
+	case "":
+		return NewDefaultConfig(), nil
+	case SourceEnvVar:
+		cfg, err := parseConfig([]byte(os.Getenv(ConfigEnvVar)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ConfigEnvVar, err)
+		}
+		return cfg, nil
+	default:
+		return loadConfigFile(source)
+	}
+}
+
+// SourceEnvVar is the source returned by Source for configuration read from the
+// GITHUB_CI_CONFIG environment variable.
+const SourceEnvVar = "$" + ConfigEnvVar
+
+// Source returns where LoadConfig(filename) reads the configuration from, following
+// the same precedence: the path of a config file, SourceEnvVar, or an empty string
+// for built-in defaults.
+func Source(filename string) string {
+	if filename != "" && osutil.FileExists(filename) {
+		return filename
+	}
+	if os.Getenv(ConfigEnvVar) != "" {
+		return SourceEnvVar
+	}
 	if filename == "" {
-		filename = DefaultConfigFileName
+		return FindConfigFile(".")
 	}
+	return ""
+}
 
-	if !osutil.FileExists(filename) {
-		return NewDefaultConfig(), nil
+// FindConfigFile returns the path of the nearest .github-ci.yaml or .github-ci.yml
+// in dir or its parents, like git looks for its repository. The search stops at a
+// repository root (a directory containing .git) or the filesystem root. Returns an
+// empty string if no config file is found.
+func FindConfigFile(dir string) string {
+	for {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+		if osutil.FileExists(filepath.Join(dir, ".git")) {
+			return ""
+		}
+
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return ""
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+		dir = parent
 	}
+}
 
+// loadConfigFile reads and parses the configuration file at filename.
+func loadConfigFile(filename string) (*Config, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	return parseConfig(data)
+}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+// parseConfig parses and validates YAML configuration data.
+// Unknown keys are rejected so that typos don't silently change behavior.
+func parseConfig(data []byte) (*Config, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config file: %w", err)
 	}
 
-	if err := cfg.Validate(); err != nil {
+	var cfg Config
+	if err := checkKnownKeys(&node, reflect.TypeFor[Config](), ""); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
+	if len(node.Content) > 0 {
+		if err := node.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config file: %w", err)
+		}
+	}
 
 	cfg.ensureDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 	return &cfg, nil
 }
 
@@ -164,6 +291,85 @@ func (c *Config) ensureDefaults() {
 	}
 }
 
+// Resolved returns a copy of the config with every unset value replaced by the value
+// in effect: the run settings as returned by their getters, all linter settings, the
+// severity of each linter, and the upgrade defaults. The receiver isn't modified, but
+// the returned config may share settings with it.
+func (c *Config) Resolved() *Config {
+	resolved := &Config{
+		Run: &RunConfig{
+			Timeout:        c.GetTimeout().String(),
+			IssuesExitCode: c.GetIssuesExitCode(),
+			MaxRetryWait:   c.GetMaxRetryWait().String(),
+			Offline:        c.IsOffline(),
+		},
+		Linters: DefaultLinterConfig(),
+		Upgrade: DefaultUpgradeConfig(),
+	}
+	if c == nil {
+		c = NewDefaultConfig()
+	}
+	if c.Run != nil {
+		resolved.Run.SilentFixers = slices.Clone(c.Run.SilentFixers)
+	}
+	if c.Linters != nil {
+		if c.Linters.Default != "" {
+			resolved.Linters.Default = c.Linters.Default
+		}
+		resolved.Linters.Enable = append(resolved.Linters.Enable, c.Linters.Enable...)
+		resolved.Linters.Disable = append(resolved.Linters.Disable, c.Linters.Disable...)
+	}
+	if c.Upgrade != nil {
+		resolved.Upgrade.Format = c.GetVersionFormat()
+		maps.Copy(resolved.Upgrade.Actions, c.Upgrade.Actions)
+	}
+
+	severity := make(map[string]string, len(allLinters))
+	for _, name := range allLinters {
+		severity[name] = c.GetSeverity(name)
+	}
+	// Unset optional flags default to true
+	enabled := true
+	format := *c.GetFormatSettings()
+	if format.CheckBlockScalars == nil {
+		format.CheckBlockScalars = &enabled
+	}
+	hosts := *c.GetHostsSettings()
+	if hosts.PrivateRanges == nil {
+		hosts.PrivateRanges = &enabled
+	}
+	cacheKeys := *c.GetCacheKeysSettings()
+	if cacheKeys.CheckSetupActions == nil {
+		cacheKeys.CheckSetupActions = &enabled
+	}
+
+	resolved.Linters.Settings = &LinterSettings{
+		Versions:    c.GetVersionsSettings(),
+		Permissions: c.GetPermissionsSettings(),
+		Format:      &format,
+		Style:       c.GetStyleSettings(),
+		Runners:     c.GetRunnersSettings(),
+		Secrets:     c.GetSecretsSettings(),
+		Hosts:       &hosts,
+		Injection:   c.GetInjectionSettings(),
+		Severity:    severity,
+		Concurrency: c.GetConcurrency(),
+
+		ContinueOnError: c.GetContinueOnErrorSettings(),
+		CacheKeys:       &cacheKeys,
+	}
+	if c.Linters != nil && c.Linters.Settings != nil {
+		settings := c.Linters.Settings
+		resolved.Linters.Settings.Needs = settings.Needs
+		resolved.Linters.Settings.Triggers = settings.Triggers
+		resolved.Linters.Settings.Matrix = settings.Matrix
+		resolved.Linters.Settings.PRSecrets = settings.PRSecrets
+		resolved.Linters.Settings.Commands = settings.Commands
+		resolved.Linters.Settings.TokenScopes = settings.TokenScopes
+	}
+	return resolved
+}
+
 // GetActionConfig returns the action config, or default if not found.
 func (c *Config) GetActionConfig(actionName string) ActionConfig {
 	if c.Upgrade != nil {
@@ -189,17 +395,62 @@ func (c *Config) GetVersionFormat() string {
 	return c.Upgrade.Format
 }
 
+// IsOffline returns true if linters that need network access should be skipped.
+func (c *Config) IsOffline() bool {
+	return c != nil && c.Run != nil && c.Run.Offline
+}
+
+// SetOffline enables offline mode, creating the run section if needed.
+func (c *Config) SetOffline() {
+	if c.Run == nil {
+		c.Run = &RunConfig{}
+	}
+	c.Run.Offline = true
+}
+
+// SelectLinters overrides which linters run, in memory only. If only is non-empty,
+// exactly those linters are enabled, including opt-in ones; linters in skip are disabled
+// unless also listed in only.
+func (c *Config) SelectLinters(only, skip []string) {
+	if len(only) == 0 && len(skip) == 0 {
+		return
+	}
+	if c.Linters == nil {
+		c.Linters = DefaultLinterConfig()
+	}
+	if len(only) > 0 {
+		c.Linters.Default = "none"
+		c.Linters.Enable = slices.Clone(only)
+		c.Linters.Disable = nil
+	}
+	for _, name := range skip {
+		if !slices.Contains(only, name) {
+			c.Linters.Disable = append(c.Linters.Disable, name)
+		}
+	}
+}
+
+// IsSilentFixer returns true if the linter's issues should not be reported.
+// Silent fixers still apply their fixes under --fix.
+func (c *Config) IsSilentFixer(linterName string) bool {
+	if c == nil || c.Run == nil {
+		return false
+	}
+	return slices.Contains(c.Run.SilentFixers, linterName)
+}
+
 // IsLinterEnabled checks if a linter is enabled based on configuration.
+// Opt-in linters are only enabled when listed in linters.enable.
 func (c *Config) IsLinterEnabled(linterName string) bool {
-	if c.Linters == nil {
-		return true
+	if c == nil || c.Linters == nil {
+		return !IsOptInLinter(linterName)
 	}
 
 	if slices.Contains(c.Linters.Disable, linterName) {
 		return false
 	}
 
-	if c.Linters.Default == defaultLinterDefault {
+	if c.Linters.Default == defaultLinterDefault && !IsOptInLinter(linterName) {
 		return true
 	}
 
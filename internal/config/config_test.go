@@ -3,8 +3,13 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadConfig_NonExistent(t *testing.T) {
@@ -97,6 +102,331 @@ func TestLoadConfig_InvalidYAML(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_InvalidValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "invalid linters default",
+			content: "linters:\n  default: sometimes\n",
+			wantErr: `invalid config: linters.default must be "all" or "none", got "sometimes"`,
+		},
+		{
+			name:    "unknown enabled linter",
+			content: "linters:\n  enable: [bogus]\n",
+			wantErr: `invalid config: unknown linter "bogus" in linters.enable`,
+		},
+		{
+			name:    "invalid upgrade format",
+			content: "upgrade:\n  format: latest\n",
+			wantErr: `invalid config: upgrade.format must be one of [tag hash major], got "latest"`,
+		},
+		{
+			name:    "upgrade version is not a key",
+			content: "upgrade:\n  version: hash\n",
+			wantErr: `invalid config: unknown key "version" in upgrade at line 2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".github-ci.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			_, err := LoadConfig(configPath)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseConfig_UnknownKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "empty config",
+			content: "",
+		},
+		{
+			name:    "known keys",
+			content: "linters:\n  enable: [hosts]\n  settings:\n    severity:\n      style: info\n",
+		},
+		{
+			name:    "unknown top-level key",
+			content: "linter:\n  enable: [hosts]\n",
+			wantErr: `invalid config: unknown key "linter" at line 1`,
+		},
+		{
+			name:    "unknown nested key",
+			content: "linters:\n  default: all\n  enabled: [hosts]\n",
+			wantErr: `invalid config: unknown key "enabled" in linters at line 3`,
+		},
+		{
+			name:    "unknown linter setting",
+			content: "linters:\n  settings:\n    style:\n      max-job: 5\n",
+			wantErr: `invalid config: unknown key "max-job" in linters.settings.style at line 4`,
+		},
+		{
+			name:    "unknown key in map value",
+			content: "upgrade:\n  actions:\n    actions/checkout:\n      constrain: ^4.0.0\n",
+			wantErr: `invalid config: unknown key "constrain" in upgrade.actions.actions/checkout at line 4`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(tt.content))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("parseConfig() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("parseConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseConfig_FullDefaultConfig(t *testing.T) {
+	data, err := yaml.Marshal(NewFullDefaultConfig())
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	if _, err := parseConfig(data); err != nil {
+		t.Errorf("parseConfig() of the full default config error = %v", err)
+	}
+}
+
+func TestLoadConfig_EnvVar(t *testing.T) {
+	t.Setenv(ConfigEnvVar, `
+run:
+  issues-exit-code: 9
+linters:
+  default: none
+  enable:
+    - injection
+`)
+
+	cfg, err := LoadConfig("/nonexistent/path/.github-ci.yaml")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if cfg.GetIssuesExitCode() != 9 {
+		t.Errorf("GetIssuesExitCode() = %d, want 9", cfg.GetIssuesExitCode())
+	}
+	if !cfg.IsLinterEnabled(LinterInjection) || cfg.IsLinterEnabled(LinterFormat) {
+		t.Errorf("cfg.Linters = %+v, want only injection enabled", cfg.Linters)
+	}
+	if cfg.Upgrade == nil {
+		t.Error("cfg.Upgrade is nil, want defaults applied")
+	}
+}
+
+func TestLoadConfig_EnvVarInvalid(t *testing.T) {
+	t.Setenv(ConfigEnvVar, "linters:\n  default: sometimes\n")
+
+	_, err := LoadConfig("")
+	if err == nil {
+		t.Fatal("LoadConfig() expected error for invalid inline config")
+	}
+	if !strings.Contains(err.Error(), ConfigEnvVar) {
+		t.Errorf("LoadConfig() error = %v, want it to mention %s", err, ConfigEnvVar)
+	}
+}
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	if err := os.WriteFile(DefaultConfigFileName, []byte("run:\n  issues-exit-code: 4\n"), 0600); err != nil {
+		t.Fatalf("Failed to write default config: %v", err)
+	}
+	explicitPath := filepath.Join(tmpDir, "explicit.yaml")
+	if err := os.WriteFile(explicitPath, []byte("run:\n  issues-exit-code: 5\n"), 0600); err != nil {
+		t.Fatalf("Failed to write explicit config: %v", err)
+	}
+	t.Setenv(ConfigEnvVar, "run:\n  issues-exit-code: 6\n")
+
+	tests := []struct {
+		name     string
+		filename string
+		want     int
+	}{
+		{"explicit file wins over env", explicitPath, 5},
+		{"env wins over discovered file", "", 6},
+		{"env used when explicit file is missing", filepath.Join(tmpDir, "missing.yaml"), 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadConfig(tt.filename)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if got := cfg.GetIssuesExitCode(); got != tt.want {
+				t.Errorf("GetIssuesExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("discovered file without env", func(t *testing.T) {
+		t.Setenv(ConfigEnvVar, "")
+		cfg, err := LoadConfig("")
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+		if got := cfg.GetIssuesExitCode(); got != 4 {
+			t.Errorf("GetIssuesExitCode() = %d, want 4", got)
+		}
+	})
+}
+
+func TestSource(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	explicitPath := filepath.Join(tmpDir, "explicit.yaml")
+	if err := os.WriteFile(explicitPath, []byte("run:\n  issues-exit-code: 5\n"), 0600); err != nil {
+		t.Fatalf("Failed to write explicit config: %v", err)
+	}
+	missingPath := filepath.Join(tmpDir, "missing.yaml")
+
+	tests := []struct {
+		name       string
+		filename   string
+		env        string
+		discovered bool
+		want       string
+	}{
+		{name: "defaults", want: ""},
+		{name: "explicit file", filename: explicitPath, env: "run: {}", want: explicitPath},
+		{name: "env when explicit file is missing", filename: missingPath, env: "run: {}", want: SourceEnvVar},
+		{name: "defaults when explicit file is missing", filename: missingPath, discovered: true, want: ""},
+		{name: "discovered file", discovered: true, want: DefaultConfigFileName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ConfigEnvVar, tt.env)
+			if tt.discovered {
+				if err := os.WriteFile(DefaultConfigFileName, []byte("run: {}\n"), 0600); err != nil {
+					t.Fatalf("Failed to write default config: %v", err)
+				}
+				t.Cleanup(func() { _ = os.Remove(DefaultConfigFileName) })
+			}
+			if got := Source(tt.filename); got != tt.want {
+				t.Errorf("Source(%q) = %q, want %q", tt.filename, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindConfigFile(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	nested := filepath.Join(repo, "services", "api")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Outside the repository, so never found from within it
+	if err := os.WriteFile(filepath.Join(root, DefaultConfigFileName), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(t *testing.T, path string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("run:\n  issues-exit-code: 9\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.Remove(path) })
+	}
+
+	tests := []struct {
+		name  string
+		files []string
+		dir   string
+		want  string
+	}{
+		{"none up to repository root", nil, nested, ""},
+		{"in the same directory", []string{filepath.Join(nested, DefaultConfigFileName)}, nested,
+			filepath.Join(nested, DefaultConfigFileName)},
+		{"yml extension", []string{filepath.Join(nested, AltConfigFileName)}, nested,
+			filepath.Join(nested, AltConfigFileName)},
+		{"yaml preferred over yml", []string{
+			filepath.Join(nested, DefaultConfigFileName), filepath.Join(nested, AltConfigFileName),
+		}, nested, filepath.Join(nested, DefaultConfigFileName)},
+		{"repository root", []string{filepath.Join(repo, AltConfigFileName)}, nested,
+			filepath.Join(repo, AltConfigFileName)},
+		{"nearest wins", []string{
+			filepath.Join(repo, DefaultConfigFileName), filepath.Join(repo, "services", DefaultConfigFileName),
+		}, nested, filepath.Join(repo, "services", DefaultConfigFileName)},
+		{"outside a repository", nil, root, filepath.Join(root, DefaultConfigFileName)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, file := range tt.files {
+				write(t, file)
+			}
+			if got := FindConfigFile(tt.dir); got != tt.want {
+				t.Errorf("FindConfigFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_DiscoveredInParent(t *testing.T) {
+	repo := t.TempDir()
+	nested := filepath.Join(repo, "services", "api")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, AltConfigFileName), []byte("run:\n  issues-exit-code: 7\n"),
+		0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(nested)
+	t.Setenv(ConfigEnvVar, "")
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := cfg.GetIssuesExitCode(); got != 7 {
+		t.Errorf("GetIssuesExitCode() = %d, want 7", got)
+	}
+
+	// An explicit file takes precedence over discovery
+	explicitPath := filepath.Join(nested, "explicit.yaml")
+	if err := os.WriteFile(explicitPath, []byte("run:\n  issues-exit-code: 8\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfig(explicitPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := cfg.GetIssuesExitCode(); got != 8 {
+		t.Errorf("GetIssuesExitCode() = %d, want 8", got)
+	}
+}
+
 func TestSaveConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, ".github-ci.yaml")
@@ -302,6 +632,60 @@ func TestConfig_IsLinterEnabled(t *testing.T) {
 	}
 }
 
+func TestConfig_SelectLinters(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		only     []string
+		skip     []string
+		expected []string
+	}{
+		{
+			name: "no selection keeps config",
+			cfg:  &Config{Linters: &LinterConfig{Default: "all", Disable: []string{LinterStyle}}},
+			expected: []string{LinterVersions, LinterPermissions, LinterFormat, LinterSecrets, LinterInjection,
+				LinterRunners, LinterNeeds, LinterTriggers, LinterMatrix, LinterCommands,
+				LinterContinueOnError, LinterTokenScopes},
+		},
+		{
+			name:     "only overrides enable and disable",
+			cfg:      &Config{Linters: &LinterConfig{Default: "all", Disable: []string{LinterInjection}}},
+			only:     []string{LinterInjection, LinterHosts},
+			expected: []string{LinterInjection, LinterHosts},
+		},
+		{
+			name: "skip adds to disable",
+			cfg:  &Config{},
+			skip: []string{LinterVersions, LinterStyle},
+			expected: []string{LinterPermissions, LinterFormat, LinterSecrets, LinterInjection, LinterRunners,
+				LinterNeeds, LinterTriggers, LinterMatrix, LinterCommands, LinterContinueOnError, LinterTokenScopes},
+		},
+		{
+			name:     "only wins over skip",
+			cfg:      &Config{},
+			only:     []string{LinterInjection, LinterSecrets},
+			skip:     []string{LinterInjection},
+			expected: []string{LinterSecrets, LinterInjection},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.SelectLinters(tt.only, tt.skip)
+
+			var enabled []string
+			for _, name := range AllLinters() {
+				if tt.cfg.IsLinterEnabled(name) {
+					enabled = append(enabled, name)
+				}
+			}
+			if !slices.Equal(enabled, tt.expected) {
+				t.Errorf("enabled linters = %v, want %v", enabled, tt.expected)
+			}
+		})
+	}
+}
+
 func TestConfig_GetTimeout(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -350,6 +734,111 @@ func TestConfig_GetTimeout(t *testing.T) {
 	}
 }
 
+func TestConfig_GetMaxRetryWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected time.Duration
+	}{
+		{
+			name:     "nil Run",
+			cfg:      &Config{},
+			expected: DefaultMaxRetryWait,
+		},
+		{
+			name:     "invalid duration",
+			cfg:      &Config{Run: &RunConfig{MaxRetryWait: "soon"}},
+			expected: DefaultMaxRetryWait,
+		},
+		{
+			name:     "disabled",
+			cfg:      &Config{Run: &RunConfig{MaxRetryWait: "0s"}},
+			expected: 0,
+		},
+		{
+			name:     "5 minutes",
+			cfg:      &Config{Run: &RunConfig{MaxRetryWait: "5m"}},
+			expected: 5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.cfg.GetMaxRetryWait()
+			if result != tt.expected {
+				t.Errorf("GetMaxRetryWait() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_GetConcurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected int
+	}{
+		{"nil config", nil, runtime.GOMAXPROCS(0)},
+		{"no settings", &Config{Linters: &LinterConfig{}}, runtime.GOMAXPROCS(0)},
+		{"zero", &Config{Linters: &LinterConfig{Settings: &LinterSettings{Concurrency: 0}}}, runtime.GOMAXPROCS(0)},
+		{"configured", &Config{Linters: &LinterConfig{Settings: &LinterSettings{Concurrency: 3}}}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.GetConcurrency(); got != tt.expected {
+				t.Errorf("GetConcurrency() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsRuleDisabled(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".github-ci.yaml")
+	content := `linters:
+  settings:
+    format:
+      max-line-length: 100
+      disabled-rules:
+        - format/line-length
+    needs:
+      disabled-rules:
+        - needs/cycle
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := cfg.GetFormatSettings().MaxLineLength; got != 100 {
+		t.Errorf("MaxLineLength = %d, want 100", got)
+	}
+
+	tests := []struct {
+		ruleID   string
+		expected bool
+	}{
+		{"format/line-length", true},
+		{"format/trailing-whitespace", false},
+		{"needs/cycle", true},
+		{"style/missing-name", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := cfg.IsRuleDisabled(tt.ruleID); got != tt.expected {
+			t.Errorf("IsRuleDisabled(%q) = %v, want %v", tt.ruleID, got, tt.expected)
+		}
+	}
+
+	var nilConfig *Config
+	if nilConfig.IsRuleDisabled("format/line-length") {
+		t.Error("IsRuleDisabled() on nil config = true, want false")
+	}
+}
+
 func TestConfig_GetIssuesExitCode(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -435,7 +924,7 @@ func TestConfig_GetFormatSettings(t *testing.T) {
 	tests := []struct {
 		name              string
 		cfg               *Config
-		wantIndentWidth   int
+		wantIndentWidth   Indent
 		wantMaxLineLength int
 	}{
 		{
@@ -637,7 +1126,8 @@ func TestFullDefaultLinterConfig(t *testing.T) {
 	// Should have all linters enabled
 	expectedLinters := []string{
 		LinterVersions, LinterPermissions, LinterFormat,
-		LinterSecrets, LinterInjection, LinterStyle,
+		LinterSecrets, LinterInjection, LinterStyle, LinterRunners, LinterNeeds, LinterTriggers,
+		LinterMatrix, LinterCommands, LinterContinueOnError, LinterTokenScopes,
 	}
 	if len(cfg.Enable) != len(expectedLinters) {
 		t.Errorf("Enable has %d linters, want %d", len(cfg.Enable), len(expectedLinters))
@@ -653,6 +1143,9 @@ func TestFullDefaultLinterConfig(t *testing.T) {
 	if cfg.Settings.Style == nil {
 		t.Error("Settings.Style is nil, want non-nil")
 	}
+	if cfg.Settings.Runners == nil {
+		t.Error("Settings.Runners is nil, want non-nil")
+	}
 }
 
 func TestUpgradeConfig_EnsureDefaults(t *testing.T) {
@@ -708,7 +1201,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "valid full config",
 			config: &Config{
-				Run:     &RunConfig{Timeout: "5m", IssuesExitCode: 2},
+				Run:     &RunConfig{Timeout: "5m", IssuesExitCode: 4},
 				Linters: &LinterConfig{Default: "all", Enable: []string{"versions"}},
 				Upgrade: &UpgradeConfig{Format: "tag"},
 			},
@@ -719,6 +1212,11 @@ func TestConfig_Validate(t *testing.T) {
 			config:  &Config{Run: &RunConfig{Timeout: "invalid"}},
 			wantErr: true,
 		},
+		{
+			name:    "invalid max-retry-wait",
+			config:  &Config{Run: &RunConfig{MaxRetryWait: "-1m"}},
+			wantErr: true,
+		},
 		{
 			name:    "invalid exit code too low",
 			config:  &Config{Run: &RunConfig{IssuesExitCode: -1}},
@@ -729,6 +1227,21 @@ func TestConfig_Validate(t *testing.T) {
 			config:  &Config{Run: &RunConfig{IssuesExitCode: 300}},
 			wantErr: true,
 		},
+		{
+			name:    "exit code reserved for usage errors",
+			config:  &Config{Run: &RunConfig{IssuesExitCode: 2}},
+			wantErr: true,
+		},
+		{
+			name:    "exit code reserved for internal errors",
+			config:  &Config{Run: &RunConfig{IssuesExitCode: 3}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown linter in silent-fixers",
+			config:  &Config{Run: &RunConfig{SilentFixers: []string{"unknown"}}},
+			wantErr: true,
+		},
 		{
 			name:    "invalid linter default",
 			config:  &Config{Linters: &LinterConfig{Default: "invalid"}},
@@ -749,6 +1262,22 @@ func TestConfig_Validate(t *testing.T) {
 			config:  &Config{Upgrade: &UpgradeConfig{Format: "invalid"}},
 			wantErr: true,
 		},
+		{
+			name: "disabled rule of the linter",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Triggers: &RuleSettings{DisabledRules: []string{"triggers/unknown-event"}}},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "disabled rule of another linter",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Format: &FormatSettings{
+					RuleSettings: RuleSettings{DisabledRules: []string{"style/missing-name"}},
+				}},
+			}},
+			wantErr: true,
+		},
 		{
 			name: "invalid format indent-width",
 			config: &Config{Linters: &LinterConfig{
@@ -784,6 +1313,125 @@ func TestConfig_Validate(t *testing.T) {
 			}},
 			wantErr: true,
 		},
+		{
+			name: "invalid style max-jobs negative",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Style: &StyleSettings{MaxJobs: -1}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid style max-job-timeout negative",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Style: &StyleSettings{MaxJobTimeout: -1}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid hosts range",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Hosts: &HostsSettings{Ranges: []string{"10.0.0.0/33"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid hosts domain",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Hosts: &HostsSettings{Domains: []string{"."}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid versions managed-comment multiline",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Versions: &VersionsSettings{ManagedComment: "a\nb"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid style full-history-workflows pattern",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Style: &StyleSettings{FullHistoryWorkflows: []string{"release["}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "valid versions allowed-owners",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Versions: &VersionsSettings{AllowedOwners: []string{"actions", "my-org"}}},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "invalid versions allowed-owners repository",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Versions: &VersionsSettings{AllowedOwners: []string{"actions/checkout"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "valid cache-keys actions",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{CacheKeys: &CacheKeysSettings{Actions: []string{"my-org/cache/restore"}}},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "invalid cache-keys actions ref",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{CacheKeys: &CacheKeysSettings{Actions: []string{"actions/cache@v4"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid cache-keys actions owner only",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{CacheKeys: &CacheKeysSettings{Actions: []string{"actions"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "valid permissions fix-permissions map",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Permissions: &PermissionsSettings{FixPermissions: "{contents: read}"}},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "invalid permissions fix-permissions write-all",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Permissions: &PermissionsSettings{FixPermissions: "write-all"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid permissions fix-permissions scope access",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Permissions: &PermissionsSettings{FixPermissions: "{contents: admin}"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid concurrency negative",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Concurrency: -1},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid secrets min-entropy negative",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Secrets: &SecretsSettings{MinEntropy: -1}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid secrets min-length negative",
+			config: &Config{Linters: &LinterConfig{
+				Settings: &LinterSettings{Secrets: &SecretsSettings{MinLength: -1}},
+			}},
+			wantErr: true,
+		},
 		{
 			name: "invalid style min > max name length",
 			config: &Config{Linters: &LinterConfig{
@@ -819,3 +1467,116 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Resolved(t *testing.T) {
+	cfg, err := parseConfig([]byte(`run:
+  timeout: 30s
+linters:
+  default: none
+  enable: [style]
+  settings:
+    format:
+      indent-width: 4
+    severity:
+      style: error
+upgrade:
+  actions:
+    actions/checkout:
+      constraint: ^4.0.0
+`))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+
+	resolved := cfg.Resolved()
+	if resolved.Run.Timeout != "30s" || resolved.Run.IssuesExitCode != DefaultIssuesExitCode ||
+		resolved.Run.MaxRetryWait != DefaultMaxRetryWait.String() {
+		t.Errorf("Resolved().Run = %+v", resolved.Run)
+	}
+	if resolved.Linters.Default != "none" || !slices.Equal(resolved.Linters.Enable, []string{LinterStyle}) {
+		t.Errorf("Resolved().Linters = %+v", resolved.Linters)
+	}
+
+	settings := resolved.Linters.Settings
+	if settings.Format.IndentWidth != 4 || !settings.Format.ShouldCheckBlockScalars() ||
+		settings.Format.CheckBlockScalars == nil {
+		t.Errorf("Resolved() format settings = %+v", settings.Format)
+	}
+	if settings.Style == nil || settings.Style.MaxNameLength != defaultMaxNameLength {
+		t.Errorf("Resolved() style settings = %+v", settings.Style)
+	}
+	if settings.Hosts == nil || settings.Hosts.PrivateRanges == nil || !*settings.Hosts.PrivateRanges {
+		t.Errorf("Resolved() hosts settings = %+v", settings.Hosts)
+	}
+	if len(settings.Severity) != len(allLinters) || settings.Severity[LinterStyle] != SeverityError ||
+		settings.Severity[LinterSecrets] != SeverityError {
+		t.Errorf("Resolved() severity = %v", settings.Severity)
+	}
+	if settings.Concurrency != runtime.GOMAXPROCS(0) {
+		t.Errorf("Resolved() concurrency = %d, want %d", settings.Concurrency, runtime.GOMAXPROCS(0))
+	}
+	if resolved.Upgrade.Format != defaultUpgradeFormat ||
+		resolved.Upgrade.Actions["actions/checkout"].Constraint != "^4.0.0" {
+		t.Errorf("Resolved().Upgrade = %+v", resolved.Upgrade)
+	}
+
+	// The receiver is left as loaded
+	if cfg.Linters.Settings.Style != nil || cfg.Linters.Settings.Format.CheckBlockScalars != nil {
+		t.Error("Resolved() modified the receiver")
+	}
+
+	// The output is itself a valid config
+	data, err := yaml.Marshal(resolved)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	if _, err := parseConfig(data); err != nil {
+		t.Errorf("parseConfig(Resolved()) error = %v", err)
+	}
+}
+
+func TestConfig_Resolved_Nil(t *testing.T) {
+	var cfg *Config
+	resolved := cfg.Resolved()
+	if resolved.Run.Timeout != DefaultTimeout.String() || resolved.Linters.Default != defaultLinterDefault ||
+		resolved.Linters.Settings.Versions == nil {
+		t.Errorf("Resolved() = %+v", resolved)
+	}
+}
+
+func TestIndent_YAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Indent
+		wantErr bool
+	}{
+		{"number", "indent-width: 4\n", 4, false},
+		{"auto", "indent-width: auto\n", IndentAuto, false},
+		{"zero", "indent-width: 0\n", IndentAuto, false},
+		{"invalid", "indent-width: wide\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var settings FormatSettings
+			err := yaml.Unmarshal([]byte(tt.content), &settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("yaml.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && settings.IndentWidth != tt.want {
+				t.Errorf("IndentWidth = %d, want %d", settings.IndentWidth, tt.want)
+			}
+		})
+	}
+
+	for indent, want := range map[Indent]string{IndentAuto: "indent-width: auto\n", 4: "indent-width: 4\n"} {
+		data, err := yaml.Marshal(&FormatSettings{IndentWidth: indent})
+		if err != nil {
+			t.Fatalf("yaml.Marshal() error = %v", err)
+		}
+		if !strings.HasPrefix(string(data), want) {
+			t.Errorf("yaml.Marshal() = %q, want prefix %q", data, want)
+		}
+	}
+}
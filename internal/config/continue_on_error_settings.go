@@ -0,0 +1,28 @@
+package config
+
+// ContinueOnErrorSettings contains settings for the continue-on-error linter.
+type ContinueOnErrorSettings struct {
+	RuleSettings `yaml:",inline"`
+
+	// AllowJobs lists job IDs that may set continue-on-error: true on the job
+	// or its steps, e.g., an experimental build
+	AllowJobs []string `yaml:"allow-jobs"`
+}
+
+// Validate checks ContinueOnErrorSettings for invalid values.
+func (c *ContinueOnErrorSettings) Validate() error {
+	return nil
+}
+
+// DefaultContinueOnErrorSettings returns the default continue-on-error linter settings.
+func DefaultContinueOnErrorSettings() *ContinueOnErrorSettings {
+	return &ContinueOnErrorSettings{AllowJobs: []string{}}
+}
+
+// GetContinueOnErrorSettings returns the continue-on-error linter settings from config.
+func (c *Config) GetContinueOnErrorSettings() *ContinueOnErrorSettings {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.ContinueOnError != nil {
+		return c.Linters.Settings.ContinueOnError
+	}
+	return DefaultContinueOnErrorSettings()
+}
@@ -1,18 +1,66 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
 
 const (
 	defaultIndentWidth   = 2
 	defaultMaxLineLength = 120
 )
 
+// indentAuto is the YAML value of IndentAuto.
+const indentAuto = "auto"
+
+// Indent is a number of spaces per indentation level. In YAML it is written as an
+// integer, or "auto" for IndentAuto.
+type Indent int
+
+// IndentAuto detects the indentation width of each file from its first indentation step.
+const IndentAuto Indent = 0
+
+// UnmarshalYAML decodes an integer or "auto".
+func (i *Indent) UnmarshalYAML(value *yaml.Node) error {
+	if value.Value == indentAuto {
+		*i = IndentAuto
+		return nil
+	}
+	var n int
+	if err := value.Decode(&n); err != nil {
+		return fmt.Errorf("format.indent-width must be a number or %q, got %q", indentAuto, value.Value)
+	}
+	*i = Indent(n)
+	return nil
+}
+
+// MarshalYAML encodes IndentAuto as "auto" and other widths as integers.
+func (i Indent) MarshalYAML() (any, error) {
+	if i == IndentAuto {
+		return indentAuto, nil
+	}
+	return int(i), nil
+}
+
 // FormatSettings contains settings for the format linter.
 type FormatSettings struct {
-	// IndentWidth is the number of spaces per indentation level (default: 2)
-	IndentWidth int `yaml:"indent-width"`
+	RuleSettings `yaml:",inline"`
+
+	// IndentWidth is the number of spaces per indentation level (default: 2), or
+	// IndentAuto ("auto") to infer it from each file and only report inconsistencies
+	IndentWidth Indent `yaml:"indent-width"`
 	// MaxLineLength is the maximum allowed line length (default: 120)
 	MaxLineLength int `yaml:"max-line-length"`
+	// CheckBlockScalars reports trailing whitespace inside block scalars such as
+	// "run: |" scripts (default: true). Unset means true.
+	CheckBlockScalars *bool `yaml:"check-block-scalars,omitempty"`
+}
+
+// ShouldCheckBlockScalars returns true if trailing whitespace inside block scalars
+// should be reported and fixed.
+func (f *FormatSettings) ShouldCheckBlockScalars() bool {
+	return f == nil || f.CheckBlockScalars == nil || *f.CheckBlockScalars
 }
 
 // Validate checks FormatSettings for invalid values.
@@ -31,9 +79,11 @@ func (f *FormatSettings) Validate() error {
 
 // DefaultFormatSettings returns the default format linter settings.
 func DefaultFormatSettings() *FormatSettings {
+	checkBlockScalars := true
 	return &FormatSettings{
-		IndentWidth:   defaultIndentWidth,
-		MaxLineLength: defaultMaxLineLength,
+		IndentWidth:       defaultIndentWidth,
+		MaxLineLength:     defaultMaxLineLength,
+		CheckBlockScalars: &checkBlockScalars,
 	}
 }
 
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// privateRanges are the RFC 1918 private IPv4 address ranges.
+var privateRanges = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+// HostsSettings contains settings for the hosts linter.
+type HostsSettings struct {
+	RuleSettings `yaml:",inline"`
+
+	// PrivateRanges reports IPv4 addresses in the RFC 1918 private ranges
+	// (default: true). Unset means true.
+	PrivateRanges *bool `yaml:"private-ranges,omitempty"`
+	// Ranges lists additional CIDR ranges whose addresses are reported
+	Ranges []string `yaml:"ranges"`
+	// Domains lists domain suffixes whose hostnames are reported (e.g., "corp.example.com")
+	Domains []string `yaml:"domains"`
+}
+
+// Validate checks HostsSettings for invalid values.
+func (h *HostsSettings) Validate() error {
+	if h == nil {
+		return nil
+	}
+	for _, r := range h.Ranges {
+		if _, err := netip.ParsePrefix(r); err != nil {
+			return fmt.Errorf("hosts.ranges: invalid CIDR range %q", r)
+		}
+	}
+	for _, d := range h.Domains {
+		if strings.Trim(d, ".") == "" {
+			return fmt.Errorf("hosts.domains: invalid domain suffix %q", d)
+		}
+	}
+	return nil
+}
+
+// Prefixes returns the parsed CIDR ranges to report, including the private ranges
+// unless disabled. Invalid ranges are skipped; Validate reports them.
+func (h *HostsSettings) Prefixes() []netip.Prefix {
+	ranges := h.Ranges
+	if h.PrivateRanges == nil || *h.PrivateRanges {
+		ranges = slices.Concat(privateRanges, ranges)
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(ranges))
+	for _, r := range ranges {
+		if prefix, err := netip.ParsePrefix(r); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		}
+	}
+	return prefixes
+}
+
+// DefaultHostsSettings returns the default hosts linter settings.
+func DefaultHostsSettings() *HostsSettings {
+	privateRanges := true
+	return &HostsSettings{
+		PrivateRanges: &privateRanges,
+		Ranges:        []string{},
+		Domains:       []string{},
+	}
+}
+
+// GetHostsSettings returns the hosts linter settings from config.
+func (c *Config) GetHostsSettings() *HostsSettings {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.Hosts != nil {
+		return c.Linters.Settings.Hosts
+	}
+	return DefaultHostsSettings()
+}
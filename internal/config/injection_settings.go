@@ -0,0 +1,28 @@
+package config
+
+// InjectionSettings contains settings for the injection linter.
+type InjectionSettings struct {
+	RuleSettings `yaml:",inline"`
+
+	// CheckWithInputs reports untrusted contexts passed to action inputs under with:,
+	// which the action may use unsafely (default: false)
+	CheckWithInputs bool `yaml:"check-with-inputs"`
+}
+
+// Validate checks InjectionSettings for invalid values.
+func (i *InjectionSettings) Validate() error {
+	return nil
+}
+
+// DefaultInjectionSettings returns the default injection linter settings.
+func DefaultInjectionSettings() *InjectionSettings {
+	return &InjectionSettings{}
+}
+
+// GetInjectionSettings returns the injection linter settings from config.
+func (c *Config) GetInjectionSettings() *InjectionSettings {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.Injection != nil {
+		return c.Linters.Settings.Injection
+	}
+	return DefaultInjectionSettings()
+}
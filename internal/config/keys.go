@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// checkKnownKeys returns an error naming the first mapping key in node that doesn't
+// match a field of t, so typos (e.g., "enabled" for "enable") are reported instead of
+// silently ignored. Keys of map types and values of type any are not checked.
+func checkKnownKeys(node *yaml.Node, t reflect.Type, path string) error {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case node.Kind == yaml.DocumentNode:
+		for _, child := range node.Content {
+			if err := checkKnownKeys(child, t, path); err != nil {
+				return err
+			}
+		}
+	case node.Kind == yaml.SequenceNode && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		for _, item := range node.Content {
+			if err := checkKnownKeys(item, t.Elem(), path); err != nil {
+				return err
+			}
+		}
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Map:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if err := checkKnownKeys(node.Content[i+1], t.Elem(), joinKeyPath(path, node.Content[i].Value)); err != nil {
+				return err
+			}
+		}
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Struct:
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Value == "<<" {
+				continue // Merge key; the merged mapping is checked through its anchor
+			}
+			field, ok := fields[key.Value]
+			if !ok {
+				return unknownKeyError(key, path)
+			}
+			if err := checkKnownKeys(node.Content[i+1], field.Type, joinKeyPath(path, key.Value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// yamlFields maps the YAML key of each field of struct type t to the field.
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && opts == "inline" {
+			maps.Copy(fields, yamlFields(field.Type))
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// unknownKeyError describes an unknown key at its line, within the mapping at path.
+func unknownKeyError(key *yaml.Node, path string) error {
+	if path == "" {
+		return fmt.Errorf("unknown key %q at line %d", key.Value, key.Line)
+	}
+	return fmt.Errorf("unknown key %q in %s at line %d", key.Value, path, key.Line)
+}
+
+// joinKeyPath appends key to a dotted key path.
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
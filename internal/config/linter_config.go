@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"runtime"
 	"slices"
 )
 
@@ -42,8 +43,29 @@ func (l *LinterConfig) Validate() error {
 
 // LinterSettings contains per-linter configuration.
 type LinterSettings struct {
-	Format *FormatSettings `yaml:"format,omitempty"`
-	Style  *StyleSettings  `yaml:"style,omitempty"`
+	Versions    *VersionsSettings    `yaml:"versions,omitempty"`
+	Permissions *PermissionsSettings `yaml:"permissions,omitempty"`
+	Format      *FormatSettings      `yaml:"format,omitempty"`
+	Style       *StyleSettings       `yaml:"style,omitempty"`
+	Runners     *RunnersSettings     `yaml:"runners,omitempty"`
+	Secrets     *SecretsSettings     `yaml:"secrets,omitempty"`
+	Hosts       *HostsSettings       `yaml:"hosts,omitempty"`
+	Injection   *InjectionSettings   `yaml:"injection,omitempty"`
+	// ContinueOnError contains settings for the continue-on-error linter
+	ContinueOnError *ContinueOnErrorSettings `yaml:"continue-on-error,omitempty"`
+	// CacheKeys contains settings for the cache-keys linter
+	CacheKeys *CacheKeysSettings `yaml:"cache-keys,omitempty"`
+	// Linters without settings of their own only accept the settings common to all linters
+	Needs       *RuleSettings `yaml:"needs,omitempty"`
+	Triggers    *RuleSettings `yaml:"triggers,omitempty"`
+	Matrix      *RuleSettings `yaml:"matrix,omitempty"`
+	PRSecrets   *RuleSettings `yaml:"pr-secrets,omitempty"`
+	Commands    *RuleSettings `yaml:"commands,omitempty"`
+	TokenScopes *RuleSettings `yaml:"token-scopes,omitempty"`
+	// Severity overrides the default severity (error, warning, or info) per linter
+	Severity map[string]string `yaml:"severity,omitempty"`
+	// Concurrency is the number of workflows linted in parallel (default: GOMAXPROCS)
+	Concurrency int `yaml:"concurrency,omitempty"`
 }
 
 // Validate checks LinterSettings for invalid values.
@@ -51,15 +73,57 @@ func (s *LinterSettings) Validate() error {
 	if s == nil {
 		return nil
 	}
+	if err := s.Versions.Validate(); err != nil {
+		return err
+	}
+	if err := s.Permissions.Validate(); err != nil {
+		return err
+	}
 	if err := s.Format.Validate(); err != nil {
 		return err
 	}
 	if err := s.Style.Validate(); err != nil {
 		return err
 	}
+	if err := s.Runners.Validate(); err != nil {
+		return err
+	}
+	if err := s.Secrets.Validate(); err != nil {
+		return err
+	}
+	if err := s.Hosts.Validate(); err != nil {
+		return err
+	}
+	if err := s.Injection.Validate(); err != nil {
+		return err
+	}
+	if err := s.ContinueOnError.Validate(); err != nil {
+		return err
+	}
+	if err := s.CacheKeys.Validate(); err != nil {
+		return err
+	}
+	if err := s.validateRuleSettings(); err != nil {
+		return err
+	}
+	if err := validateSeverities(s.Severity); err != nil {
+		return err
+	}
+	if s.Concurrency < 0 {
+		return fmt.Errorf("linters.settings.concurrency must be non-negative, got %d", s.Concurrency)
+	}
 	return nil
 }
 
+// GetConcurrency returns the number of workflows to lint in parallel.
+// Returns GOMAXPROCS if not configured.
+func (c *Config) GetConcurrency() int {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.Concurrency > 0 {
+		return c.Linters.Settings.Concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
 // DefaultLinterConfig returns a minimal LinterConfig with default values.
 func DefaultLinterConfig() *LinterConfig {
 	return &LinterConfig{
@@ -73,11 +137,20 @@ func DefaultLinterConfig() *LinterConfig {
 func FullDefaultLinterConfig() *LinterConfig {
 	return &LinterConfig{
 		Default: defaultLinterDefault,
-		Enable:  allLinters,
+		Enable:  defaultLinters(),
 		Disable: []string{},
 		Settings: &LinterSettings{
-			Format: DefaultFormatSettings(),
-			Style:  DefaultStyleSettings(),
+			Versions:    DefaultVersionsSettings(),
+			Permissions: DefaultPermissionsSettings(),
+			Format:      DefaultFormatSettings(),
+			Style:       DefaultStyleSettings(),
+			Runners:     DefaultRunnersSettings(),
+			Secrets:     DefaultSecretsSettings(),
+			Hosts:       DefaultHostsSettings(),
+			Injection:   DefaultInjectionSettings(),
+
+			ContinueOnError: DefaultContinueOnErrorSettings(),
+			CacheKeys:       DefaultCacheKeysSettings(),
 		},
 	}
 }
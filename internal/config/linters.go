@@ -1,13 +1,25 @@
 package config
 
+import "slices"
+
 // Linter name constants.
 const (
-	LinterVersions    = "versions"
-	LinterPermissions = "permissions"
-	LinterFormat      = "format"
-	LinterSecrets     = "secrets"
-	LinterInjection   = "injection"
-	LinterStyle       = "style"
+	LinterVersions        = "versions"
+	LinterPermissions     = "permissions"
+	LinterFormat          = "format"
+	LinterSecrets         = "secrets"
+	LinterInjection       = "injection"
+	LinterStyle           = "style"
+	LinterRunners         = "runners"
+	LinterNeeds           = "needs"
+	LinterHosts           = "hosts"
+	LinterTriggers        = "triggers"
+	LinterMatrix          = "matrix"
+	LinterPRSecrets       = "pr-secrets"
+	LinterCommands        = "commands"
+	LinterContinueOnError = "continue-on-error"
+	LinterTokenScopes     = "token-scopes"
+	LinterCacheKeys       = "cache-keys"
 )
 
 // allLinters lists all available linters.
@@ -18,4 +30,42 @@ var allLinters = []string{
 	LinterSecrets,
 	LinterInjection,
 	LinterStyle,
+	LinterRunners,
+	LinterNeeds,
+	LinterHosts,
+	LinterTriggers,
+	LinterMatrix,
+	LinterPRSecrets,
+	LinterCommands,
+	LinterContinueOnError,
+	LinterTokenScopes,
+	LinterCacheKeys,
+}
+
+// optInLinters lists linters that are only run when listed in linters.enable,
+// even with linters.default set to "all".
+var optInLinters = []string{
+	LinterHosts,
+	LinterPRSecrets,
+	LinterCacheKeys,
+}
+
+// AllLinters returns the names of all available linters.
+func AllLinters() []string {
+	return slices.Clone(allLinters)
+}
+
+// IsKnownLinter returns true if name is one of the available linters.
+func IsKnownLinter(name string) bool {
+	return slices.Contains(allLinters, name)
+}
+
+// IsOptInLinter returns true if the linter only runs when explicitly enabled.
+func IsOptInLinter(name string) bool {
+	return slices.Contains(optInLinters, name)
+}
+
+// defaultLinters returns the linters enabled by linters.default "all".
+func defaultLinters() []string {
+	return slices.DeleteFunc(AllLinters(), IsOptInLinter)
 }
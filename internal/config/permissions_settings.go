@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultFixPermissions = "read-all"
+
+// PermissionsSettings contains settings for the permissions linter.
+type PermissionsSettings struct {
+	RuleSettings `yaml:",inline"`
+
+	// WarnWorkflowWrites warns when workflow-level permissions grant write access to a
+	// scope (e.g., contents: write), which applies to every job; grant it per job instead
+	WarnWorkflowWrites bool `yaml:"warn-workflow-writes"`
+	// WarnPersistCredentials warns when an actions/checkout step doesn't set
+	// persist-credentials: false, which leaves the token in the git config for later steps
+	WarnPersistCredentials bool `yaml:"warn-persist-credentials"`
+	// FixPermissions is the workflow-level permissions value inserted by --fix:
+	// "read-all", "{}", or an inline map such as "{contents: read}" (default: "read-all")
+	FixPermissions string `yaml:"fix-permissions"`
+}
+
+// Validate checks PermissionsSettings for invalid values.
+func (p *PermissionsSettings) Validate() error {
+	if p == nil || p.FixPermissions == "" {
+		return nil
+	}
+
+	var value any
+	if err := yaml.Unmarshal([]byte(p.FixPermissions), &value); err != nil {
+		return fmt.Errorf("permissions.fix-permissions is not valid YAML: %w", err)
+	}
+	switch v := value.(type) {
+	case string:
+		if v == defaultFixPermissions {
+			return nil
+		}
+	case map[string]any:
+		for scope, access := range v {
+			if access != "read" && access != "write" && access != "none" {
+				return fmt.Errorf("permissions.fix-permissions scope %q must be read, write, or none, got %v",
+					scope, access)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("permissions.fix-permissions must be %q or an inline map of scopes, got %q",
+		defaultFixPermissions, p.FixPermissions)
+}
+
+// GetFixPermissions returns the permissions value inserted by --fix, defaulting to read-all.
+func (p *PermissionsSettings) GetFixPermissions() string {
+	if p == nil || p.FixPermissions == "" {
+		return defaultFixPermissions
+	}
+	return p.FixPermissions
+}
+
+// DefaultPermissionsSettings returns the default permissions linter settings.
+func DefaultPermissionsSettings() *PermissionsSettings {
+	return &PermissionsSettings{FixPermissions: defaultFixPermissions}
+}
+
+// GetPermissionsSettings returns the permissions linter settings from config.
+func (c *Config) GetPermissionsSettings() *PermissionsSettings {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.Permissions != nil {
+		return c.Linters.Settings.Permissions
+	}
+	return DefaultPermissionsSettings()
+}
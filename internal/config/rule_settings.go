@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// RuleSettings contains the settings every linter accepts, embedded in the settings
+// of each linter.
+type RuleSettings struct {
+	// DisabledRules lists rule IDs of the linter (e.g., "format/line-length") whose
+	// issues are not reported, while the linter's other rules still are
+	DisabledRules []string `yaml:"disabled-rules,omitempty"`
+}
+
+// ruleSettings returns r, so the settings of every linter can be reached through
+// the embedded RuleSettings.
+func (r *RuleSettings) ruleSettings() *RuleSettings {
+	return r
+}
+
+// Validate checks that each disabled rule belongs to the linter with the given name.
+// Whether the rule exists is checked by the linter package, which defines the rules.
+func (r *RuleSettings) Validate(linterName string) error {
+	if r == nil {
+		return nil
+	}
+	for _, id := range r.DisabledRules {
+		if !strings.HasPrefix(id, linterName+"/") {
+			return fmt.Errorf("linters.settings.%s.disabled-rules: %q is not a rule of the %s linter "+
+				"(rule IDs have the form %s/<check>)", linterName, id, linterName, linterName)
+		}
+	}
+	return nil
+}
+
+// ruleSettings returns the RuleSettings of the linter with the given name, or nil if
+// the linter has no settings configured.
+func (s *LinterSettings) ruleSettings(linterName string) *RuleSettings {
+	if s == nil {
+		return nil
+	}
+	field, ok := yamlFields(reflect.TypeFor[LinterSettings]())[linterName]
+	if !ok {
+		return nil
+	}
+	value := reflect.ValueOf(s).Elem().FieldByIndex(field.Index)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil
+	}
+	holder, ok := value.Interface().(interface{ ruleSettings() *RuleSettings })
+	if !ok {
+		return nil
+	}
+	return holder.ruleSettings()
+}
+
+// validateRuleSettings checks the disabled rules of every linter.
+func (s *LinterSettings) validateRuleSettings() error {
+	for _, name := range allLinters {
+		if err := s.ruleSettings(name).Validate(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDisabledRules returns the rule IDs disabled in the settings of the linter with the
+// given name, or nil if there are none.
+func (c *Config) GetDisabledRules(linterName string) []string {
+	if c == nil || c.Linters == nil {
+		return nil
+	}
+	if r := c.Linters.Settings.ruleSettings(linterName); r != nil {
+		return r.DisabledRules
+	}
+	return nil
+}
+
+// IsRuleDisabled returns true if the rule with the given ID is listed in the
+// disabled-rules of its linter.
+func (c *Config) IsRuleDisabled(ruleID string) bool {
+	linterName, _, _ := strings.Cut(ruleID, "/")
+	return slices.Contains(c.GetDisabledRules(linterName), ruleID)
+}
@@ -0,0 +1,28 @@
+package config
+
+// RunnersSettings contains settings for the runners linter.
+type RunnersSettings struct {
+	RuleSettings `yaml:",inline"`
+
+	// Strict warns on floating labels such as ubuntu-latest that change
+	// the underlying image without notice (default: false)
+	Strict bool `yaml:"strict"`
+}
+
+// Validate checks RunnersSettings for invalid values.
+func (r *RunnersSettings) Validate() error {
+	return nil
+}
+
+// DefaultRunnersSettings returns the default runners linter settings.
+func DefaultRunnersSettings() *RunnersSettings {
+	return &RunnersSettings{}
+}
+
+// GetRunnersSettings returns the runners linter settings from config.
+func (c *Config) GetRunnersSettings() *RunnersSettings {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.Runners != nil {
+		return c.Linters.Settings.Runners
+	}
+	return DefaultRunnersSettings()
+}
@@ -0,0 +1,53 @@
+package config
+
+import "fmt"
+
+const (
+	defaultMinEntropy = 4.5
+	defaultMinLength  = 20
+)
+
+// SecretsSettings contains settings for the secrets linter.
+type SecretsSettings struct {
+	RuleSettings `yaml:",inline"`
+
+	// MinEntropy is the Shannon entropy in bits per character above which a quoted
+	// string is reported as a possible secret (default: 4.5, 0 = disabled).
+	// Hex strings are held to two thirds of this value, since they use 16 symbols instead of 64.
+	MinEntropy float64 `yaml:"min-entropy"`
+	// MinLength is the minimum length of quoted strings checked for entropy (default: 20)
+	MinLength int `yaml:"min-length"`
+	// Allow lists values that are never reported; a value is skipped if it contains
+	// any entry (case-insensitive), e.g., a known test fixture
+	Allow []string `yaml:"allow,omitempty"`
+}
+
+// Validate checks SecretsSettings for invalid values.
+func (s *SecretsSettings) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.MinEntropy < 0 {
+		return fmt.Errorf("secrets.min-entropy must be non-negative, got %g", s.MinEntropy)
+	}
+	if s.MinLength < 0 {
+		return fmt.Errorf("secrets.min-length must be non-negative, got %d", s.MinLength)
+	}
+	return nil
+}
+
+// DefaultSecretsSettings returns the default secrets linter settings.
+func DefaultSecretsSettings() *SecretsSettings {
+	return &SecretsSettings{
+		MinEntropy: defaultMinEntropy,
+		MinLength:  defaultMinLength,
+	}
+}
+
+// GetSecretsSettings returns the secrets linter settings from config.
+func (c *Config) GetSecretsSettings() *SecretsSettings {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.Secrets != nil {
+		return c.Linters.Settings.Secrets
+	}
+	return DefaultSecretsSettings()
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Severity levels, from most to least severe.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// severities lists the valid severity levels, from most to least severe.
+var severities = []string{SeverityError, SeverityWarning, SeverityInfo}
+
+// defaultSeverities maps linters to their default severity.
+// Linters not listed default to SeverityWarning.
+var defaultSeverities = map[string]string{
+	LinterPermissions:     SeverityError,
+	LinterSecrets:         SeverityError,
+	LinterInjection:       SeverityError,
+	LinterNeeds:           SeverityError,
+	LinterRunners:         SeverityError,
+	LinterVersions:        SeverityWarning,
+	LinterFormat:          SeverityWarning,
+	LinterStyle:           SeverityWarning,
+	LinterHosts:           SeverityWarning,
+	LinterTriggers:        SeverityWarning,
+	LinterMatrix:          SeverityWarning,
+	LinterPRSecrets:       SeverityWarning,
+	LinterCommands:        SeverityWarning,
+	LinterContinueOnError: SeverityWarning,
+	LinterTokenScopes:     SeverityError,
+	LinterCacheKeys:       SeverityInfo,
+}
+
+// Severities returns the valid severity levels, from most to least severe.
+func Severities() []string {
+	return slices.Clone(severities)
+}
+
+// IsValidSeverity returns true if s is a known severity level.
+func IsValidSeverity(s string) bool {
+	return slices.Contains(severities, s)
+}
+
+// SeverityAtLeast returns true if severity is at least as severe as threshold.
+// Unknown severities are treated as SeverityError so they are never ignored.
+func SeverityAtLeast(severity, threshold string) bool {
+	rank := slices.Index(severities, severity)
+	if rank < 0 {
+		rank = 0
+	}
+	return rank <= slices.Index(severities, threshold)
+}
+
+// validateSeverities checks that the severity overrides reference known linters and levels.
+func validateSeverities(overrides map[string]string) error {
+	for name, severity := range overrides {
+		if !slices.Contains(allLinters, name) {
+			return fmt.Errorf("unknown linter %q in linters.settings.severity", name)
+		}
+		if !IsValidSeverity(severity) {
+			return fmt.Errorf("linters.settings.severity.%s must be one of %v, got %q",
+				name, severities, severity)
+		}
+	}
+	return nil
+}
+
+// IssueSeverity returns the severity of an issue reported by a linter. A severity configured
+// for the linter applies to all of its issues; otherwise, the issue's own severity, set by
+// linters that also report advisory issues, wins over the linter's default.
+func (c *Config) IssueSeverity(linterName, issueSeverity string) string {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil {
+		if severity, ok := c.Linters.Settings.Severity[linterName]; ok {
+			return severity
+		}
+	}
+	if issueSeverity != "" {
+		return issueSeverity
+	}
+	return c.GetSeverity(linterName)
+}
+
+// GetSeverity returns the configured severity for a linter, falling back to its default.
+func (c *Config) GetSeverity(linterName string) string {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil {
+		if severity, ok := c.Linters.Settings.Severity[linterName]; ok {
+			return severity
+		}
+	}
+	if severity, ok := defaultSeverities[linterName]; ok {
+		return severity
+	}
+	return SeverityWarning
+}
@@ -0,0 +1,113 @@
+package config
+
+import "testing"
+
+func TestConfig_GetSeverity(t *testing.T) {
+	cfg := &Config{
+		Linters: &LinterConfig{
+			Settings: &LinterSettings{
+				Severity: map[string]string{LinterStyle: SeverityInfo, LinterSecrets: SeverityWarning},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *Config
+		linter   string
+		expected string
+	}{
+		{"nil config uses default", nil, LinterInjection, SeverityError},
+		{"default error", NewDefaultConfig(), LinterPermissions, SeverityError},
+		{"default warning", NewDefaultConfig(), LinterFormat, SeverityWarning},
+		{"unknown linter", NewDefaultConfig(), "unknown", SeverityWarning},
+		{"override lowers severity", cfg, LinterSecrets, SeverityWarning},
+		{"override to info", cfg, LinterStyle, SeverityInfo},
+		{"not overridden", cfg, LinterInjection, SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.GetSeverity(tt.linter); got != tt.expected {
+				t.Errorf("GetSeverity(%q) = %q, want %q", tt.linter, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IssueSeverity(t *testing.T) {
+	cfg := &Config{
+		Linters: &LinterConfig{
+			Settings: &LinterSettings{
+				Severity: map[string]string{LinterStyle: SeverityWarning},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		cfg           *Config
+		linter        string
+		issueSeverity string
+		expected      string
+	}{
+		{"linter default", NewDefaultConfig(), LinterTokenScopes, "", SeverityError},
+		{"issue severity wins over default", NewDefaultConfig(), LinterTokenScopes, SeverityInfo, SeverityInfo},
+		{"override wins over issue severity", cfg, LinterStyle, SeverityInfo, SeverityWarning},
+		{"nil config", nil, LinterTokenScopes, SeverityInfo, SeverityInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.IssueSeverity(tt.linter, tt.issueSeverity); got != tt.expected {
+				t.Errorf("IssueSeverity(%q, %q) = %q, want %q", tt.linter, tt.issueSeverity, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSeverityAtLeast(t *testing.T) {
+	tests := []struct {
+		severity  string
+		threshold string
+		expected  bool
+	}{
+		{SeverityError, SeverityError, true},
+		{SeverityWarning, SeverityError, false},
+		{SeverityInfo, SeverityError, false},
+		{SeverityError, SeverityWarning, true},
+		{SeverityWarning, SeverityWarning, true},
+		{SeverityInfo, SeverityWarning, false},
+		{SeverityInfo, SeverityInfo, true},
+		{"", SeverityError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity+"/"+tt.threshold, func(t *testing.T) {
+			if got := SeverityAtLeast(tt.severity, tt.threshold); got != tt.expected {
+				t.Errorf("SeverityAtLeast(%q, %q) = %v, want %v", tt.severity, tt.threshold, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLinterSettings_ValidateSeverity(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity map[string]string
+		wantErr  bool
+	}{
+		{"valid", map[string]string{LinterFormat: SeverityInfo, LinterStyle: SeverityError}, false},
+		{"unknown linter", map[string]string{"unknown": SeverityInfo}, true},
+		{"unknown severity", map[string]string{LinterFormat: "fatal"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &LinterSettings{Severity: tt.severity}
+			if err := s.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
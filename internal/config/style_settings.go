@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"path"
 	"slices"
 )
 
@@ -9,6 +10,7 @@ const (
 	defaultMinNameLength = 3
 	defaultMaxNameLength = 50
 	defaultMaxRunLines   = 0 // 0 means disabled
+	defaultMaxJobs       = 0 // 0 means disabled
 )
 
 // Valid naming conventions.
@@ -16,6 +18,8 @@ var validNamingConventions = []string{"title", "sentence"}
 
 // StyleSettings contains settings for the style linter.
 type StyleSettings struct {
+	RuleSettings `yaml:",inline"`
+
 	// MinNameLength is the minimum allowed characters for names (default: 3)
 	MinNameLength int `yaml:"min-name-length"`
 	// MaxNameLength is the maximum allowed characters for names (default: 50)
@@ -31,6 +35,37 @@ type StyleSettings struct {
 	RequireStepNames bool `yaml:"require-step-names"`
 	// MaxRunLines is the maximum allowed lines in a run script (0 = disabled)
 	MaxRunLines int `yaml:"max-run-lines"`
+	// MaxJobs is the maximum allowed jobs in a workflow (0 = disabled)
+	MaxJobs int `yaml:"max-jobs"`
+	// DistinctWorkflowNames requires workflow names to be unique across files and
+	// reusable workflows to have a name that doesn't just repeat the file name
+	DistinctWorkflowNames bool `yaml:"distinct-workflow-names"`
+	// RequirePipefail warns when multi-line run scripts with pipes use the default
+	// shell on Linux or macOS runners, which doesn't enable pipefail
+	RequirePipefail bool `yaml:"require-pipefail"`
+	// WarnTokenOverride warns when workflow- or job-level env sets GITHUB_TOKEN
+	// to a custom value (e.g., a personal access token)
+	WarnTokenOverride bool `yaml:"warn-token-override"`
+	// WarnConstantConcurrency warns when a workflow- or job-level concurrency group is a
+	// constant without expressions, which serializes all runs that share it repository-wide
+	WarnConstantConcurrency bool `yaml:"warn-constant-concurrency"`
+	// RequireJobTimeout requires jobs to set timeout-minutes, so a hung job doesn't run
+	// for the 6-hour default; jobs that call a reusable workflow are exempt
+	RequireJobTimeout bool `yaml:"require-job-timeout"`
+	// MaxJobTimeout is the maximum allowed timeout-minutes for a job (0 = disabled)
+	MaxJobTimeout int `yaml:"max-job-timeout"`
+	// RequireConcurrency suggests a concurrency group with cancel-in-progress for workflows
+	// triggered by push or pull_request that set none at the workflow or job level
+	RequireConcurrency bool `yaml:"require-concurrency"`
+	// AllowedWorkingDirectories lists working-directory paths outside the workspace that
+	// steps may use, e.g., "../shared" for a sibling checkout; subdirectories are allowed too
+	AllowedWorkingDirectories []string `yaml:"allowed-working-directories,omitempty"`
+	// FullHistoryWorkflows lists patterns (e.g., "release*" or "Release *") matched against the
+	// workflow name and file name; checkout steps in matching workflows must set fetch-depth
+	FullHistoryWorkflows []string `yaml:"full-history-workflows,omitempty"`
+	// FixStepKeyOrder enables the style fixer, which reorders step keys into the canonical
+	// order: name, id, if, uses or run, with, env, followed by any other keys
+	FixStepKeyOrder bool `yaml:"fix-step-key-order"`
 }
 
 // Validate checks StyleSettings for invalid values.
@@ -55,6 +90,17 @@ func (s *StyleSettings) Validate() error {
 	if s.MaxRunLines < 0 {
 		return fmt.Errorf("style.max-run-lines must be non-negative, got %d", s.MaxRunLines)
 	}
+	if s.MaxJobs < 0 {
+		return fmt.Errorf("style.max-jobs must be non-negative, got %d", s.MaxJobs)
+	}
+	if s.MaxJobTimeout < 0 {
+		return fmt.Errorf("style.max-job-timeout must be non-negative, got %d", s.MaxJobTimeout)
+	}
+	for _, pattern := range s.FullHistoryWorkflows {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("style.full-history-workflows: invalid pattern %q: %w", pattern, err)
+		}
+	}
 	return nil
 }
 
@@ -64,6 +110,7 @@ func DefaultStyleSettings() *StyleSettings {
 		MinNameLength: defaultMinNameLength,
 		MaxNameLength: defaultMaxNameLength,
 		MaxRunLines:   defaultMaxRunLines,
+		MaxJobs:       defaultMaxJobs,
 	}
 }
 
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionsSettings contains settings for the versions linter.
+type VersionsSettings struct {
+	RuleSettings `yaml:",inline"`
+
+	// ManagedComment is appended to the version comment of actions pinned by --fix,
+	// e.g., "managed by github-ci" (default: "", no marker)
+	ManagedComment string `yaml:"managed-comment"`
+	// SuggestUpgrades appends the latest version allowed by the action's upgrade constraint
+	// to version tag issues; needs the GitHub API (default: false)
+	SuggestUpgrades bool `yaml:"suggest-upgrades"`
+	// CheckReusableWorkflows reports called reusable workflows that do not exist at
+	// the referenced ref; needs the GitHub API (default: false)
+	CheckReusableWorkflows bool `yaml:"check-reusable-workflows"`
+	// FixPinComments makes --fix add the tag as a version comment to actions already
+	// pinned to a commit hash without a comment; needs the GitHub API (default: false)
+	FixPinComments bool `yaml:"fix-pin-comments"`
+	// AllowedOwners lists the owners (users or organizations) whose actions and reusable
+	// workflows may be used, e.g., "actions"; empty allows every owner (default: [])
+	AllowedOwners []string `yaml:"allowed-owners"`
+}
+
+// Validate checks VersionsSettings for invalid values.
+func (v *VersionsSettings) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if strings.ContainsAny(v.ManagedComment, "\r\n") {
+		return fmt.Errorf("versions.managed-comment must be a single line, got %q", v.ManagedComment)
+	}
+	for _, owner := range v.AllowedOwners {
+		if owner == "" || strings.ContainsAny(owner, "/@ ") {
+			return fmt.Errorf("versions.allowed-owners: invalid owner %q (use the owner only, e.g., actions)", owner)
+		}
+	}
+	return nil
+}
+
+// DefaultVersionsSettings returns the default versions linter settings.
+func DefaultVersionsSettings() *VersionsSettings {
+	return &VersionsSettings{AllowedOwners: []string{}}
+}
+
+// GetVersionsSettings returns the versions linter settings from config.
+func (c *Config) GetVersionsSettings() *VersionsSettings {
+	if c != nil && c.Linters != nil && c.Linters.Settings != nil && c.Linters.Settings.Versions != nil {
+		return c.Linters.Settings.Versions
+	}
+	return DefaultVersionsSettings()
+}
+
+// SetSuggestUpgrades enables upgrade suggestions for the versions linter,
+// creating the linter settings if needed.
+func (c *Config) SetSuggestUpgrades() {
+	if c.Linters == nil {
+		c.Linters = DefaultLinterConfig()
+	}
+	if c.Linters.Settings == nil {
+		c.Linters.Settings = &LinterSettings{}
+	}
+	if c.Linters.Settings.Versions == nil {
+		c.Linters.Settings.Versions = DefaultVersionsSettings()
+	}
+	c.Linters.Settings.Versions.SuggestUpgrades = true
+}
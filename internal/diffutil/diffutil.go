@@ -0,0 +1,149 @@
+package diffutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+// noNewlineMarker follows a line that has no trailing newline.
+const noNewlineMarker = "\\ No newline at end of file\n"
+
+// op is a single line of an edit script: kept (' '), removed ('-'), or added ('+').
+type op struct {
+	kind byte
+	line string
+}
+
+// Unified returns a unified diff that turns before into after, labeled with
+// oldName and newName. It returns an empty string if the contents are equal.
+func Unified(oldName, newName string, before, after []byte) string {
+	ops := editScript(splitLines(string(before)), splitLines(string(after)))
+
+	var sb strings.Builder
+	for _, h := range hunks(ops) {
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		writeHunk(&sb, ops, h[0], h[1])
+	}
+	return sb.String()
+}
+
+// splitLines splits s into lines, keeping the newline on each line so that
+// a missing newline at the end of the file shows up as a change.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// editScript returns the shortest sequence of kept, removed, and added lines
+// that turns a into b, based on their longest common subsequence.
+func editScript(a, b []string) []op {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
+
+// hunks groups changed ops into [start, end) ranges padded with context.
+// Changes separated by no more than twice the context share a hunk.
+func hunks(ops []op) [][2]int {
+	var result [][2]int
+	for i, o := range ops {
+		if o.kind == ' ' {
+			continue
+		}
+		start := max(0, i-contextLines)
+		end := min(len(ops), i+1+contextLines)
+		if n := len(result); n > 0 && start <= result[n-1][1] {
+			result[n-1][1] = end
+			continue
+		}
+		result = append(result, [2]int{start, end})
+	}
+	return result
+}
+
+// writeHunk writes the ops in [start, end) with a "@@ -l,s +l,s @@" header.
+func writeHunk(sb *strings.Builder, ops []op, start, end int) {
+	// Line numbers of the hunk start in both files
+	oldLine, newLine := 1, 1
+	for _, o := range ops[:start] {
+		if o.kind != '+' {
+			oldLine++
+		}
+		if o.kind != '-' {
+			newLine++
+		}
+	}
+
+	var oldCount, newCount int
+	for _, o := range ops[start:end] {
+		if o.kind != '+' {
+			oldCount++
+		}
+		if o.kind != '-' {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+	for _, o := range ops[start:end] {
+		sb.WriteByte(o.kind)
+		sb.WriteString(o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			sb.WriteString("\n" + noNewlineMarker)
+		}
+	}
+}
+
+// hunkRange formats a hunk range; an empty range refers to the line before it.
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
@@ -0,0 +1,92 @@
+package diffutil
+
+import (
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   string
+		after    string
+		expected string
+	}{
+		{
+			name:     "equal",
+			before:   "a\nb\n",
+			after:    "a\nb\n",
+			expected: "",
+		},
+		{
+			name:   "changed line",
+			before: "a\nb\nc\n",
+			after:  "a\nB\nc\n",
+			expected: `--- a/ci.yml
++++ b/ci.yml
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`,
+		},
+		{
+			name:     "removed line",
+			before:   "a\n\n\nb\n",
+			after:    "a\n\nb\n",
+			expected: "--- a/ci.yml\n+++ b/ci.yml\n@@ -1,4 +1,3 @@\n a\n \n-\n b\n",
+		},
+		{
+			name:   "separate hunks",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			after:  "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			expected: `--- a/ci.yml
++++ b/ci.yml
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -7,4 +7,4 @@
+ 7
+ 8
+ 9
+-10
++ten
+`,
+		},
+		{
+			name:   "added newline at end of file",
+			before: "a\nb",
+			after:  "a\nb\n",
+			expected: `--- a/ci.yml
++++ b/ci.yml
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++b
+`,
+		},
+		{
+			name:   "new file",
+			before: "",
+			after:  "a\n",
+			expected: `--- a/ci.yml
++++ b/ci.yml
+@@ -0,0 +1 @@
++a
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("a/ci.yml", "b/ci.yml", []byte(tt.before), []byte(tt.after))
+			if got != tt.expected {
+				t.Errorf("Unified() =\n%s\nwant:\n%s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package linter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// DefaultBaselineFileName is the default name of the baseline file.
+const DefaultBaselineFileName = ".github-ci-baseline.json"
+
+// Baseline holds known issues that should be suppressed from lint results.
+// Issues are matched by BaselineKey, so moving an issue to a different line
+// doesn't invalidate its entry. Each entry suppresses a single occurrence.
+type Baseline struct {
+	counts map[string]int
+}
+
+// baselineFile is the on-disk representation of a Baseline.
+type baselineFile struct {
+	Issues []string `json:"issues"`
+}
+
+// NewBaseline creates a Baseline that suppresses the given issues.
+func NewBaseline(issues []*Issue) *Baseline {
+	b := &Baseline{counts: make(map[string]int, len(issues))}
+	for _, issue := range issues {
+		b.counts[issue.BaselineKey()]++
+	}
+	return b
+}
+
+// LoadBaseline reads a baseline file. A missing file yields an empty baseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &Baseline{counts: map[string]int{}}, nil
+		}
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var file baselineFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file %s: %w", path, err)
+	}
+
+	b := &Baseline{counts: make(map[string]int, len(file.Issues))}
+	for _, key := range file.Issues {
+		b.counts[key]++
+	}
+	return b, nil
+}
+
+// Save writes the baseline to path with keys in sorted order,
+// so regenerating it produces minimal diffs.
+func (b *Baseline) Save(path string) error {
+	file := baselineFile{Issues: []string{}}
+	for key, count := range b.counts {
+		for range count {
+			file.Issues = append(file.Issues, key)
+		}
+	}
+	slices.Sort(file.Issues)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// Len returns the number of suppressed issue occurrences.
+func (b *Baseline) Len() int {
+	total := 0
+	for _, count := range b.counts {
+		total += count
+	}
+	return total
+}
+
+// Filter returns the issues not suppressed by the baseline.
+// Each baseline entry suppresses at most one matching issue.
+func (b *Baseline) Filter(issues []*Issue) []*Issue {
+	if b == nil || len(b.counts) == 0 {
+		return issues
+	}
+
+	remaining := make(map[string]int, len(b.counts))
+	for key, count := range b.counts {
+		remaining[key] = count
+	}
+
+	var result []*Issue
+	for _, issue := range issues {
+		key := issue.BaselineKey()
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		result = append(result, issue)
+	}
+	return result
+}
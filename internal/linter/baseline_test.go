@@ -0,0 +1,107 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+)
+
+func TestBaseline_Filter(t *testing.T) {
+	known := []*Issue{
+		{File: "ci.yml", Line: 5, Linter: config.LinterStyle, Message: "Step is missing a name"},
+		{File: "ci.yml", Line: 9, Linter: config.LinterStyle, Message: "Step is missing a name"},
+		{File: "ci.yml", Line: 0, Linter: config.LinterPermissions, Message: "Workflow is missing permissions configuration"},
+	}
+	baseline := NewBaseline(known)
+
+	// Lines shifted by an unrelated edit, plus one new occurrence and one new issue
+	current := []*Issue{
+		{File: "ci.yml", Line: 7, Linter: config.LinterStyle, Message: "Step is missing a name"},
+		{File: "ci.yml", Line: 11, Linter: config.LinterStyle, Message: "Step is missing a name"},
+		{File: "ci.yml", Line: 14, Linter: config.LinterStyle, Message: "Step is missing a name"},
+		{File: "ci.yml", Line: 0, Linter: config.LinterPermissions, Message: "Workflow is missing permissions configuration"},
+		{File: "ci.yml", Line: 3, Linter: config.LinterFormat, Message: "Line has trailing whitespace"},
+	}
+
+	got := baseline.Filter(current)
+	want := []*Issue{current[2], current[4]}
+	if !slices.Equal(got, want) {
+		t.Errorf("Filter() = %v, want %v", got, want)
+	}
+
+	// Filtering doesn't consume the baseline
+	if again := baseline.Filter(current); !slices.Equal(again, want) {
+		t.Errorf("second Filter() = %v, want %v", again, want)
+	}
+}
+
+func TestBaseline_FilterNil(t *testing.T) {
+	var baseline *Baseline
+	issues := []*Issue{{File: "ci.yml", Linter: config.LinterStyle, Message: "Step is missing a name"}}
+	if got := baseline.Filter(issues); !slices.Equal(got, issues) {
+		t.Errorf("Filter() = %v, want %v", got, issues)
+	}
+}
+
+func TestBaseline_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultBaselineFileName)
+	issues := []*Issue{
+		{File: "ci.yml", Line: 9, Linter: config.LinterStyle, Message: "Step is missing a name"},
+		{File: "ci.yml", Line: 5, Linter: config.LinterStyle, Message: "Step is missing a name"},
+		{File: "a.yml", Line: 2, Linter: config.LinterFormat, Message: "Line has trailing whitespace"},
+	}
+
+	if err := NewBaseline(issues).Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	want := `{
+  "issues": [
+    "a.yml:format:Line has trailing whitespace",
+    "ci.yml:style:Step is missing a name",
+    "ci.yml:style:Step is missing a name"
+  ]
+}
+`
+	if string(data) != want {
+		t.Errorf("baseline file =\n%s\nwant\n%s", data, want)
+	}
+
+	loaded, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error = %v", err)
+	}
+	if loaded.Len() != len(issues) {
+		t.Errorf("Len() = %d, want %d", loaded.Len(), len(issues))
+	}
+	if got := loaded.Filter(issues); len(got) != 0 {
+		t.Errorf("Filter() = %v, want no issues", got)
+	}
+}
+
+func TestLoadBaseline_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	baseline, err := LoadBaseline(filepath.Join(tmpDir, "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadBaseline() missing file error = %v", err)
+	}
+	if baseline.Len() != 0 {
+		t.Errorf("missing file Len() = %d, want 0", baseline.Len())
+	}
+
+	invalid := filepath.Join(tmpDir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("not json"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := LoadBaseline(invalid); err == nil {
+		t.Error("LoadBaseline() expected error for invalid JSON")
+	}
+}
@@ -0,0 +1,95 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Rules reported by the cache-keys linter.
+const (
+	ruleCacheKeysMissingHash = "cache-keys/missing-hash"
+)
+
+// cacheActions are the actions whose key input is always checked.
+var cacheActions = []string{"actions/cache", "actions/cache/restore", "actions/cache/save"}
+
+// setupKeyInputs are the inputs through which setup-* actions accept a cache key.
+var setupKeyInputs = []string{"key", "cache-key"}
+
+// hashFilesPattern matches a hashFiles(...) call inside a ${{ }} expression.
+var hashFilesPattern = regexp.MustCompile(`(?i)\$\{\{[^}]*\bhashFiles\s*\(`)
+
+// CacheKeysLinter checks that the key of each cache step includes a hashFiles(...) expression,
+// so the cache is saved anew when the dependency lockfiles change. The check is advisory:
+// a key without a lockfile hash is never invalidated, or never hit if it changes every run.
+type CacheKeysLinter struct {
+	noOpFixer
+	settings *config.CacheKeysSettings
+}
+
+// NewCacheKeysLinter creates a new CacheKeysLinter instance.
+func NewCacheKeysLinter(settings *config.CacheKeysSettings) *CacheKeysLinter {
+	if settings == nil {
+		settings = config.DefaultCacheKeysSettings()
+	}
+	return &CacheKeysLinter{settings: settings}
+}
+
+// LintWorkflow checks the key input of actions/cache steps, the configured actions, and
+// setup-* actions with caching for a hashFiles(...) expression. Steps without a key are
+// skipped: actions/cache requires one, and setup-* actions derive it from lockfiles.
+func (l *CacheKeysLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	workflowActions, err := wf.FindActions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find actions: %w", err)
+	}
+
+	var issues []*Issue
+	file := wf.BaseName()
+	for _, action := range workflowActions {
+		name, _, _ := strings.Cut(action.Uses, "@")
+		input, key, ok := l.cacheKey(action, name)
+		if !ok || hashFilesPattern.MatchString(key) {
+			continue
+		}
+		message := fmt.Sprintf("Cache key input '%s' of %s has no hashFiles(...) expression, "+
+			"so the cache isn't refreshed when dependencies change", input, name)
+		issues = append(issues, newRuleIssue(file, action.Line, ruleCacheKeysMissingHash, message))
+	}
+	return issues, nil
+}
+
+// cacheKey returns the name and value of the cache key input of an action, and whether
+// the action is checked and sets one.
+func (l *CacheKeysLinter) cacheKey(action *workflow.Action, name string) (string, string, bool) {
+	matches := func(candidate string) bool { return strings.EqualFold(name, candidate) }
+	if slices.ContainsFunc(cacheActions, matches) || slices.ContainsFunc(l.settings.Actions, matches) {
+		key, ok := action.Input("key")
+		return "key", key, ok
+	}
+
+	if !l.settings.ShouldCheckSetupActions() || !isSetupAction(name) {
+		return "", "", false
+	}
+	if cache, _ := action.Input("cache"); strings.EqualFold(cache, "false") {
+		return "", "", false
+	}
+	for _, input := range setupKeyInputs {
+		if key, ok := action.Input(input); ok {
+			return input, key, true
+		}
+	}
+	return "", "", false
+}
+
+// isSetupAction returns true if name refers to a setup-* action, such as actions/setup-go.
+func isSetupAction(name string) bool {
+	_, repo, ok := strings.Cut(name, "/")
+	repo, _, _ = strings.Cut(repo, "/")
+	return ok && strings.HasPrefix(strings.ToLower(repo), "setup-")
+}
@@ -0,0 +1,105 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestCacheKeysLinter_LintWorkflow(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/cache@v4
+        with:
+          path: ~/go/pkg/mod
+          key: ${{ runner.os }}-go-${{ hashFiles('**/go.sum') }}
+      - uses: actions/cache@v4
+        with:
+          path: node_modules
+          key: ${{ runner.os }}-node
+      - uses: actions/cache/restore@v4
+        with:
+          path: .cache
+          key: static-key
+      - uses: actions/cache/save@v4
+        with:
+          path: .cache
+          key: |
+            ${{ runner.os }}-${{
+              HashFiles('requirements.txt') }}
+      - uses: mlugg/setup-zig@v2
+        with:
+          cache-key: zig-${{ github.sha }}
+      - uses: mlugg/setup-zig@v2
+        with:
+          cache: false
+          cache-key: zig
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+      - uses: my-org/cache-action@v1
+        with:
+          key: deps
+`
+
+	tests := []struct {
+		name     string
+		settings *config.CacheKeysSettings
+		expected []string
+	}{
+		{
+			name: "default settings",
+			expected: []string{
+				"10: Cache key input 'key' of actions/cache has no hashFiles(...) expression, " +
+					"so the cache isn't refreshed when dependencies change",
+				"14: Cache key input 'key' of actions/cache/restore has no hashFiles(...) expression, " +
+					"so the cache isn't refreshed when dependencies change",
+				"24: Cache key input 'cache-key' of mlugg/setup-zig has no hashFiles(...) expression, " +
+					"so the cache isn't refreshed when dependencies change",
+			},
+		},
+		{
+			name: "additional actions without setup actions",
+			settings: &config.CacheKeysSettings{
+				Actions:           []string{"My-Org/cache-action"},
+				CheckSetupActions: new(bool),
+			},
+			expected: []string{
+				"10: Cache key input 'key' of actions/cache has no hashFiles(...) expression, " +
+					"so the cache isn't refreshed when dependencies change",
+				"14: Cache key input 'key' of actions/cache/restore has no hashFiles(...) expression, " +
+					"so the cache isn't refreshed when dependencies change",
+				"34: Cache key input 'key' of my-org/cache-action has no hashFiles(...) expression, " +
+					"so the cache isn't refreshed when dependencies change",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := workflow.ParseWorkflow("test.yml", []byte(content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			issues, err := NewCacheKeysLinter(tt.settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/reugn/github-ci/internal/stringutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// ruleCommandsDeprecated is the rule reported by the commands linter.
+const ruleCommandsDeprecated = "commands/deprecated-command"
+
+// deprecatedCommandPattern matches a deprecated workflow command at the start of echoed output,
+// capturing the command and the name parameter, if any (e.g., "::set-output name=version::").
+var deprecatedCommandPattern = regexp.MustCompile(
+	`(?:^|[\s"'])::(set-output|save-state|set-env|add-path)\b(?:\s+name=([^:\s]+))?`)
+
+// commandFiles maps each deprecated workflow command to the environment file that replaces it.
+var commandFiles = map[string]string{
+	"set-output": "GITHUB_OUTPUT",
+	"save-state": "GITHUB_STATE",
+	"set-env":    "GITHUB_ENV",
+	"add-path":   "GITHUB_PATH",
+}
+
+// CommandsLinter checks run scripts for the deprecated set-output, save-state, set-env,
+// and add-path workflow commands, which are replaced by environment files.
+type CommandsLinter struct {
+	noOpFixer
+}
+
+// NewCommandsLinter creates a new CommandsLinter instance.
+func NewCommandsLinter() *CommandsLinter {
+	return &CommandsLinter{}
+}
+
+// LintWorkflow checks the run scripts of a single workflow for deprecated workflow commands.
+// Only the values of run keys are checked, and comment lines are skipped.
+func (l *CommandsLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	runLines, err := wf.ValueLines("run")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	var issues []*Issue
+	file := wf.BaseName()
+	for i, line := range wf.Lines() {
+		if !runLines[i+1] || stringutil.IsBlankOrComment(line) {
+			continue
+		}
+		for _, match := range deprecatedCommandPattern.FindAllStringSubmatch(line, -1) {
+			issues = append(issues, newRuleIssue(file, i+1, ruleCommandsDeprecated,
+				deprecatedCommandMessage(match[1], match[2])))
+		}
+	}
+
+	return issues, nil
+}
+
+// deprecatedCommandMessage returns the issue message for a deprecated workflow command,
+// suggesting the equivalent write to its environment file.
+func deprecatedCommandMessage(command, name string) string {
+	file := commandFiles[command]
+	if command == "add-path" {
+		return fmt.Sprintf(`Workflow command '::add-path' is deprecated; use echo "<path>" >> "$%s" instead`, file)
+	}
+	if name == "" {
+		name = "<name>"
+	}
+	return fmt.Sprintf(`Workflow command '::%s' is deprecated; use echo "%s=<value>" >> "$%s" instead`,
+		command, name, file)
+}
@@ -0,0 +1,92 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestCommandsLinter_LintWorkflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name: "deprecated commands in run blocks",
+			content: `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - id: version
+        run: echo "::set-output name=version::1.2.3"
+      - run: |
+          # echo "::set-output name=old::value"
+          echo "::save-state name=pid::$PID"
+          echo "::set-env name=FOO::bar"
+          echo "::add-path::/opt/bin"
+          echo "::set-output::value"
+`,
+			expected: []string{
+				`7: Workflow command '::set-output' is deprecated; use echo "version=<value>" >> "$GITHUB_OUTPUT" instead`,
+				`10: Workflow command '::save-state' is deprecated; use echo "pid=<value>" >> "$GITHUB_STATE" instead`,
+				`11: Workflow command '::set-env' is deprecated; use echo "FOO=<value>" >> "$GITHUB_ENV" instead`,
+				`12: Workflow command '::add-path' is deprecated; use echo "<path>" >> "$GITHUB_PATH" instead`,
+				`13: Workflow command '::set-output' is deprecated; use echo "<name>=<value>" >> "$GITHUB_OUTPUT" instead`,
+			},
+		},
+		{
+			name: "commands outside run blocks",
+			content: `on: push
+env:
+  HINT: "::set-output name=version::"
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - name: Uses ::set-output
+        uses: actions/github-script@v7
+        with:
+          script: console.log("::save-state name=pid::1")
+`,
+		},
+		{
+			name: "environment files",
+			content: `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "version=1.2.3" >> "$GITHUB_OUTPUT"
+          echo "::notice::set-output is gone"
+          echo "::set-outputs name=x::y"
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := workflow.ParseWorkflow("test.yml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			issues, err := NewCommandsLinter().LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Rules reported by the continue-on-error linter.
+const (
+	ruleContinueOnErrorJob  = "continue-on-error/job"
+	ruleContinueOnErrorStep = "continue-on-error/step"
+)
+
+// ContinueOnErrorLinter checks for jobs and steps with continue-on-error: true,
+// whose failures don't fail the workflow and are easy to miss in review.
+type ContinueOnErrorLinter struct {
+	noOpFixer
+	settings *config.ContinueOnErrorSettings
+}
+
+// NewContinueOnErrorLinter creates a new ContinueOnErrorLinter instance.
+func NewContinueOnErrorLinter(settings *config.ContinueOnErrorSettings) *ContinueOnErrorLinter {
+	if settings == nil {
+		settings = config.DefaultContinueOnErrorSettings()
+	}
+	return &ContinueOnErrorLinter{settings: settings}
+}
+
+// LintWorkflow checks a single workflow for jobs and steps that set continue-on-error: true.
+// Expressions such as ${{ matrix.experimental }} are not reported, and jobs listed in
+// allow-jobs are skipped along with their steps.
+func (l *ContinueOnErrorLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	var issues []*Issue
+
+	if wf.Content == nil || wf.Content.Jobs == nil {
+		return issues, nil
+	}
+
+	model, err := wf.Model()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	file := wf.BaseName()
+	for _, jobModel := range model.Jobs {
+		job, ok := wf.Content.Jobs[jobModel.ID].(map[string]any)
+		if !ok || slices.Contains(l.settings.AllowJobs, jobModel.ID) {
+			continue
+		}
+
+		if isLiteralTrue(job["continue-on-error"]) {
+			message := fmt.Sprintf("Job '%s' sets continue-on-error: true, so its failures don't fail the workflow",
+				jobModel.ID)
+			issues = append(issues, newRuleIssue(file, jobModel.Line, ruleContinueOnErrorJob, message))
+		}
+
+		steps, _ := job["steps"].([]any)
+		for i, stepData := range steps {
+			step, ok := stepData.(map[string]any)
+			if !ok || !isLiteralTrue(step["continue-on-error"]) {
+				continue
+			}
+			line := jobModel.Line
+			if stepModel := jobModel.Step(i); stepModel != nil {
+				line = stepModel.Line
+			}
+			issues = append(issues, newRuleIssue(file, line, ruleContinueOnErrorStep,
+				continueOnErrorStepMessage(step, jobModel.ID)))
+		}
+	}
+
+	return issues, nil
+}
+
+// continueOnErrorStepMessage returns the issue message for a step with continue-on-error: true,
+// naming the step by its name or ID if it has one.
+func continueOnErrorStepMessage(step map[string]any, jobID string) string {
+	subject := "Step"
+	if name, _ := step["name"].(string); name != "" {
+		subject = fmt.Sprintf("Step '%s'", name)
+	} else if id, _ := step["id"].(string); id != "" {
+		subject = fmt.Sprintf("Step '%s'", id)
+	}
+	return fmt.Sprintf("%s in job '%s' sets continue-on-error: true, so its failure doesn't fail the job",
+		subject, jobID)
+}
+
+// isLiteralTrue reports whether a continue-on-error value is the literal true,
+// either as a YAML boolean or as a string.
+func isLiteralTrue(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(strings.TrimSpace(v), "true")
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestContinueOnErrorLinter_LintWorkflow(t *testing.T) {
+	content := `on: push
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    continue-on-error: true
+    steps:
+      - uses: actions/checkout@v4
+      - name: Upload coverage
+        run: ./upload.sh
+        continue-on-error: true
+      - id: notify
+        run: ./notify.sh
+        continue-on-error: "true"
+      - run: ./cleanup.sh
+        continue-on-error: true
+      - run: ./check.sh
+        continue-on-error: false
+  experimental:
+    runs-on: ubuntu-latest
+    continue-on-error: ${{ matrix.experimental }}
+    strategy:
+      matrix:
+        experimental: [true, false]
+    steps:
+      - run: make test
+        continue-on-error: ${{ matrix.experimental }}
+  nightly:
+    runs-on: ubuntu-latest
+    continue-on-error: true
+    steps:
+      - run: make nightly
+        continue-on-error: true
+`
+
+	tests := []struct {
+		name     string
+		settings *config.ContinueOnErrorSettings
+		expected []string
+	}{
+		{
+			name: "default settings",
+			expected: []string{
+				"3: Job 'deploy' sets continue-on-error: true, so its failures don't fail the workflow",
+				"8: Step 'Upload coverage' in job 'deploy' sets continue-on-error: true, " +
+					"so its failure doesn't fail the job",
+				"11: Step 'notify' in job 'deploy' sets continue-on-error: true, so its failure doesn't fail the job",
+				"14: Step in job 'deploy' sets continue-on-error: true, so its failure doesn't fail the job",
+				"27: Job 'nightly' sets continue-on-error: true, so its failures don't fail the workflow",
+				"31: Step in job 'nightly' sets continue-on-error: true, so its failure doesn't fail the job",
+			},
+		},
+		{
+			name:     "allowed jobs",
+			settings: &config.ContinueOnErrorSettings{AllowJobs: []string{"deploy", "nightly"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := workflow.ParseWorkflow("test.yml", []byte(content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			issues, err := NewContinueOnErrorLinter(tt.settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+var (
+	// ignoreLinePattern matches a "# github-ci:ignore" or "# github-ci:ignore=a,b" comment.
+	// Text after the directive, separated by whitespace, is treated as an explanation.
+	ignoreLinePattern = regexp.MustCompile(`(?:^|\s)#\s*github-ci:ignore(?:=([\w,-]+))?(?:\s|$)`)
+	// ignoreFilePattern matches a "# github-ci:ignore-file" comment line.
+	ignoreFilePattern = regexp.MustCompile(`^\s*#\s*github-ci:ignore-file(?:\s|$)`)
+	// rangePattern matches a "# github-ci:disable" or "# github-ci:enable" comment, optionally
+	// followed by linter names (e.g., "# github-ci:disable format,style").
+	rangePattern = regexp.MustCompile(
+		`(?:^|\s)#\s*github-ci:(disable|enable)(?:[=\s]\s*([a-z][\w-]*(?:,[a-z][\w-]*)*))?(?:\s|$)`)
+)
+
+// allLintersRange is the key of the linters disabled by a range marker without names.
+const allLintersRange = ""
+
+// ignoreDirectives holds the inline ignore comments found in a workflow file.
+type ignoreDirectives struct {
+	file   bool                    // Whole file is ignored
+	lines  map[int][]string        // Line number to ignored linter names (empty means all linters)
+	ranges map[int]map[string]bool // Line number to linters disabled by open ranges
+}
+
+// parseIgnoreDirectives scans the workflow lines for ignore comments.
+// A "# github-ci:ignore-file" comment is honored only in the leading comment block,
+// before the first line of YAML content.
+func parseIgnoreDirectives(wf *workflow.Workflow) *ignoreDirectives {
+	d := &ignoreDirectives{lines: make(map[int][]string), ranges: make(map[int]map[string]bool)}
+	inHeader := true
+	open := make(map[string]int) // Open disable ranges per linter name
+
+	for i, line := range wf.Lines() {
+		trimmed := strings.TrimSpace(line)
+		if inHeader && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			inHeader = false
+		}
+		if inHeader && ignoreFilePattern.MatchString(line) {
+			d.file = true
+			continue
+		}
+
+		if match := rangePattern.FindStringSubmatch(line); match != nil {
+			updateRanges(open, match[1] == "disable", splitNames(match[2]))
+		}
+		if disabled := disabledLinters(open); disabled != nil {
+			d.ranges[i+1] = disabled
+		}
+
+		match := ignoreLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		d.lines[i+1] = splitNames(match[1])
+	}
+
+	return d
+}
+
+// splitNames returns the non-empty names in a comma-separated list.
+func splitNames(list string) []string {
+	var names []string
+	for name := range strings.SplitSeq(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// updateRanges opens (disable) or closes (enable) a range for each of the named linters,
+// or for all linters if no names are given. Ranges nest, so a linter stays disabled until
+// each of its ranges is closed; closing a range that isn't open has no effect.
+func updateRanges(open map[string]int, disable bool, names []string) {
+	if len(names) == 0 {
+		names = []string{allLintersRange}
+	}
+	for _, name := range names {
+		switch {
+		case disable:
+			open[name]++
+		case open[name] > 0:
+			open[name]--
+		}
+	}
+}
+
+// disabledLinters returns the linters with an open range, or nil if there are none.
+func disabledLinters(open map[string]int) map[string]bool {
+	var disabled map[string]bool
+	for name, count := range open {
+		if count == 0 {
+			continue
+		}
+		if disabled == nil {
+			disabled = make(map[string]bool)
+		}
+		disabled[name] = true
+	}
+	return disabled
+}
+
+// ignores returns true if the issue is suppressed by a directive.
+func (d *ignoreDirectives) ignores(issue *Issue) bool {
+	if d.file {
+		return true
+	}
+	if disabled := d.ranges[issue.Line]; disabled[allLintersRange] || disabled[issue.Linter] {
+		return true
+	}
+	names, ok := d.lines[issue.Line]
+	if !ok || issue.Line == 0 {
+		return false
+	}
+	return len(names) == 0 || slices.Contains(names, issue.Linter)
+}
+
+// filterIgnored removes issues suppressed by inline ignore comments in the workflows.
+// Issues are matched to workflows by their full path, so workflows with the same file
+// name in different directories don't share ignore comments.
+func filterIgnored(workflows []*workflow.Workflow, issues []*Issue) []*Issue {
+	directives := make(map[string]*ignoreDirectives, len(workflows))
+	for _, wf := range workflows {
+		directives[wf.File] = parseIgnoreDirectives(wf)
+	}
+
+	var result []*Issue
+	for _, issue := range issues {
+		if d, ok := directives[issue.FullPath]; ok && d.ignores(issue) {
+			continue
+		}
+		result = append(result, issue)
+	}
+	return result
+}
+
+// unknownLinterIssues returns an issue for each name in a disable or enable marker that
+// isn't a known linter, since a misspelled name leaves the intended linter enabled.
+func unknownLinterIssues(workflows []*workflow.Workflow) []*Issue {
+	known := config.AllLinters()
+	var issues []*Issue
+	for _, wf := range workflows {
+		for i, line := range wf.Lines() {
+			match := rangePattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			for _, name := range splitNames(match[2]) {
+				if slices.Contains(known, name) {
+					continue
+				}
+				message := fmt.Sprintf("Unknown linter '%s' in github-ci:%s marker", name, match[1])
+				issue := newRuleIssue(wf.BaseName(), i+1, ruleSyntaxUnknownLinter, message)
+				issue.FullPath = wf.File
+				issue.Linter = SyntaxLinter
+				issue.Severity = config.SeverityError
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
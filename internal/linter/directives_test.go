@@ -0,0 +1,255 @@
+package linter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestIgnoreDirectives_Ignores(t *testing.T) {
+	content := `# Deploy workflow
+on: push # github-ci:ignore
+name: Deploy # github-ci:ignore=format,style
+jobs: # github-ci:ignore=versions -- pinned by renovate
+  build: # not a github-ci:ignore directive
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "#github-ci:ignore"
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	d := parseIgnoreDirectives(wf)
+
+	tests := []struct {
+		name     string
+		line     int
+		linter   string
+		expected bool
+	}{
+		{"all linters", 2, config.LinterPermissions, true},
+		{"listed linter", 3, config.LinterStyle, true},
+		{"unlisted linter", 3, config.LinterSecrets, false},
+		{"explanation after list", 4, config.LinterVersions, true},
+		{"directive not at comment start", 5, config.LinterFormat, false},
+		{"directive inside a string", 8, config.LinterFormat, false},
+		{"line without directive", 6, config.LinterFormat, false},
+		{"file-level issue", 0, config.LinterFormat, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &Issue{File: "test.yml", Line: tt.line, Linter: tt.linter}
+			if got := d.ignores(issue); got != tt.expected {
+				t.Errorf("ignores() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIgnoreDirectives_Ranges(t *testing.T) {
+	content := `name: Generated
+on: push
+# github-ci:disable format
+jobs:
+  build: # github-ci:disable=style -- generated job
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          # github-ci:enable format
+          echo "format is checked again"
+          # github-ci:disable format,secrets
+          # github-ci:disable format
+          echo "nested"
+          # github-ci:enable format
+          echo "still in the outer range"
+          # github-ci:enable format,secrets
+  # github-ci:enable style
+  test: # github-ci:enable versions
+    runs-on: ubuntu-latest
+    # github-ci:disable -- everything below is generated
+    steps: []
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	d := parseIgnoreDirectives(wf)
+
+	tests := []struct {
+		name     string
+		line     int
+		linter   string
+		expected bool
+	}{
+		{"before range", 2, config.LinterFormat, false},
+		{"disable marker line", 3, config.LinterFormat, true},
+		{"inside range", 4, config.LinterFormat, true},
+		{"other linter", 4, config.LinterStyle, false},
+		{"explanation after names", 6, config.LinterStyle, true},
+		{"enable marker line", 9, config.LinterFormat, false},
+		{"after enable", 10, config.LinterFormat, false},
+		{"other range still open", 10, config.LinterStyle, true},
+		{"multiple names", 11, config.LinterSecrets, true},
+		{"nested range", 13, config.LinterFormat, true},
+		{"outer range after inner enable", 15, config.LinterFormat, true},
+		{"all ranges closed", 17, config.LinterFormat, false},
+		{"closed range", 17, config.LinterStyle, false},
+		{"enable without open range", 18, config.LinterVersions, false},
+		{"unclosed range without names", 21, config.LinterPermissions, true},
+		{"unclosed range to end of file", 21, config.LinterFormat, true},
+		{"file-level issue", 0, config.LinterFormat, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &Issue{File: "test.yml", Line: tt.line, Linter: tt.linter}
+			if got := d.ignores(issue); got != tt.expected {
+				t.Errorf("ignores() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIgnoreDirectives_IgnoreFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{
+			name:     "in leading comments",
+			content:  "# Legacy workflow\n\n# github-ci:ignore-file\non: push\n",
+			expected: true,
+		},
+		{
+			name:     "after content",
+			content:  "on: push\n# github-ci:ignore-file\n",
+			expected: false,
+		},
+		{
+			name:     "absent",
+			content:  "# github-ci:ignore\non: push\n",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", tt.content)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+			if got := parseIgnoreDirectives(wf).file; got != tt.expected {
+				t.Errorf("parseIgnoreDirectives().file = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkflowLinter_IgnoreDirectives(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Linters: &config.LinterConfig{
+		Default: "none",
+		Enable:  []string{config.LinterFormat, config.LinterStyle},
+	}}
+
+	ignoredPath := testutil.CreateWorkflow(t, tmpDir, "ignored.yml",
+		"# github-ci:ignore-file\non: push   \njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+	// Both directive lines have trailing whitespace, reported by the format linter
+	partialPath := testutil.CreateWorkflow(t, tmpDir, "partial.yml", `name: Partial
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo one # github-ci:ignore=format `+`
+      - run: echo two # github-ci:ignore=style `+`
+`)
+	// Only the trailing whitespace outside the disabled range is reported
+	rangedPath := testutil.CreateWorkflow(t, tmpDir, "ranged.yml",
+		"name: Ranged\non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n"+
+			"      # github-ci:disable format\n      - run: echo one  \n      # github-ci:enable format\n"+
+			"      - run: echo two  \n")
+
+	var workflows []*workflow.Workflow
+	for _, path := range []string{ignoredPath, partialPath, rangedPath} {
+		wf, err := workflow.LoadWorkflow(path)
+		if err != nil {
+			t.Fatalf("LoadWorkflow() error = %v", err)
+		}
+		workflows = append(workflows, wf)
+	}
+
+	issues, err := NewWithConfig(context.Background(), workflows, cfg).Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		if issue.File == "partial.yml" && (issue.Line == 7 || issue.Line == 8) {
+			got = append(got, issue.File+":"+issue.Linter)
+		}
+		if issue.File == "ranged.yml" && issue.Linter == config.LinterFormat {
+			got = append(got, fmt.Sprintf("%s:%d:%s", issue.File, issue.Line, issue.Linter))
+		}
+		if issue.File == "ignored.yml" {
+			t.Errorf("unexpected issue in ignored file: %s", issue)
+		}
+	}
+	slices.Sort(got)
+	expected := []string{"partial.yml:format", "ranged.yml:10:format"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("issues on directive lines = %v, want %v", got, expected)
+	}
+}
+
+func TestWorkflowLinter_UnknownLinterInMarker(t *testing.T) {
+	path := testutil.CreateWorkflow(t, t.TempDir(), "markers.yml", `name: Markers
+on: push
+# github-ci:disable format,formatting
+jobs:
+  build: # github-ci:disable generated job
+    runs-on: ubuntu-latest
+    # github-ci:enable -- explanation only
+    steps: []
+# github-ci:enable style,formatting
+`)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	cfg := &config.Config{Linters: &config.LinterConfig{Default: "none"}}
+
+	issues, err := NewWithConfig(context.Background(), []*workflow.Workflow{wf}, cfg).Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		if issue.RuleID != ruleSyntaxUnknownLinter || issue.Linter != SyntaxLinter ||
+			issue.Severity != config.SeverityError || issue.FullPath != path {
+			t.Errorf("unexpected issue: %+v", *issue)
+		}
+		got = append(got, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+	}
+	expected := []string{
+		"3: Unknown linter 'formatting' in github-ci:disable marker",
+		"5: Unknown linter 'generated' in github-ci:disable marker",
+		"9: Unknown linter 'formatting' in github-ci:enable marker",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("issues = %v, want %v", got, expected)
+	}
+}
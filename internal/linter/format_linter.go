@@ -2,7 +2,6 @@ package linter
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/reugn/github-ci/internal/config"
@@ -10,6 +9,16 @@ import (
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
+// Rules reported by the format linter.
+const (
+	ruleFormatBlankLines         = "format/blank-lines"
+	ruleFormatTrailingWhitespace = "format/trailing-whitespace"
+	ruleFormatLineLength         = "format/line-length"
+	ruleFormatTabs               = "format/tab-indentation"
+	ruleFormatMixedIndentation   = "format/mixed-indentation"
+	ruleFormatIndentation        = "format/indentation"
+)
+
 // FormatLinter checks for YAML formatting issues in workflow files.
 type FormatLinter struct {
 	settings *config.FormatSettings
@@ -28,6 +37,9 @@ func (l *FormatLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
 	file := wf.BaseName()
 	lines := wf.Lines()
 	minIndent := l.findMinIndentation(lines)
+	indent := l.indentationFor(lines)
+	mixed, mixedMessage := mixedIndentation(lines)
+	skipWhitespace := l.skippedWhitespaceLines(lines)
 
 	var (
 		issues       []*Issue
@@ -44,12 +56,12 @@ func (l *FormatLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
 
 		// Check multiple consecutive blank lines
 		if isBlank && prevWasBlank {
-			issues = append(issues, newIssue(file, lineNum, "Multiple consecutive blank lines found"))
+			issues = append(issues, newRuleIssue(file, lineNum, ruleFormatBlankLines, "Multiple consecutive blank lines found"))
 		}
 
 		// Check trailing whitespace
-		if stringutil.HasTrailingWhitespace(line) {
-			issues = append(issues, newIssue(file, lineNum, "Line has trailing whitespace"))
+		if stringutil.HasTrailingWhitespace(line) && !skipWhitespace[lineNum] {
+			issues = append(issues, newRuleIssue(file, lineNum, ruleFormatTrailingWhitespace, "Line has trailing whitespace"))
 		}
 
 		// Check line length
@@ -59,10 +71,15 @@ func (l *FormatLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
 
 		// Check indentation (skip blank lines and comments)
 		if !isBlank && !isComment {
-			if issue := l.checkIndentation(line, file, lineNum, leadingSpaces, minIndent, prevIndent); issue != nil {
+			if mixed[lineNum] {
+				issues = append(issues, newRuleIssue(file, lineNum, ruleFormatMixedIndentation, mixedMessage))
+			} else if issue := indent.check(line, file, lineNum, leadingSpaces, minIndent, prevIndent); issue != nil {
 				issues = append(issues, issue)
 			}
-			prevIndent = leadingSpaces
+			// Tab-indented lines have no meaningful depth to compare against
+			if !strings.HasPrefix(line, "\t") {
+				prevIndent = leadingSpaces
+			}
 		}
 
 		prevWasBlank = isBlank
@@ -71,6 +88,15 @@ func (l *FormatLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
 	return issues, nil
 }
 
+// skippedWhitespaceLines returns the 1-based numbers of lines whose trailing whitespace
+// is left alone: block scalar content, unless check-block-scalars is enabled.
+func (l *FormatLinter) skippedWhitespaceLines(lines []string) map[int]bool {
+	if l.settings.ShouldCheckBlockScalars() {
+		return nil
+	}
+	return workflow.BlockScalarLines(lines)
+}
+
 // checkLineLength checks if a line exceeds the configured maximum.
 func (l *FormatLinter) checkLineLength(line, file string, lineNum int) *Issue {
 	if l.settings == nil || l.settings.MaxLineLength <= 0 {
@@ -78,39 +104,109 @@ func (l *FormatLinter) checkLineLength(line, file string, lineNum int) *Issue {
 	}
 	if len(line) > l.settings.MaxLineLength {
 		message := fmt.Sprintf("Line exceeds maximum length of %d characters (found %d)", l.settings.MaxLineLength, len(line))
-		return newIssue(file, lineNum, message)
+		return newRuleIssue(file, lineNum, ruleFormatLineLength, message)
 	}
 	return nil
 }
 
-// checkIndentation validates indentation rules for a line.
-func (l *FormatLinter) checkIndentation(line, file string, lineNum, leadingSpaces, minIndent, prevIndent int) *Issue {
-	if l.settings == nil || l.settings.IndentWidth <= 0 {
+// indentation is the indentation width a file is checked against.
+type indentation struct {
+	width    int  // Spaces per indentation level; 0 disables the checks
+	detected bool // Width was inferred from the file rather than configured
+}
+
+// indentationFor returns the configured indentation width, or the width detected
+// from lines if indent-width is "auto".
+func (l *FormatLinter) indentationFor(lines []string) indentation {
+	if l.settings == nil {
+		return indentation{}
+	}
+	if l.settings.IndentWidth != config.IndentAuto {
+		return indentation{width: int(l.settings.IndentWidth)}
+	}
+	return indentation{width: detectIndentWidth(lines), detected: true}
+}
+
+// check validates indentation rules for a line.
+func (in indentation) check(line, file string, lineNum, leadingSpaces, minIndent, prevIndent int) *Issue {
+	if in.width <= 0 {
 		return nil
 	}
 
+	increase := leadingSpaces - prevIndent
 	var message string
 	switch {
 	// Check for tabs
 	case strings.HasPrefix(line, "\t"):
-		message = fmt.Sprintf("Line uses tabs for indentation, expected %d spaces",
-			l.settings.IndentWidth)
+		message := fmt.Sprintf("Line uses tabs for indentation, expected %d spaces", in.width)
+		return newRuleIssue(file, lineNum, ruleFormatTabs, message)
+	// With a detected width, any other step is an inconsistency within the file
+	case in.detected && increase > 0 && increase != in.width:
+		message = fmt.Sprintf("File mixes %d and %d space indentation", in.width, increase)
 	// Check indentation is multiple of indent-width
-	case leadingSpaces > 0 && leadingSpaces%l.settings.IndentWidth != 0:
+	case leadingSpaces > 0 && leadingSpaces%in.width != 0:
 		message = fmt.Sprintf("Line indentation is %d spaces, expected multiple of %d",
-			leadingSpaces, l.settings.IndentWidth)
-	// Check base indentation level
-	case minIndent > 0 && minIndent != l.settings.IndentWidth && leadingSpaces == minIndent:
+			leadingSpaces, in.width)
+	// Check base indentation level; a detected width is taken from the first step
+	case !in.detected && minIndent > 0 && minIndent != in.width && leadingSpaces == minIndent:
 		message = fmt.Sprintf("Line uses %d spaces for base indentation, expected %d spaces",
-			leadingSpaces, l.settings.IndentWidth)
+			leadingSpaces, in.width)
 	// Check indentation increase is exactly indent-width
-	case leadingSpaces > prevIndent && (leadingSpaces-prevIndent) != l.settings.IndentWidth:
-		increase := leadingSpaces - prevIndent
+	case increase > 0 && increase != in.width:
 		message = fmt.Sprintf("Line indentation increased by %d spaces, expected increase of %d (should be %d spaces)",
-			increase, l.settings.IndentWidth, prevIndent+l.settings.IndentWidth)
+			increase, in.width, prevIndent+in.width)
 	}
 
-	return newIssue(file, lineNum, message)
+	return newRuleIssue(file, lineNum, ruleFormatIndentation, message)
+}
+
+// mixedIndentation returns the 1-based numbers of the lines indented differently from
+// most indented lines when a file uses both tabs and spaces, with the message to report
+// for them. Returns nil if the file doesn't mix tabs and spaces.
+func mixedIndentation(lines []string) (map[int]bool, string) {
+	var tabLines, spaceLines []int
+	for i, line := range lines {
+		switch {
+		case stringutil.IsBlankOrComment(line):
+		case strings.HasPrefix(line, "\t"):
+			tabLines = append(tabLines, i+1)
+		case strings.HasPrefix(line, " "):
+			spaceLines = append(spaceLines, i+1)
+		}
+	}
+	if len(tabLines) == 0 || len(spaceLines) == 0 {
+		return nil, ""
+	}
+
+	deviating, message := tabLines, "Mixed indentation (tabs and spaces): line is indented with tabs, "+
+		"but the file uses spaces"
+	if len(tabLines) > len(spaceLines) {
+		deviating, message = spaceLines, "Mixed indentation (tabs and spaces): line is indented with spaces, "+
+			"but the file uses tabs"
+	}
+
+	lineNums := make(map[int]bool, len(deviating))
+	for _, lineNum := range deviating {
+		lineNums[lineNum] = true
+	}
+	return lineNums, message
+}
+
+// detectIndentWidth returns the size of the first indentation step in lines,
+// or 0 if no line is indented deeper than the one before it.
+func detectIndentWidth(lines []string) int {
+	prevIndent := 0
+	for _, line := range lines {
+		if stringutil.IsBlankOrComment(line) || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		spaces := stringutil.CountLeadingSpaces(line)
+		if spaces > prevIndent {
+			return spaces - prevIndent
+		}
+		prevIndent = spaces
+	}
+	return 0
 }
 
 // findMinIndentation finds the minimum non-zero indentation in the file.
@@ -132,34 +228,35 @@ func (l *FormatLinter) findMinIndentation(lines []string) int {
 // FixWorkflow automatically fixes formatting issues in a single workflow.
 func (l *FormatLinter) FixWorkflow(wf *workflow.Workflow) error {
 	lines := wf.Lines()
-	fixed := l.fixLines(lines)
+	fixed := l.fixLines(lines, l.skippedWhitespaceLines(lines), l.indentationFor(lines).width)
 
 	// Remove trailing empty lines
 	for len(fixed) > 0 && strings.TrimSpace(fixed[len(fixed)-1]) == "" {
 		fixed = fixed[:len(fixed)-1]
 	}
 
-	content := strings.Join(fixed, "\n") + "\n"
-
-	// Keep in-memory state in sync with the fixed content
-	wf.RawBytes = []byte(content)
-
-	// Write the fixed content to the file
-	return os.WriteFile(wf.File, wf.RawBytes, 0600)
+	return wf.SetRawBytes([]byte(strings.Join(fixed, "\n") + "\n"))
 }
 
-// fixLines applies formatting fixes to lines.
-func (l *FormatLinter) fixLines(lines []string) []string {
+// fixLines applies formatting fixes to lines, keeping trailing whitespace
+// on the lines in skipWhitespace (by 1-based line number). Block scalar content
+// keeps its indentation, which is part of the value.
+func (l *FormatLinter) fixLines(lines []string, skipWhitespace map[int]bool, indentWidth int) []string {
 	fixed := make([]string, 0, len(lines))
+	blockLines := workflow.BlockScalarLines(lines)
 	var prevWasBlank bool
 	var prevIndent int
 
-	for _, line := range lines {
+	for i, line := range lines {
 		// Trim trailing whitespace
-		line = strings.TrimRight(line, " \t")
+		if !skipWhitespace[i+1] {
+			line = strings.TrimRight(line, " \t")
+		}
 
 		// Fix over-indentation
-		line = l.fixIndentation(line, prevIndent)
+		if !blockLines[i+1] {
+			line = fixIndentation(line, prevIndent, indentWidth)
+		}
 
 		isBlank := strings.TrimSpace(line) == ""
 
@@ -180,9 +277,9 @@ func (l *FormatLinter) fixLines(lines []string) []string {
 	return fixed
 }
 
-// fixIndentation reduces indentation when it increases by more than indent-width.
-func (l *FormatLinter) fixIndentation(line string, prevIndent int) string {
-	if l.settings == nil || l.settings.IndentWidth <= 0 {
+// fixIndentation reduces indentation when it increases by more than indentWidth.
+func fixIndentation(line string, prevIndent, indentWidth int) string {
+	if indentWidth <= 0 {
 		return line
 	}
 
@@ -196,11 +293,11 @@ func (l *FormatLinter) fixIndentation(line string, prevIndent int) string {
 	}
 
 	increase := leadingSpaces - prevIndent
-	if increase <= l.settings.IndentWidth {
+	if increase <= indentWidth {
 		return line
 	}
 
 	// Reduce to correct indentation
-	correctIndent := prevIndent + l.settings.IndentWidth
+	correctIndent := prevIndent + indentWidth
 	return strings.Repeat(" ", correctIndent) + strings.TrimLeft(line, " \t")
 }
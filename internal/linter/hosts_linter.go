@@ -0,0 +1,109 @@
+package linter
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/stringutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Rules reported by the hosts linter.
+const (
+	ruleHostsIPAddress = "hosts/internal-ip"
+	ruleHostsHostname  = "hosts/internal-hostname"
+)
+
+// hostsContentKeys are the keys whose values are scanned for hardcoded hosts.
+var hostsContentKeys = []string{"run", "with", "env"}
+
+// ipv4Pattern matches dotted-quad IPv4 address literals.
+var ipv4Pattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+
+// hostnamePattern matches dotted hostnames (e.g., db.corp.example.com).
+var hostnamePattern = regexp.MustCompile(`(?i)\b(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z][a-z0-9-]*\b`)
+
+// HostsLinter checks run scripts, action inputs, and env values for hardcoded
+// internal IP addresses and hostnames.
+type HostsLinter struct {
+	noOpFixer
+	prefixes []netip.Prefix
+	domains  []string
+}
+
+// NewHostsLinter creates a new HostsLinter instance.
+func NewHostsLinter(settings *config.HostsSettings) *HostsLinter {
+	if settings == nil {
+		settings = config.DefaultHostsSettings()
+	}
+
+	domains := make([]string, 0, len(settings.Domains))
+	for _, d := range settings.Domains {
+		domains = append(domains, strings.ToLower(strings.Trim(d, ".")))
+	}
+	return &HostsLinter{prefixes: settings.Prefixes(), domains: domains}
+}
+
+// LintWorkflow checks a single workflow for hardcoded internal hosts.
+func (l *HostsLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	var issues []*Issue
+
+	contentLines, err := wf.ValueLines(hostsContentKeys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	file := wf.BaseName()
+	for i, line := range wf.Lines() {
+		if !contentLines[i+1] || stringutil.IsBlankOrComment(line) {
+			continue
+		}
+		issues = append(issues, l.checkLine(file, i+1, line)...)
+	}
+
+	return issues, nil
+}
+
+// checkLine returns an issue for each internal address or hostname on the line.
+func (l *HostsLinter) checkLine(file string, lineNum int, line string) []*Issue {
+	var issues []*Issue
+
+	for _, literal := range ipv4Pattern.FindAllString(line, -1) {
+		addr, err := netip.ParseAddr(literal)
+		if err != nil {
+			continue
+		}
+		for _, prefix := range l.prefixes {
+			if prefix.Contains(addr) {
+				message := fmt.Sprintf("Hardcoded internal IP address '%s' (in %s)", literal, prefix)
+				issues = append(issues, newRuleIssue(file, lineNum, ruleHostsIPAddress, message))
+				break
+			}
+		}
+	}
+
+	if len(l.domains) == 0 {
+		return issues
+	}
+	for _, host := range hostnamePattern.FindAllString(line, -1) {
+		if domain, ok := l.matchDomain(strings.ToLower(host)); ok {
+			message := fmt.Sprintf("Hardcoded internal hostname '%s' (matches '%s')", host, domain)
+			issues = append(issues, newRuleIssue(file, lineNum, ruleHostsHostname, message))
+		}
+	}
+
+	return issues
+}
+
+// matchDomain returns the configured domain suffix that host equals or is a subdomain of.
+func (l *HostsLinter) matchDomain(host string) (string, bool) {
+	for _, domain := range l.domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return domain, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,109 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestHostsLinter_LintWorkflow(t *testing.T) {
+	content := `name: Deploy 10.0.0.1
+on: push
+env:
+  API_URL: https://api.corp.example.com/v1
+  MIRROR: https://proxy.golang.org
+jobs:
+  deploy:
+    name: Deploy
+    runs-on: ubuntu-latest
+    steps:
+      - name: Ping
+        run: |
+          # ssh deploy@10.1.2.3 for debugging
+          ping -c 1 8.8.8.8
+          curl http://192.168.1.20:8080/health
+      - name: Upload
+        uses: actions/upload-artifact@v4
+        with:
+          path: dist
+          url: https://github.com
+      - name: Notify
+        run: curl -X POST https://hooks.corp.example.com/ci
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "deploy.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	settings := config.DefaultHostsSettings()
+	settings.Domains = []string{".corp.example.com"}
+
+	issues, err := NewHostsLinter(settings).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+
+	expected := []string{
+		"4: Hardcoded internal hostname 'api.corp.example.com' (matches 'corp.example.com')",
+		"15: Hardcoded internal IP address '192.168.1.20' (in 192.168.0.0/16)",
+		"22: Hardcoded internal hostname 'hooks.corp.example.com' (matches 'corp.example.com')",
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("LintWorkflow() issues = %v, want %v", got, expected)
+	}
+}
+
+func TestHostsLinter_CheckLine(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name     string
+		settings *config.HostsSettings
+		line     string
+		expected int
+	}{
+		{"private address", nil, "curl http://10.20.30.40/", 1},
+		{"public address", nil, "ping 8.8.8.8", 0},
+		{"outside 172.16.0.0/12", nil, "ping 172.32.0.1", 0},
+		{"version number", nil, "go-version: 1.24.0", 0},
+		{"several addresses", nil, "scp 10.0.0.1:/a 172.16.5.4:/b", 2},
+		{"private ranges disabled", &config.HostsSettings{PrivateRanges: &disabled}, "curl http://10.0.0.1/", 0},
+		{
+			"custom range",
+			&config.HostsSettings{PrivateRanges: &disabled, Ranges: []string{"100.64.0.0/10"}},
+			"curl http://100.64.1.1/",
+			1,
+		},
+		{"domain suffix", &config.HostsSettings{Domains: []string{"internal"}}, "ssh build.internal", 1},
+		{"exact domain", &config.HostsSettings{Domains: []string{"corp.example.com"}}, "dig corp.example.com", 1},
+		{"similar domain", &config.HostsSettings{Domains: []string{"corp.example.com"}}, "dig notcorp.example.com", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewHostsLinter(tt.settings).checkLine("test.yml", 1, tt.line); len(got) != tt.expected {
+				t.Errorf("checkLine(%q) = %v, want %d issue(s)", tt.line, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHostsLinter_OptIn(t *testing.T) {
+	cfg := &config.Config{Linters: &config.LinterConfig{Default: "all"}}
+	if cfg.IsLinterEnabled(config.LinterHosts) {
+		t.Error("hosts linter should not be enabled by default: all")
+	}
+
+	cfg.Linters.Enable = []string{config.LinterHosts}
+	if !cfg.IsLinterEnabled(config.LinterHosts) {
+		t.Error("hosts linter should be enabled when listed in enable")
+	}
+}
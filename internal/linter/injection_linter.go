@@ -6,10 +6,19 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/stringutil"
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
+// Rules reported by the injection linter.
+const (
+	ruleInjectionRun          = "injection/run"
+	ruleInjectionScript       = "injection/github-script"
+	ruleInjectionInput        = "injection/action-input"
+	ruleInjectionHeadCheckout = "injection/pr-head-checkout"
+)
+
 // dangerousContexts lists GitHub context expressions that can be attacker-controlled
 // and are dangerous when used directly in run: commands.
 // These should be passed through environment variables instead.
@@ -60,6 +69,14 @@ var stepConfigKeys = []string{
 	"shell:",
 }
 
+// githubScriptAction runs its script input as inline JavaScript, which is as vulnerable
+// to expression interpolation as a run command.
+const githubScriptAction = "actions/github-script"
+
+// pullRequestHeadPattern matches a checkout ref that points at the head of a pull request,
+// e.g., ${{ github.event.pull_request.head.sha }} or refs/pull/${{ ... }}/merge.
+var pullRequestHeadPattern = regexp.MustCompile(`github\.event\.pull_request\.head\.|github\.head_ref|refs/pull/`)
+
 // initPatterns compiles dangerous context patterns once.
 func initPatterns() {
 	patternsOnce.Do(func() {
@@ -75,16 +92,22 @@ func initPatterns() {
 }
 
 // InjectionLinter checks for shell injection vulnerabilities in workflow files.
-// It detects dangerous use of GitHub context expressions in run: commands
-// that could allow attackers to inject arbitrary commands.
+// It detects dangerous use of GitHub context expressions in run: commands and in
+// the script: input of actions/github-script that could allow attackers to inject
+// arbitrary commands or code, and checkouts of the pull request's head in
+// pull_request_target workflows, which run untrusted code with secrets.
 type InjectionLinter struct {
 	noOpFixer
+	settings *config.InjectionSettings
 }
 
 // NewInjectionLinter creates a new InjectionLinter instance.
-func NewInjectionLinter() *InjectionLinter {
+func NewInjectionLinter(settings *config.InjectionSettings) *InjectionLinter {
 	initPatterns()
-	return &InjectionLinter{}
+	if settings == nil {
+		settings = config.DefaultInjectionSettings()
+	}
+	return &InjectionLinter{settings: settings}
 }
 
 // lineContext tracks the parsing state while scanning workflow lines.
@@ -93,6 +116,29 @@ type lineContext struct {
 	inEnvBlock     bool
 	runBlockIndent int
 	envBlockIndent int
+
+	step              stepTracker
+	scriptSteps       map[int]bool // Start lines of actions/github-script steps
+	inScriptBlock     bool
+	scriptBlockIndent int
+	inWithBlock       bool
+	withBlockIndent   int
+}
+
+// stepTracker follows which sequence item (e.g., a step) a line belongs to by indentation.
+type stepTracker struct {
+	start  int // Line number starting the current item, or 0 if outside any item
+	indent int // Indentation of the current item's "- "
+}
+
+// update moves the tracker to the item containing the given non-blank line.
+func (t *stepTracker) update(lineNum int, trimmed string, indent int) {
+	switch {
+	case strings.HasPrefix(trimmed, "- ") && (t.start == 0 || indent <= t.indent):
+		t.start, t.indent = lineNum, indent
+	case t.start != 0 && indent <= t.indent:
+		t.start = 0
+	}
 }
 
 // LintWorkflow checks a single workflow for injection vulnerabilities.
@@ -100,7 +146,7 @@ func (l *InjectionLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error)
 	var issues []*Issue
 	file := wf.BaseName()
 	lines := wf.Lines()
-	ctx := &lineContext{}
+	ctx := &lineContext{scriptSteps: githubScriptSteps(lines)}
 
 	for i, line := range lines {
 		if issue := l.processLine(file, i+1, line, ctx); issue != nil {
@@ -108,6 +154,40 @@ func (l *InjectionLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error)
 		}
 	}
 
+	if wf.HasTrigger("pull_request_target") {
+		checkoutIssues, err := pullRequestHeadCheckouts(wf, file)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, checkoutIssues...)
+	}
+
+	return issues, nil
+}
+
+// pullRequestHeadCheckouts reports actions/checkout steps whose ref input points at the
+// pull request's head. In a pull_request_target workflow, any build or script run after
+// such a checkout executes the pull request's code with secrets and a write token.
+func pullRequestHeadCheckouts(wf *workflow.Workflow, file string) ([]*Issue, error) {
+	workflowActions, err := wf.FindActions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find actions: %w", err)
+	}
+
+	var issues []*Issue
+	for _, action := range workflowActions {
+		name, _, _ := strings.Cut(action.Uses, "@")
+		if !strings.EqualFold(name, "actions/checkout") {
+			continue
+		}
+		ref, _ := action.Input("ref")
+		if !pullRequestHeadPattern.MatchString(ref) {
+			continue
+		}
+		issues = append(issues, newRuleIssue(file, action.Line, ruleInjectionHeadCheckout,
+			"Checkout of the pull request's head in a pull_request_target workflow runs untrusted code "+
+				"with the repository's secrets and a write token"))
+	}
 	return issues, nil
 }
 
@@ -122,6 +202,35 @@ func (l *InjectionLinter) processLine(file string, lineNum int, line string, ctx
 
 	currentIndent := stringutil.CountLeadingSpaces(line)
 
+	// Check content of a github-script script block
+	if ctx.inScriptBlock {
+		if currentIndent > ctx.scriptBlockIndent {
+			return l.checkScriptForInjection(file, lineNum, line, line)
+		}
+		ctx.inScriptBlock = false
+	}
+
+	// Detect the script input of a github-script step
+	ctx.step.update(lineNum, trimmed, currentIndent)
+	if ctx.scriptSteps[ctx.step.start] && currentIndent > ctx.step.indent && isScriptKey(trimmed) {
+		ctx.inScriptBlock = true
+		ctx.scriptBlockIndent = currentIndent
+		return l.checkScriptForInjection(file, lineNum, line, extractKeyContent(trimmed, "script:"))
+	}
+
+	// Check action inputs (opt-in)
+	if l.settings.CheckWithInputs {
+		if ctx.inWithBlock && currentIndent > ctx.withBlockIndent {
+			return l.checkInputForInjection(file, lineNum, line, line)
+		}
+		ctx.inWithBlock = isWithKey(trimmed)
+		if ctx.inWithBlock {
+			ctx.withBlockIndent = currentIndent
+			// Check inline inputs (with: {title: ...})
+			return l.checkInputForInjection(file, lineNum, line, trimmed)
+		}
+	}
+
 	// Update context based on current line
 	l.updateContext(trimmed, currentIndent, ctx)
 
@@ -133,19 +242,61 @@ func (l *InjectionLinter) processLine(file string, lineNum int, line string, ctx
 
 		// Check inline run command (run: echo "...")
 		if runContent := extractRunContent(trimmed); runContent != "" {
-			return l.checkForInjection(file, lineNum, runContent)
+			return l.checkForInjection(file, lineNum, line, runContent)
 		}
 		return nil
 	}
 
 	// Check content inside run block (but not in env block)
 	if ctx.inRunBlock && !ctx.inEnvBlock && !isStepConfigKey(trimmed) {
-		return l.checkForInjection(file, lineNum, line)
+		return l.checkForInjection(file, lineNum, line, line)
 	}
 
 	return nil
 }
 
+// githubScriptSteps returns the start lines of steps that use actions/github-script,
+// wherever the uses: key appears within the step.
+func githubScriptSteps(lines []string) map[int]bool {
+	steps := make(map[int]bool)
+	var step stepTracker
+	for i, line := range lines {
+		if stringutil.IsBlankOrComment(line) {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		step.update(i+1, trimmed, stringutil.CountLeadingSpaces(line))
+		if step.start == 0 {
+			continue
+		}
+
+		uses, ok := strings.CutPrefix(strings.TrimPrefix(trimmed, "- "), "uses:")
+		if ok && strings.HasPrefix(strings.ToLower(strings.TrimSpace(uses)), githubScriptAction+"@") {
+			steps[step.start] = true
+		}
+	}
+	return steps
+}
+
+// isScriptKey checks if a line holds the script: input of a step's with: block.
+func isScriptKey(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "script:")
+}
+
+// isWithKey checks if a line starts the with: inputs of a step or reusable workflow call.
+func isWithKey(trimmed string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(trimmed, "- "), "with:")
+}
+
+// extractKeyContent extracts the inline value after key, or an empty string for a block scalar.
+func extractKeyContent(trimmed, key string) string {
+	content := strings.TrimSpace(strings.TrimPrefix(trimmed, key))
+	if isBlockScalarIndicator(content) {
+		return ""
+	}
+	return content
+}
+
 // updateContext updates the parsing context based on the current line.
 func (l *InjectionLinter) updateContext(trimmed string, currentIndent int, ctx *lineContext) {
 	// Check if we've exited the env block
@@ -228,24 +379,64 @@ func isBlockScalarIndicator(content string) bool {
 	return false
 }
 
-// checkForInjection checks if a line contains dangerous GitHub context expressions.
-func (l *InjectionLinter) checkForInjection(file string, lineNum int, line string) *Issue {
-	// First check if line contains any expression
-	if !strings.Contains(line, "${{") {
+// checkForInjection checks if the content of a line contains dangerous GitHub context expressions.
+func (l *InjectionLinter) checkForInjection(file string, lineNum int, line, content string) *Issue {
+	expr, column := dangerousExpression(line, content)
+	if expr == "" {
+		return nil
+	}
+	message := fmt.Sprintf(
+		"Potential shell injection: %s in run command. Use an environment variable instead",
+		expr,
+	)
+	return newRuleIssueAt(file, lineNum, column, ruleInjectionRun, message)
+}
+
+// checkScriptForInjection checks the content of a github-script script line for dangerous
+// GitHub context expressions, which are interpolated into the JavaScript source.
+func (l *InjectionLinter) checkScriptForInjection(file string, lineNum int, line, content string) *Issue {
+	expr, column := dangerousExpression(line, content)
+	if expr == "" {
 		return nil
 	}
+	message := fmt.Sprintf(
+		"Potential script injection: %s in github-script script. Pass it through env and read process.env instead",
+		expr,
+	)
+	return newRuleIssueAt(file, lineNum, column, ruleInjectionScript, message)
+}
+
+// checkInputForInjection checks the content of a with: inputs line for dangerous GitHub context
+// expressions, which are passed as-is to an action that may use them unsafely.
+func (l *InjectionLinter) checkInputForInjection(file string, lineNum int, line, content string) *Issue {
+	expr, column := dangerousExpression(line, content)
+	if expr == "" {
+		return nil
+	}
+	message := fmt.Sprintf(
+		"Potential injection: %s passed to action input. Check that the action handles untrusted input safely",
+		expr,
+	)
+	return newRuleIssueAt(file, lineNum, column, ruleInjectionInput, message)
+}
+
+// dangerousExpression returns the first dangerous GitHub context expression in content,
+// a part of line taken from its end, and the expression's 1-based column in line.
+// Returns an empty string if there is none.
+func dangerousExpression(line, content string) (string, int) {
+	// First check if content contains any expression
+	if !strings.Contains(content, "${{") {
+		return "", 0
+	}
+
+	offset := max(strings.LastIndex(line, content), 0)
 
 	// Check against each dangerous pattern
 	for _, pattern := range dangerousPatterns {
-		if matches := pattern.FindStringSubmatch(line); len(matches) > 0 {
-			expr := matches[0]
-			message := fmt.Sprintf(
-				"Potential shell injection: %s in run command. Use an environment variable instead",
-				expr,
-			)
-			return newIssue(file, lineNum, message)
+		if loc := pattern.FindStringIndex(content); loc != nil {
+			return content[loc[0]:loc[1]], offset + loc[0] + 1
 		}
 	}
 
-	return nil
+	return "", 0
 }
@@ -1,8 +1,12 @@
 package linter
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
@@ -234,7 +238,7 @@ jobs:
 				RawBytes: []byte(tt.content),
 			}
 
-			linter := NewInjectionLinter()
+			linter := NewInjectionLinter(nil)
 			issues, err := linter.LintWorkflow(wf)
 			if err != nil {
 				t.Fatalf("LintWorkflow() error = %v", err)
@@ -280,8 +284,220 @@ func containsSubstring(s, substr string) bool {
 	return false
 }
 
+func TestInjectionLinter_GitHubScript(t *testing.T) {
+	content := `name: Test
+on: issues
+jobs:
+  triage:
+    runs-on: ubuntu-latest
+    steps:
+      - name: Label
+        uses: actions/github-script@v7
+        with:
+          script: |
+            const title = "${{ github.event.issue.title }}";
+            console.log(title);
+      - name: Inline
+        with:
+          script: console.log("${{ github.event.issue.body }}")
+        uses: actions/github-script@v7
+      - name: Safe
+        uses: actions/github-script@v7
+        env:
+          TITLE: ${{ github.event.issue.title }}
+        with:
+          script: |
+            console.log(process.env.TITLE);
+      - name: Other action
+        uses: some/action@v1
+        with:
+          script: ${{ github.event.issue.title }}
+      - name: Run
+        run: echo "${{ github.event.comment.body }}"
+`
+	wf, err := workflow.ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	issues, err := NewInjectionLinter(nil).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+	}
+	expected := []string{
+		"11: Potential script injection: ${{ github.event.issue.title }} in github-script script. " +
+			"Pass it through env and read process.env instead",
+		"15: Potential script injection: ${{ github.event.issue.body }} in github-script script. " +
+			"Pass it through env and read process.env instead",
+		"29: Potential shell injection: ${{ github.event.comment.body }} in run command. " +
+			"Use an environment variable instead",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("LintWorkflow() issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestInjectionLinter_WithInputs(t *testing.T) {
+	content := `name: Test
+on: pull_request_target
+jobs:
+  comment:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: some/comment-action@v1
+        with:
+          title: ${{ github.event.pull_request.title }}
+          body: |
+            Branch: ${{ github.head_ref }}
+          number: ${{ github.event.pull_request.number }}
+      - name: Script
+        uses: actions/github-script@v7
+        with:
+          script: console.log("${{ github.event.pull_request.body }}")
+          github-token: ${{ github.event.comment.body }}
+      - name: Env
+        env:
+          TITLE: ${{ github.event.pull_request.title }}
+        run: echo "$TITLE"
+  call:
+    uses: ./.github/workflows/reusable.yml
+    with: {title: "${{ github.event.pull_request.title }}"}
+`
+	wf, err := workflow.ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		settings *config.InjectionSettings
+		expected []string
+	}{
+		{
+			name: "disabled by default",
+			expected: []string{
+				"16: ${{ github.event.pull_request.body }}",
+			},
+		},
+		{
+			name:     "enabled",
+			settings: &config.InjectionSettings{CheckWithInputs: true},
+			expected: []string{
+				"9: ${{ github.event.pull_request.title }}",
+				"11: ${{ github.head_ref }}",
+				"16: ${{ github.event.pull_request.body }}",
+				"17: ${{ github.event.comment.body }}",
+				"24: ${{ github.event.pull_request.title }}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := NewInjectionLinter(tt.settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var got []string
+			for _, issue := range issues {
+				expr := issue.Message[strings.Index(issue.Message, "${{") : strings.Index(issue.Message, "}}")+2]
+				got = append(got, fmt.Sprintf("%d: %s", issue.Line, expr))
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("LintWorkflow() issues = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	issues, err := NewInjectionLinter(&config.InjectionSettings{CheckWithInputs: true}).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+	want := "Potential injection: ${{ github.event.pull_request.title }} passed to action input. " +
+		"Check that the action handles untrusted input safely"
+	if issues[0].Message != want {
+		t.Errorf("Message = %q, want %q", issues[0].Message, want)
+	}
+}
+
+func TestInjectionLinter_PullRequestHeadCheckout(t *testing.T) {
+	steps := `
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - uses: actions/checkout@v4
+        with:
+          repository: ${{ github.event.pull_request.head.repo.full_name }}
+          ref: ${{ github.head_ref }}
+      - uses: actions/checkout@v4
+        with:
+          ref: refs/pull/${{ github.event.pull_request.number }}/merge
+      - uses: actions/checkout@v4
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.base.sha }}
+      - uses: some/action@v1
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+`
+	message := "Checkout of the pull request's head in a pull_request_target workflow runs untrusted code " +
+		"with the repository's secrets and a write token"
+
+	tests := []struct {
+		name     string
+		on       string
+		expected []string
+	}{
+		{
+			name: "pull_request_target",
+			on:   "on: pull_request_target",
+			expected: []string{
+				"6: " + message,
+				"9: " + message,
+				"13: " + message,
+			},
+		},
+		{
+			name: "pull_request",
+			on:   "on: pull_request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := workflow.ParseWorkflow("test.yml", []byte(tt.on+steps))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			issues, err := NewInjectionLinter(nil).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var got []string
+			for _, issue := range issues {
+				got = append(got, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("LintWorkflow() issues = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestInjectionLinter_Fix(t *testing.T) {
-	linter := NewInjectionLinter()
+	linter := NewInjectionLinter(nil)
 	wf := &workflow.Workflow{
 		File:     "test.yml",
 		RawBytes: []byte("name: Test\n"),
@@ -319,6 +535,30 @@ func TestExtractRunContent(t *testing.T) {
 	}
 }
 
+func TestDangerousExpression(t *testing.T) {
+	const line = `        run: echo "${{ github.event.issue.title }}"  `
+	tests := []struct {
+		content    string
+		wantExpr   string
+		wantColumn int
+	}{
+		{line, "${{ github.event.issue.title }}", 20},
+		{`echo "${{ github.event.issue.title }}"`, "${{ github.event.issue.title }}", 20},
+		{`echo "${{ github.sha }}"`, "", 0},
+		{`echo hello`, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			expr, column := dangerousExpression(line, tt.content)
+			if expr != tt.wantExpr || column != tt.wantColumn {
+				t.Errorf("dangerousExpression(%q) = (%q, %d), want (%q, %d)",
+					tt.content, expr, column, tt.wantExpr, tt.wantColumn)
+			}
+		})
+	}
+}
+
 func TestIsRunCommand(t *testing.T) {
 	tests := []struct {
 		line string
@@ -8,7 +8,18 @@ type Linter interface {
 	// LintWorkflow checks a single workflow and returns issues found.
 	LintWorkflow(wf *workflow.Workflow) ([]*Issue, error)
 
-	// FixWorkflow attempts to fix issues in a single workflow.
+	// FixWorkflow attempts to fix issues in a single workflow by updating its RawBytes
+	// in memory; the orchestrator decides whether to write the result to disk.
 	// For linters that don't support fixing, this should be a no-op (return nil).
 	FixWorkflow(wf *workflow.Workflow) error
 }
+
+// WorkflowSetLinter is an optional interface for linters that need to analyze
+// all workflows together (e.g., consistency checks across files).
+// It is invoked once per run after the per-workflow checks.
+type WorkflowSetLinter interface {
+	// LintWorkflows checks the full set of workflows and returns issues found.
+	// Each issue must set FullPath to the path of its workflow, since workflows in
+	// different directories can share a file name.
+	LintWorkflows(workflows []*workflow.Workflow) ([]*Issue, error)
+}
@@ -1,14 +1,23 @@
 package linter
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
 
 // Issue represents a linting problem found in a workflow file.
-// It contains the file name, line number, linter name, and a descriptive message about the issue.
+// It contains the file name, position, linter name, rule ID, severity, and a descriptive message
+// about the issue.
 type Issue struct {
-	File    string // Name of the workflow file with the issue
-	Line    int    // Line number where the issue was found (0 if not applicable)
-	Linter  string // Name of the linter that found this issue
-	Message string // Description of the linting issue
+	File     string `json:"file"`              // Name of the workflow file with the issue
+	FullPath string `json:"path,omitempty"`    // Path of the workflow file as loaded (set by WorkflowLinter)
+	Line     int    `json:"line"`              // Line number where the issue was found (0 if not applicable)
+	Column   int    `json:"column,omitempty"`  // 1-based byte column of the match on the line (0 if unknown)
+	Linter   string `json:"linter"`            // Name of the linter that found this issue
+	RuleID   string `json:"rule_id,omitempty"` // Stable ID of the check, e.g., "format/trailing-whitespace"
+	Severity string `json:"severity"`          // Severity level (error, warning, or info)
+	Message  string `json:"message"`           // Description of the linting issue
 }
 
 // newIssue creates an Issue if message is non-empty, otherwise returns nil.
@@ -23,15 +32,63 @@ func newIssue(file string, line int, message string) *Issue {
 	}
 }
 
-// Key returns a unique identifier for this issue.
+// newRuleIssue creates an Issue for the rule with the given ID if message is non-empty,
+// otherwise returns nil. Rule IDs have the form "linter/check".
+func newRuleIssue(file string, line int, ruleID, message string) *Issue {
+	issue := newIssue(file, line, message)
+	if issue != nil {
+		issue.RuleID = ruleID
+	}
+	return issue
+}
+
+// newRuleIssueAt creates an Issue for a rule at a line and column if message is non-empty,
+// otherwise returns nil.
+func newRuleIssueAt(file string, line, column int, ruleID, message string) *Issue {
+	issue := newRuleIssue(file, line, ruleID, message)
+	if issue != nil {
+		issue.Column = column
+	}
+	return issue
+}
+
+// Key returns a unique identifier for this issue. It uses the relative path rather than
+// the file name, so files with the same name in different directories are kept apart.
 func (i *Issue) Key() string {
-	return fmt.Sprintf("%s:%d:%s:%s", i.File, i.Line, i.Linter, i.Message)
+	return fmt.Sprintf("%s:%d:%s:%s", filepath.ToSlash(i.Path()), i.Line, i.Linter, i.Message)
+}
+
+// BaselineKey returns an identifier for this issue that doesn't depend on its line number,
+// so it stays stable when unrelated lines are added or removed.
+func (i *Issue) BaselineKey() string {
+	return fmt.Sprintf("%s:%s:%s", filepath.ToSlash(i.Path()), i.Linter, i.Message)
+}
+
+// Path returns the path of the workflow file relative to the current directory,
+// or the file name if the full path is unknown.
+func (i *Issue) Path() string {
+	if i.FullPath == "" {
+		return i.File
+	}
+	if !filepath.IsAbs(i.FullPath) {
+		return filepath.Clean(i.FullPath)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, i.FullPath); err == nil {
+			return rel
+		}
+	}
+	return i.FullPath
 }
 
 // String implements fmt.Stringer for Issue.
 func (i *Issue) String() string {
+	path := i.Path()
+	if i.Line > 0 && i.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d: (%s) %s", path, i.Line, i.Column, i.Linter, i.Message)
+	}
 	if i.Line > 0 {
-		return fmt.Sprintf("%s:%d: (%s) %s", i.File, i.Line, i.Linter, i.Message)
+		return fmt.Sprintf("%s:%d: (%s) %s", path, i.Line, i.Linter, i.Message)
 	}
-	return fmt.Sprintf("%s: (%s) %s", i.File, i.Linter, i.Message)
+	return fmt.Sprintf("%s: (%s) %s", path, i.Linter, i.Message)
 }
@@ -1,6 +1,10 @@
 package linter
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func Test_newIssue(t *testing.T) {
 	tests := []struct {
@@ -52,6 +56,23 @@ func Test_newIssue(t *testing.T) {
 	}
 }
 
+func Test_newRuleIssue(t *testing.T) {
+	if issue := newRuleIssue("test.yml", 10, "format/line-length", ""); issue != nil {
+		t.Errorf("newRuleIssue() = %v, want nil", issue)
+	}
+
+	issue := newRuleIssueAt("test.yml", 10, 5, "format/line-length", "some issue")
+	if issue == nil {
+		t.Fatal("newRuleIssueAt() = nil, want non-nil")
+	}
+	if issue.RuleID != "format/line-length" {
+		t.Errorf("issue.RuleID = %q, want %q", issue.RuleID, "format/line-length")
+	}
+	if issue.Column != 5 {
+		t.Errorf("issue.Column = %d, want 5", issue.Column)
+	}
+}
+
 func TestIssue_Key(t *testing.T) {
 	issue := &Issue{
 		File:    "test.yml",
@@ -65,6 +86,40 @@ func TestIssue_Key(t *testing.T) {
 	}
 }
 
+func TestIssue_BaselineKey(t *testing.T) {
+	issue := &Issue{
+		File:    "test.yml",
+		Line:    10,
+		Linter:  "style",
+		Message: "some issue",
+	}
+	want := "test.yml:style:some issue"
+	if got := issue.BaselineKey(); got != want {
+		t.Errorf("BaselineKey() = %q, want %q", got, want)
+	}
+
+	moved := *issue
+	moved.Line = 42
+	if moved.BaselineKey() != issue.BaselineKey() {
+		t.Errorf("BaselineKey() changed with line number: %q != %q", moved.BaselineKey(), issue.BaselineKey())
+	}
+}
+
+func TestIssue_Keys_SameFileNames(t *testing.T) {
+	api := &Issue{File: "action.yml", FullPath: "api/action.yml", Linter: "injection", Message: "some issue"}
+	web := &Issue{File: "action.yml", FullPath: "web/action.yml", Linter: "injection", Message: "some issue"}
+
+	if api.Key() == web.Key() {
+		t.Errorf("Key() = %q for files in different directories", api.Key())
+	}
+	if api.BaselineKey() == web.BaselineKey() {
+		t.Errorf("BaselineKey() = %q for files in different directories", api.BaselineKey())
+	}
+	if want := "api/action.yml:injection:some issue"; api.BaselineKey() != want {
+		t.Errorf("BaselineKey() = %q, want %q", api.BaselineKey(), want)
+	}
+}
+
 func TestIssue_String(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -81,6 +136,17 @@ func TestIssue_String(t *testing.T) {
 			},
 			want: "test.yml:10: (style) some issue",
 		},
+		{
+			name: "with line and column",
+			issue: &Issue{
+				File:    "test.yml",
+				Line:    10,
+				Column:  7,
+				Linter:  "secrets",
+				Message: "some issue",
+			},
+			want: "test.yml:10:7: (secrets) some issue",
+		},
 		{
 			name: "without line number",
 			issue: &Issue{
@@ -91,6 +157,17 @@ func TestIssue_String(t *testing.T) {
 			},
 			want: "test.yml: (style) some issue",
 		},
+		{
+			name: "with full path",
+			issue: &Issue{
+				File:     "ci.yml",
+				FullPath: "./services/api/.github/workflows/ci.yml",
+				Line:     3,
+				Linter:   "style",
+				Message:  "some issue",
+			},
+			want: "services/api/.github/workflows/ci.yml:3: (style) some issue",
+		},
 	}
 
 	for _, tt := range tests {
@@ -101,3 +178,31 @@ func TestIssue_String(t *testing.T) {
 		})
 	}
 }
+
+func TestIssue_Path(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  string
+	}{
+		{"file name only", &Issue{File: "ci.yml"}, "ci.yml"},
+		{"relative path", &Issue{File: "ci.yml", FullPath: ".github/workflows/ci.yml"}, ".github/workflows/ci.yml"},
+		{"absolute path", &Issue{File: "ci.yml", FullPath: filepath.Join(wd, "workflows", "ci.yml")},
+			filepath.Join("workflows", "ci.yml")},
+		{"absolute path outside", &Issue{File: "ci.yml", FullPath: filepath.Join(filepath.Dir(wd), "ci.yml")},
+			filepath.Join("..", "ci.yml")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.issue.Path(); got != tt.want {
+				t.Errorf("Path() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
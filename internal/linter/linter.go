@@ -1,8 +1,15 @@
 package linter
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/reugn/github-ci/internal/actions"
 	"github.com/reugn/github-ci/internal/config"
@@ -11,13 +18,33 @@ import (
 
 // WorkflowLinter orchestrates multiple individual linters based on configuration.
 type WorkflowLinter struct {
-	ctx        context.Context      // Context for timeout/cancellation
-	workflows  []*workflow.Workflow // Workflows to analyze
-	configFile string               // Path to configuration file
-	cfg        *config.Config       // Loaded configuration
-	linters    map[string]Linter    // Map of linter name to linter implementation
+	ctx        context.Context        // Context for timeout/cancellation
+	workflows  []*workflow.Workflow   // Workflows to analyze
+	configFile string                 // Path to configuration file
+	cfg        *config.Config         // Loaded configuration
+	linters    map[string]Linter      // Map of linter name to linter implementation
+	baseline   *Baseline              // Known issues to suppress from Lint results
+	parseErrs  []*workflow.ParseError // Workflow files that failed to parse
+	timings    []WorkflowTiming       // Time taken per workflow by the last Lint
 }
 
+// WorkflowTiming is the time the enabled linters took to check a single workflow.
+type WorkflowTiming struct {
+	File     string
+	Duration time.Duration
+}
+
+// SyntaxLinter is the linter name of issues for workflow files that aren't valid YAML,
+// or whose disable and enable markers name unknown linters. It isn't a configurable
+// linter: these issues are always reported, as errors.
+const SyntaxLinter = "syntax"
+
+// Rules reported by the syntax linter.
+const (
+	ruleSyntaxInvalidYAML   = SyntaxLinter + "/invalid-yaml"
+	ruleSyntaxUnknownLinter = SyntaxLinter + "/unknown-linter"
+)
+
 // New creates a new WorkflowLinter instance for the specified workflows directory.
 // The directory should contain .yml or .yaml workflow files.
 func New(ctx context.Context, workflowsDir string) *WorkflowLinter {
@@ -51,16 +78,51 @@ func NewWithWorkflows(ctx context.Context, workflows []*workflow.Workflow, confi
 	}
 }
 
+// NewWithConfig creates a new WorkflowLinter instance with the provided workflows
+// and an already loaded configuration. If cfg is nil, defaults are used.
+func NewWithConfig(ctx context.Context, workflows []*workflow.Workflow, cfg *config.Config) *WorkflowLinter {
+	if cfg == nil {
+		cfg = config.NewDefaultConfig()
+	}
+	return &WorkflowLinter{
+		ctx:       ctx,
+		workflows: workflows,
+		cfg:       cfg,
+		linters:   createLinters(ctx, cfg),
+	}
+}
+
+// NewWithCache creates a new WorkflowLinter like NewWithConfig, with the versions linter
+// looking up versions through cache. Sharing a cache across linters avoids repeating
+// GitHub API calls, e.g., when re-linting on file changes.
+func NewWithCache(ctx context.Context, workflows []*workflow.Workflow, cfg *config.Config,
+	cache *actions.Cache) *WorkflowLinter {
+	l := NewWithConfig(ctx, workflows, cfg)
+	if _, ok := l.linters[config.LinterVersions]; ok && cache != nil && !l.cfg.IsOffline() {
+		l.linters[config.LinterVersions] = newConfiguredVersionsLinter(ctx, l.cfg, cache)
+	}
+	return l
+}
+
 // createLinters creates a map of enabled linters with their settings from config.
 // Only linters that are enabled according to the configuration are created.
-// If cfg is nil, all linters are created (default behavior).
+// In offline mode, linters that need network access are replaced by their offline
+// checks, if they have any, or skipped.
+// If cfg is nil, all linters except opt-in ones are created (default behavior).
 func createLinters(ctx context.Context, cfg *config.Config) map[string]Linter {
 	linters := make(map[string]Linter)
 
 	for name, factory := range linterFactories {
-		if cfg == nil || cfg.IsLinterEnabled(name) {
-			linters[name] = factory(ctx, cfg)
+		if !cfg.IsLinterEnabled(name) {
+			continue
 		}
+		if cfg.IsOffline() && RequiresNetwork(name) {
+			if offline := newOfflineLinter(name, cfg); offline != nil {
+				linters[name] = offline
+			}
+			continue
+		}
+		linters[name] = factory(ctx, cfg)
 	}
 
 	return linters
@@ -79,58 +141,236 @@ func (l *WorkflowLinter) Lint() ([]*Issue, error) {
 		l.linters = createLinters(l.ctx, l.cfg)
 	}
 
-	var allIssues []*Issue
+	allIssues, err := l.lintWorkflows()
+	if err != nil {
+		return nil, err
+	}
 
-	// Iterate over workflows once, running all enabled linters on each
-	for _, wf := range l.workflows {
-		for name, linter := range l.linters {
-			if !l.cfg.IsLinterEnabled(name) {
-				continue
-			}
+	// Run cross-workflow checks once over the full set
+	for name, linter := range l.linters {
+		setLinter, ok := linter.(WorkflowSetLinter)
+		if !ok || !l.isReporting(name) {
+			continue
+		}
 
-			issues, err := linter.LintWorkflow(wf)
-			if err != nil {
-				return nil, fmt.Errorf("linter %s failed on %s: %w", name, wf.File, err)
-			}
+		workflows := slices.DeleteFunc(slices.Clone(l.workflows), func(wf *workflow.Workflow) bool {
+			return !appliesTo(name, wf)
+		})
+		issues, err := setLinter.LintWorkflows(workflows)
+		if err != nil {
+			return nil, fmt.Errorf("linter %s failed: %w", name, err)
+		}
+
+		for _, issue := range issues {
+			issue.Linter = name
+			issue.Severity = l.cfg.IssueSeverity(name, issue.Severity)
+		}
+		allIssues = append(allIssues, issues...)
+	}
+
+	allIssues = slices.DeleteFunc(allIssues, func(issue *Issue) bool {
+		return l.cfg.IsRuleDisabled(issue.RuleID)
+	})
+	allIssues = append(allIssues, l.parseIssues()...)
+	allIssues = append(allIssues, unknownLinterIssues(l.workflows)...)
+	sortIssues(allIssues)
+	allIssues = filterIgnored(l.workflows, allIssues)
+	return l.baseline.Filter(allIssues), nil
+}
+
+// lintWorkflows runs all enabled linters on each workflow, using a pool of up to
+// the configured number of workers. Each workflow is linted by a single worker,
+// since its cached YAML node is not safe for concurrent use.
+func (l *WorkflowLinter) lintWorkflows() ([]*Issue, error) {
+	results := make([][]*Issue, len(l.workflows))
+	errs := make([]error, len(l.workflows))
+	timings := make([]WorkflowTiming, len(l.workflows))
 
-			// Set the linter name on each issue
-			for _, issue := range issues {
-				issue.Linter = name
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(l.cfg.GetConcurrency(), len(l.workflows)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				start := time.Now()
+				results[i], errs[i] = l.lintWorkflow(l.workflows[i])
+				timings[i] = WorkflowTiming{File: l.workflows[i].File, Duration: time.Since(start)}
 			}
-			allIssues = append(allIssues, issues...)
+		}()
+	}
+	for i := range l.workflows {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	l.timings = timings
+
+	// Report the error of the first failing workflow, regardless of completion order
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return slices.Concat(results...), nil
+}
+
+// lintWorkflow runs all enabled linters on a single workflow.
+func (l *WorkflowLinter) lintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	var allIssues []*Issue
+
+	for _, name := range slices.Sorted(maps.Keys(l.linters)) {
+		if !l.isReporting(name) || !appliesTo(name, wf) {
+			continue
 		}
+
+		issues, err := l.linters[name].LintWorkflow(wf)
+		if err != nil {
+			return nil, fmt.Errorf("linter %s failed on %s: %w", name, wf.File, err)
+		}
+
+		// Set the linter name, severity, and workflow path on each issue
+		for _, issue := range issues {
+			issue.Linter = name
+			issue.Severity = l.cfg.IssueSeverity(name, issue.Severity)
+			issue.FullPath = wf.File
+		}
+		allIssues = append(allIssues, issues...)
 	}
 
 	return allIssues, nil
 }
 
-// Fix runs the Fix method on all enabled linters for all workflows.
+// sortIssues orders issues by path, line, linter, and message, so the output is
+// deterministic however workflows were scheduled.
+func sortIssues(issues []*Issue) {
+	slices.SortStableFunc(issues, compareIssues)
+}
+
+// compareIssues compares issues by path, then line, then linter, then message.
+// Paths are compared as loaded rather than with Path, which looks up the working
+// directory on every call.
+func compareIssues(a, b *Issue) int {
+	return cmp.Or(
+		cmp.Compare(cmp.Or(a.FullPath, a.File), cmp.Or(b.FullPath, b.File)),
+		cmp.Compare(a.Line, b.Line),
+		cmp.Compare(a.Linter, b.Linter),
+		cmp.Compare(a.Message, b.Message),
+	)
+}
+
+// Timings returns the time taken to lint each workflow by the last Lint, in the
+// order of the workflows.
+func (l *WorkflowLinter) Timings() []WorkflowTiming {
+	return l.timings
+}
+
+// EnabledLinters returns the sorted names of the linters whose issues are reported.
+func (l *WorkflowLinter) EnabledLinters() []string {
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(l.linters)) {
+		if l.isReporting(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SetParseErrors sets the workflow files that failed to parse, so that Lint reports
+// each as an issue alongside the issues of the workflows that loaded.
+func (l *WorkflowLinter) SetParseErrors(errs []*workflow.ParseError) {
+	l.parseErrs = errs
+}
+
+// parseIssues returns an issue for each workflow file that failed to parse.
+func (l *WorkflowLinter) parseIssues() []*Issue {
+	issues := make([]*Issue, 0, len(l.parseErrs))
+	for _, err := range l.parseErrs {
+		issue := newRuleIssue(filepath.Base(err.File), err.Line, ruleSyntaxInvalidYAML, "Invalid YAML: "+err.Reason)
+		issue.FullPath = err.File
+		issue.Linter = SyntaxLinter
+		issue.Severity = config.SeverityError
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// SetBaseline sets known issues to suppress from subsequent Lint results.
+func (l *WorkflowLinter) SetBaseline(baseline *Baseline) {
+	l.baseline = baseline
+}
+
+// isReporting returns true if the linter is enabled and its issues should be reported.
+// Silent fixers are skipped during linting but still run during Fix.
+func (l *WorkflowLinter) isReporting(name string) bool {
+	return l.cfg.IsLinterEnabled(name) && !l.cfg.IsSilentFixer(name)
+}
+
+// SupportsAutoFix returns true if the linter supports automatic fixing with the
+// loaded configuration.
+func (l *WorkflowLinter) SupportsAutoFix(linterName string) bool {
+	return SupportsAutoFix(linterName, l.cfg)
+}
+
+// FileFix describes a workflow whose content was changed by fixes.
+// The fixed content is the workflow's current RawBytes.
+type FileFix struct {
+	Workflow *workflow.Workflow
+	Original []byte // Content before the fixes were applied
+}
+
+// Fix runs the Fix method on all enabled linters for all workflows
+// and writes the changed workflows to disk.
 func (l *WorkflowLinter) Fix() error {
+	fixes, err := l.ApplyFixes()
+	if err != nil {
+		return err
+	}
+
+	for _, fix := range fixes {
+		if err := fix.Workflow.Save(); err != nil {
+			return fmt.Errorf("failed to save %s: %w", fix.Workflow.File, err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyFixes runs the Fix method on all enabled linters for all workflows,
+// updating them in memory only. It returns the workflows whose content changed.
+func (l *WorkflowLinter) ApplyFixes() ([]*FileFix, error) {
 	// Initialize config if not already loaded
 	if l.cfg == nil {
 		var err error
 		l.cfg, err = config.LoadConfig(l.configFile)
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return nil, fmt.Errorf("failed to load config: %w", err)
 		}
 		// Recreate linters with the loaded config to get updated settings
 		l.linters = createLinters(l.ctx, l.cfg)
 	}
 
+	var fixes []*FileFix
+
 	// Iterate over workflows once, running all enabled linter fixes on each
 	for _, wf := range l.workflows {
+		original := wf.RawBytes
 		for name, linter := range l.linters {
-			if !l.cfg.IsLinterEnabled(name) {
+			if !l.cfg.IsLinterEnabled(name) || !appliesTo(name, wf) {
 				continue
 			}
 
 			if err := linter.FixWorkflow(wf); err != nil {
-				return fmt.Errorf("linter %s fix failed on %s: %w", name, wf.File, err)
+				return nil, fmt.Errorf("linter %s fix failed on %s: %w", name, wf.File, err)
 			}
 		}
+
+		if !bytes.Equal(original, wf.RawBytes) {
+			fixes = append(fixes, &FileFix{Workflow: wf, Original: original})
+		}
 	}
 
-	return nil
+	return fixes, nil
 }
 
 // GetCacheStats returns cache statistics from the versions linter if it's enabled.
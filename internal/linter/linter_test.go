@@ -2,10 +2,14 @@ package linter
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/reugn/github-ci/internal/actions"
 	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/testutil"
 	"github.com/reugn/github-ci/internal/workflow"
@@ -108,6 +112,69 @@ jobs:
 	}
 }
 
+func TestWorkflowLinter_Lint_DisabledRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowPath := testutil.CreateWorkflow(t, tmpDir, "test.yml",
+		"name: Test  \non: push\n# "+strings.Repeat("x", 130)+"\njobs: {}\n")
+	configPath := testutil.CreateConfig(t, tmpDir, `linters:
+  default: none
+  enable:
+    - format
+  settings:
+    format:
+      disabled-rules:
+        - format/line-length
+`)
+
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	issues, err := NewWithWorkflows(context.Background(), []*workflow.Workflow{wf}, configPath).Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	// The disabled rule is filtered out, while the linter's other rules still report
+	if len(issues) != 1 || issues[0].RuleID != ruleFormatTrailingWhitespace {
+		t.Errorf("Lint() = %v, want only a trailing whitespace issue", issues)
+	}
+}
+
+func TestWorkflowLinter_Lint_ParseErrors(t *testing.T) {
+	_, err := workflow.ParseWorkflow(".github/workflows/broken.yml", []byte("on: push\njobs: [build]\n"))
+	parseErrs, onlyParse := workflow.ParseErrors(err)
+	if !onlyParse || len(parseErrs) != 1 {
+		t.Fatalf("ParseWorkflow() error = %v, want a parse error", err)
+	}
+
+	cfg := config.NewDefaultConfig()
+	cfg.SetOffline()
+	l := NewWithConfig(context.Background(), nil, cfg)
+	l.SetParseErrors(parseErrs)
+
+	issues, err := l.Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Lint() returned %d issues, want 1", len(issues))
+	}
+
+	want := Issue{
+		File:     "broken.yml",
+		FullPath: ".github/workflows/broken.yml",
+		Line:     2,
+		Linter:   SyntaxLinter,
+		RuleID:   "syntax/invalid-yaml",
+		Severity: config.SeverityError,
+		Message:  "Invalid YAML: cannot unmarshal !!seq into map[string]interface {}",
+	}
+	if *issues[0] != want {
+		t.Errorf("Lint() issue = %+v, want %+v", *issues[0], want)
+	}
+}
+
 func TestWorkflowLinter_LintCleanWorkflow(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -280,7 +347,7 @@ jobs:
 			name: "versions linter disabled",
 			linter: &WorkflowLinter{
 				linters: map[string]Linter{
-					config.LinterPermissions: NewPermissionsLinter(),
+					config.LinterPermissions: NewPermissionsLinter(nil),
 				},
 			},
 		},
@@ -297,21 +364,29 @@ jobs:
 }
 
 func TestSupportsAutoFix(t *testing.T) {
+	sortKeys := &config.Config{Linters: &config.LinterConfig{Settings: &config.LinterSettings{
+		Style: &config.StyleSettings{FixStepKeyOrder: true},
+	}}}
+
 	tests := []struct {
+		name   string
 		linter string
+		cfg    *config.Config
 		want   bool
 	}{
-		{config.LinterVersions, true},
-		{config.LinterFormat, true},
-		{config.LinterPermissions, false},
-		{config.LinterSecrets, false},
-		{config.LinterInjection, false},
-		{"unknown", false},
+		{"versions", config.LinterVersions, nil, true},
+		{"format", config.LinterFormat, nil, true},
+		{"permissions", config.LinterPermissions, nil, true},
+		{"secrets", config.LinterSecrets, nil, false},
+		{"injection", config.LinterInjection, nil, false},
+		{"style by default", config.LinterStyle, nil, false},
+		{"style with fix-step-key-order", config.LinterStyle, sortKeys, true},
+		{"unknown", "unknown", nil, false},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.linter, func(t *testing.T) {
-			if got := SupportsAutoFix(tt.linter); got != tt.want {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SupportsAutoFix(tt.linter, tt.cfg); got != tt.want {
 				t.Errorf("SupportsAutoFix(%q) = %v, want %v", tt.linter, got, tt.want)
 			}
 		})
@@ -359,6 +434,459 @@ jobs:
 	}
 }
 
+func TestWorkflowLinter_CompositeAction(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configPath := testutil.CreateConfig(t, tmpDir, `
+linters:
+  default: none
+  enable:
+    - injection
+    - permissions
+    - style
+    - format
+`)
+
+	content := `name: Build
+runs:
+  using: composite
+  steps:
+    - run: echo "${{ github.event.pull_request.title }}"
+      shell: bash
+`
+	actionPath := testutil.CreateWorkflow(t, tmpDir, "action.yml", content)
+	wf, err := workflow.LoadWorkflow(actionPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	linter := NewWithWorkflows(context.Background(), []*workflow.Workflow{wf}, configPath)
+	issues, err := linter.Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	// Workflow-only linters such as permissions and style skip composite actions
+	if len(issues) != 1 || issues[0].Linter != config.LinterInjection {
+		t.Errorf("Lint() = %v, want a single injection issue", issues)
+	}
+
+	fixes, err := linter.ApplyFixes()
+	if err != nil {
+		t.Fatalf("ApplyFixes() error = %v", err)
+	}
+	if len(fixes) != 0 {
+		t.Errorf("ApplyFixes() changed the action:\n%s", wf.RawBytes)
+	}
+}
+
+func TestWorkflowLinter_EnabledLintersAndTimings(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := testutil.CreateConfig(t, tmpDir, `
+run:
+  silent-fixers:
+    - format
+linters:
+  default: none
+  enable:
+    - style
+    - format
+    - permissions
+`)
+	ci := testutil.CreateWorkflow(t, tmpDir, "ci.yml", "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+	release := testutil.CreateWorkflow(t, tmpDir, "release.yml", "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n")
+
+	var workflows []*workflow.Workflow
+	for _, path := range []string{ci, release} {
+		wf, err := workflow.LoadWorkflow(path)
+		if err != nil {
+			t.Fatalf("LoadWorkflow() error = %v", err)
+		}
+		workflows = append(workflows, wf)
+	}
+
+	linter := NewWithWorkflows(context.Background(), workflows, configPath)
+
+	// Silent fixers run only during fixing, so they aren't reported as enabled
+	want := []string{config.LinterPermissions, config.LinterStyle}
+	if got := linter.EnabledLinters(); !slices.Equal(got, want) {
+		t.Errorf("EnabledLinters() = %v, want %v", got, want)
+	}
+
+	if len(linter.Timings()) != 0 {
+		t.Errorf("Timings() before Lint = %v, want none", linter.Timings())
+	}
+	if _, err := linter.Lint(); err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	timings := linter.Timings()
+	if len(timings) != 2 || timings[0].File != ci || timings[1].File != release {
+		t.Errorf("Timings() = %v, want one per workflow in order", timings)
+	}
+}
+
+func TestWorkflowLinter_SameFileNames(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	configPath := testutil.CreateConfig(t, tmpDir, "linters:\n  default: none\n  enable:\n    - permissions\n")
+	content := "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"
+	var workflows []*workflow.Workflow
+	for _, dir := range []string{"api", "web"} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			t.Fatalf("Mkdir() error = %v", err)
+		}
+		if dir == "web" {
+			content = "# github-ci:ignore-file\n" + content
+		}
+		wf, err := workflow.LoadWorkflow(testutil.CreateWorkflow(t, dir, "ci.yml", content))
+		if err != nil {
+			t.Fatalf("LoadWorkflow() error = %v", err)
+		}
+		workflows = append(workflows, wf)
+	}
+
+	issues, err := NewWithWorkflows(context.Background(), workflows, configPath).Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	// The ignore comment in web/ci.yml doesn't suppress the issue in api/ci.yml
+	if len(issues) != 1 {
+		t.Fatalf("Lint() returned %v, want 1 issue", issues)
+	}
+	want := filepath.Join("api", "ci.yml") + ":3: (permissions) Job 'build' is missing permissions configuration"
+	if got := issues[0].String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestWorkflowLinter_SameFileNames_WorkflowSet(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	configPath := testutil.CreateConfig(t, tmpDir,
+		"linters:\n  default: none\n  enable:\n    - style\n  settings:\n    style:\n      distinct-workflow-names: true\n")
+	content := "name: CI\non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"
+	var workflows []*workflow.Workflow
+	for _, dir := range []string{"api", "web"} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			t.Fatalf("Mkdir() error = %v", err)
+		}
+		source := content
+		if dir == "api" {
+			source = "# github-ci:ignore-file\n" + content
+		}
+		wf, err := workflow.LoadWorkflow(testutil.CreateWorkflow(t, dir, "ci.yml", source))
+		if err != nil {
+			t.Fatalf("LoadWorkflow() error = %v", err)
+		}
+		workflows = append(workflows, wf)
+	}
+
+	issues, err := NewWithWorkflows(context.Background(), workflows, configPath).Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	// The duplicate name is reported in web/ci.yml, not in the first ci.yml loaded,
+	// so the ignore comment in api/ci.yml doesn't suppress it
+	var got []string
+	for _, issue := range issues {
+		if issue.RuleID == ruleStyleDuplicateWorkflowName {
+			got = append(got, issue.String())
+		}
+	}
+	want := filepath.Join("web", "ci.yml") + ":1: (style) Workflow name 'CI' is already used by ci.yml"
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("duplicate name issues = %v, want [%s]", got, want)
+	}
+}
+
+func TestWorkflowLinter_SilentFixers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configPath := testutil.CreateConfig(t, tmpDir, `
+run:
+  silent-fixers:
+    - format
+linters:
+  default: none
+  enable:
+    - format
+    - permissions
+`)
+
+	workflowPath := testutil.CreateWorkflow(t, tmpDir, "test.yml", "name: Test   \n"+`on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+
+
+    steps:
+      - uses: actions/checkout@v3
+`)
+
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	linter := NewWithWorkflows(context.Background(), []*workflow.Workflow{wf}, configPath)
+	issues, err := linter.Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	// Only the permissions issue is reported; format issues are silent
+	if len(issues) != 1 || issues[0].Linter != config.LinterPermissions {
+		t.Fatalf("Lint() = %v, want only the permissions issue", issues)
+	}
+
+	// Format fixes still apply
+	if err := linter.Fix(); err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+
+	content, err := os.ReadFile(workflowPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(content), "Test   ") || strings.Contains(string(content), "\n\n\n") {
+		t.Errorf("Fix() did not apply format fixes:\n%s", content)
+	}
+}
+
+func TestWorkflowLinter_Severity(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configPath := testutil.CreateConfig(t, tmpDir, `
+linters:
+  default: none
+  enable:
+    - format
+    - permissions
+  settings:
+    severity:
+      format: info
+`)
+
+	workflowPath := testutil.CreateWorkflow(t, tmpDir, "test.yml", "name: Test   \n"+`on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`)
+
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	linter := NewWithWorkflows(context.Background(), []*workflow.Workflow{wf}, configPath)
+	issues, err := linter.Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	expected := map[string]string{
+		config.LinterFormat:      config.SeverityInfo,
+		config.LinterPermissions: config.SeverityError,
+	}
+	if len(issues) == 0 {
+		t.Fatal("Lint() returned no issues")
+	}
+	for _, issue := range issues {
+		if issue.Severity != expected[issue.Linter] {
+			t.Errorf("issue %s has severity %q, want %q", issue, issue.Severity, expected[issue.Linter])
+		}
+	}
+}
+
+func TestWorkflowLinter_Offline(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configPath := testutil.CreateConfig(t, tmpDir, `
+run:
+  offline: true
+linters:
+  default: none
+  enable:
+    - versions
+    - permissions
+`)
+
+	workflowPath := testutil.CreateWorkflow(t, tmpDir, "test.yml", `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+`)
+
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if skipped := SkippedOffline(cfg); !slices.Equal(skipped, []string{config.LinterVersions}) {
+		t.Errorf("SkippedOffline() = %v, want [%s]", skipped, config.LinterVersions)
+	}
+
+	linter := NewWithConfig(context.Background(), []*workflow.Workflow{wf}, cfg)
+	if _, ok := linter.linters[config.LinterVersions]; ok {
+		t.Error("versions linter should not be created in offline mode")
+	}
+
+	issues, err := linter.Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	var hasPermissions bool
+	for _, issue := range issues {
+		if issue.Linter == config.LinterVersions {
+			t.Errorf("unexpected versions issue in offline mode: %s", issue)
+		}
+		hasPermissions = hasPermissions || issue.Linter == config.LinterPermissions
+	}
+	if !hasPermissions {
+		t.Error("expected offline-capable linters to still report issues")
+	}
+}
+
+func TestWorkflowLinter_Offline_AllowedOwners(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configPath := testutil.CreateConfig(t, tmpDir, `
+run:
+  offline: true
+linters:
+  default: none
+  enable:
+    - versions
+  settings:
+    versions:
+      allowed-owners:
+        - actions
+`)
+
+	workflowPath := testutil.CreateWorkflow(t, tmpDir, "test.yml", `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: some-user/setup-tool@v1
+      - uses: docker://alpine:3.20
+`)
+
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	// The owner check needs no network, so it still runs; pinning checks are skipped
+	issues, err := NewWithCache(context.Background(), []*workflow.Workflow{wf}, cfg, actions.NewCache()).Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Lint() = %v, want 1 issue", issues)
+	}
+	issue := issues[0]
+	if issue.Linter != config.LinterVersions || issue.RuleID != ruleVersionsUntrustedOwner || issue.Line != 7 {
+		t.Errorf("Lint() = %s (%s), want the untrusted owner on line 7", issue, issue.RuleID)
+	}
+}
+
+func TestWorkflowLinter_Concurrency(t *testing.T) {
+	tmpDir := t.TempDir()
+	var workflows []*workflow.Workflow
+	for i := range 20 {
+		// Files are created in reverse order to check the sorting of issues
+		name := fmt.Sprintf("wf%02d.yml", 19-i)
+		path := testutil.CreateWorkflow(t, tmpDir, name, "on: push\njobs:\n  j1:\n    runs-on: ubuntu-latest  \n")
+		wf, err := workflow.LoadWorkflow(path)
+		if err != nil {
+			t.Fatalf("LoadWorkflow() error = %v", err)
+		}
+		workflows = append(workflows, wf)
+	}
+
+	for _, concurrency := range []int{1, 4, 32} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			cfg := &config.Config{Linters: &config.LinterConfig{
+				Default:  "none",
+				Enable:   []string{config.LinterPermissions, config.LinterFormat, config.LinterStyle},
+				Settings: &config.LinterSettings{Concurrency: concurrency},
+			}}
+
+			issues, err := NewWithConfig(context.Background(), workflows, cfg).Lint()
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+
+			// permissions and style (line 3), style (line 1), and format (line 4) per workflow
+			if len(issues) != 4*len(workflows) {
+				t.Fatalf("Lint() returned %d issues, want %d", len(issues), 4*len(workflows))
+			}
+			if !slices.IsSortedFunc(issues, compareIssues) {
+				t.Error("Lint() issues are not sorted")
+			}
+			if issues[0].File != "wf00.yml" {
+				t.Errorf("first issue file = %s, want wf00.yml", issues[0].File)
+			}
+		})
+	}
+}
+
+func TestWorkflowLinter_Lint_Deterministic(t *testing.T) {
+	tmpDir := t.TempDir()
+	// Several linters report on the same lines: the job and step lines have
+	// style issues, and trailing whitespace is a format issue on the same lines
+	workflowPath := testutil.CreateWorkflow(t, tmpDir, "test.yml", `on: push
+jobs:
+  b:  
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ github.event.issue.title }}  
+        env:
+          API_KEY: "abcdefghijklmnopqrstuvwxyz123456"
+`)
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+	cfg := &config.Config{Linters: &config.LinterConfig{Default: "all", Disable: []string{config.LinterVersions}}}
+
+	first, err := NewWithConfig(context.Background(), []*workflow.Workflow{wf}, cfg).Lint()
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !slices.IsSortedFunc(first, compareIssues) {
+		t.Errorf("Lint() issues are not sorted by file, line, linter, and message: %v", first)
+	}
+
+	for range 5 {
+		next, err := NewWithConfig(context.Background(), []*workflow.Workflow{wf}, cfg).Lint()
+		if err != nil {
+			t.Fatalf("Lint() error = %v", err)
+		}
+		if !slices.EqualFunc(first, next, func(a, b *Issue) bool { return *a == *b }) {
+			t.Fatalf("Lint() order changed between runs:\n%v\n%v", first, next)
+		}
+	}
+}
+
 func TestWorkflowLinter_Lint_EmptyWorkflows(t *testing.T) {
 	linter := NewWithWorkflows(context.Background(), []*workflow.Workflow{}, "")
 	issues, err := linter.Lint()
@@ -0,0 +1,165 @@
+package linter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Rules reported by the matrix linter.
+const (
+	ruleMatrixUndefinedExclude = "matrix/undefined-exclude-axis"
+	ruleMatrixUndefinedInclude = "matrix/undefined-include-axis"
+	ruleMatrixEmpty            = "matrix/no-jobs"
+)
+
+// MatrixLinter checks job strategy.matrix definitions for include and exclude
+// entries that reference undefined axes, and for matrices that produce no jobs.
+type MatrixLinter struct {
+	noOpFixer
+}
+
+// NewMatrixLinter creates a new MatrixLinter instance.
+func NewMatrixLinter() *MatrixLinter {
+	return &MatrixLinter{}
+}
+
+// LintWorkflow checks the matrix of each job in a single workflow.
+// Issues are reported at the line of the job's matrix key.
+func (l *MatrixLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	var issues []*Issue
+
+	if wf.Content == nil || wf.Content.Jobs == nil {
+		return issues, nil
+	}
+
+	file := wf.BaseName()
+	for _, jobID := range slices.Sorted(maps.Keys(wf.Content.Jobs)) {
+		job, ok := wf.Content.Jobs[jobID].(map[string]any)
+		if !ok {
+			continue
+		}
+		strategy, ok := job["strategy"].(map[string]any)
+		if !ok {
+			continue
+		}
+		// A matrix given as an expression (e.g., fromJSON) is only known at run time
+		matrix, ok := strategy["matrix"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		line := wf.FindMatrixLine(jobID)
+		if line == 0 {
+			line = wf.FindJobLine(jobID)
+		}
+		issues = append(issues, checkMatrix(file, line, jobID, matrix)...)
+	}
+
+	return issues, nil
+}
+
+// checkMatrix returns the issues for the matrix of a job, reported at line.
+func checkMatrix(file string, line int, jobID string, matrix map[string]any) []*Issue {
+	var issues []*Issue
+
+	axes := matrixAxes(matrix)
+	for _, key := range undefinedExcludeKeys(matrix, axes) {
+		message := fmt.Sprintf("Matrix exclude in job '%s' references undefined axis '%s'", jobID, key)
+		issues = append(issues, newRuleIssue(file, line, ruleMatrixUndefinedExclude, message))
+	}
+	for _, key := range misspelledIncludeKeys(matrix, axes) {
+		message := fmt.Sprintf("Matrix include in job '%s' references undefined axis '%s'; "+
+			"did you mean '%s'?", jobID, key, axisFold(axes, key))
+		issues = append(issues, newRuleIssue(file, line, ruleMatrixUndefinedInclude, message))
+	}
+
+	// Include entries add combinations of their own, so the matrix still produces jobs
+	if include, ok := matrix["include"].([]any); ok && len(include) > 0 {
+		return issues
+	}
+	if _, isExpr := matrix["include"].(string); isExpr {
+		return issues
+	}
+	if len(axes) == 0 {
+		message := fmt.Sprintf("Matrix in job '%s' has no axes or include entries, so it produces no jobs", jobID)
+		issues = append(issues, newRuleIssue(file, line, ruleMatrixEmpty, message))
+	}
+	for _, axis := range axes {
+		if values, ok := matrix[axis].([]any); ok && len(values) == 0 {
+			message := fmt.Sprintf("Matrix axis '%s' in job '%s' is empty, so the matrix produces no jobs", axis, jobID)
+			issues = append(issues, newRuleIssue(file, line, ruleMatrixEmpty, message))
+		}
+	}
+
+	return issues
+}
+
+// matrixAxes returns the sorted axes of a matrix: its keys other than include and exclude.
+func matrixAxes(matrix map[string]any) []string {
+	return slices.Sorted(func(yield func(string) bool) {
+		for key := range matrix {
+			if key != "include" && key != "exclude" && !yield(key) {
+				return
+			}
+		}
+	})
+}
+
+// undefinedExcludeKeys returns the sorted keys used in a matrix's exclude entries
+// that are not axes. Such entries never match, so nothing is excluded.
+func undefinedExcludeKeys(matrix map[string]any, axes []string) []string {
+	undefined := make(map[string]bool)
+	for _, entry := range matrixEntries(matrix, "exclude") {
+		for key := range entry {
+			if !slices.Contains(axes, key) {
+				undefined[key] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(undefined))
+}
+
+// misspelledIncludeKeys returns the sorted keys used in a matrix's include entries
+// that are not axes but differ from one only by case. Include entries may add new
+// keys, so other keys are not reported.
+func misspelledIncludeKeys(matrix map[string]any, axes []string) []string {
+	misspelled := make(map[string]bool)
+	for _, entry := range matrixEntries(matrix, "include") {
+		for key := range entry {
+			if !slices.Contains(axes, key) && axisFold(axes, key) != "" {
+				misspelled[key] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(misspelled))
+}
+
+// matrixEntries returns the mapping entries of a matrix's include or exclude list.
+func matrixEntries(matrix map[string]any, name string) []map[string]any {
+	list, ok := matrix[name].([]any)
+	if !ok {
+		return nil
+	}
+
+	entries := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if entry, ok := item.(map[string]any); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// axisFold returns the axis that equals key under case folding, or an empty string.
+func axisFold(axes []string, key string) string {
+	for _, axis := range axes {
+		if strings.EqualFold(axis, key) {
+			return axis
+		}
+	}
+	return ""
+}
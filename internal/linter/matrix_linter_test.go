@@ -0,0 +1,145 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestMatrixLinter_LintWorkflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		matrix   string
+		expected []string
+	}{
+		{
+			name: "exclude matches axes",
+			matrix: `        os: [ubuntu-latest, windows-latest]
+        go: ['1.23', '1.24']
+        exclude:
+          - os: windows-latest
+            go: '1.23'`,
+		},
+		{
+			name: "exclude references undefined axis",
+			matrix: `        os: [ubuntu-latest, windows-latest]
+        go: ['1.23', '1.24']
+        exclude:
+          - os: windows-latest
+            golang: '1.23'
+          - platform: arm64`,
+			expected: []string{
+				"8: Matrix exclude in job 'build' references undefined axis 'golang'",
+				"8: Matrix exclude in job 'build' references undefined axis 'platform'",
+			},
+		},
+		{
+			name: "include may add keys",
+			matrix: `        os: [ubuntu-latest]
+        include:
+          - os: ubuntu-latest
+            experimental: true`,
+		},
+		{
+			name: "include references misspelled axis",
+			matrix: `        os: [ubuntu-latest]
+        include:
+          - OS: windows-latest
+            experimental: true`,
+			expected: []string{"8: Matrix include in job 'build' references undefined axis 'OS'; did you mean 'os'?"},
+		},
+		{
+			name: "exclude references include-only key",
+			matrix: `        os: [ubuntu-latest]
+        include:
+          - os: ubuntu-latest
+            experimental: true
+        exclude:
+          - experimental: true`,
+			expected: []string{"8: Matrix exclude in job 'build' references undefined axis 'experimental'"},
+		},
+		{
+			name:     "empty axis",
+			matrix:   `        os: []`,
+			expected: []string{"8: Matrix axis 'os' in job 'build' is empty, so the matrix produces no jobs"},
+		},
+		{
+			name: "empty axis with include",
+			matrix: `        os: []
+        include:
+          - os: ubuntu-latest`,
+		},
+		{
+			name: "no axes",
+			matrix: `        exclude:
+          - os: ubuntu-latest`,
+			expected: []string{
+				"8: Matrix exclude in job 'build' references undefined axis 'os'",
+				"8: Matrix in job 'build' has no axes or include entries, so it produces no jobs",
+			},
+		},
+		{
+			name:   "axis from expression",
+			matrix: `        os: ${{ fromJSON(vars.PLATFORMS) }}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := `name: Test
+on: push
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+` + tt.matrix + `
+`
+			path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			issues, err := NewMatrixLinter().LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatrixLinter_MatrixExpression(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix: ${{ fromJSON(needs.setup.outputs.matrix) }}
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	issues, err := NewMatrixLinter().LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("LintWorkflow() returned %d issues, want 0", len(issues))
+	}
+}
@@ -0,0 +1,103 @@
+package linter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Rules reported by the needs linter.
+const (
+	ruleNeedsUnknownJob = "needs/unknown-job"
+	ruleNeedsCycle      = "needs/cycle"
+)
+
+// NeedsLinter checks job dependencies declared with needs for unknown jobs and cycles.
+type NeedsLinter struct {
+	noOpFixer
+}
+
+// NewNeedsLinter creates a new NeedsLinter instance.
+func NewNeedsLinter() *NeedsLinter {
+	return &NeedsLinter{}
+}
+
+// LintWorkflow checks a single workflow for dangling needs references and dependency cycles.
+func (l *NeedsLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	var issues []*Issue
+
+	if wf.Content == nil || wf.Content.Jobs == nil {
+		return issues, nil
+	}
+
+	file := wf.BaseName()
+	jobIDs := slices.Sorted(maps.Keys(wf.Content.Jobs))
+	graph := make(map[string][]string, len(jobIDs))
+
+	// Build the dependency graph, reporting references to unknown jobs
+	for _, jobID := range jobIDs {
+		job, _ := wf.Content.Jobs[jobID].(map[string]any)
+		for _, dep := range scalarOrList(job["needs"]) {
+			if _, ok := wf.Content.Jobs[dep]; !ok {
+				message := fmt.Sprintf("Job '%s' needs unknown job '%s'", jobID, dep)
+				issues = append(issues, newRuleIssue(file, wf.FindJobLine(jobID), ruleNeedsUnknownJob, message))
+				continue
+			}
+			graph[jobID] = append(graph[jobID], dep)
+		}
+	}
+
+	for _, cycle := range findCycles(jobIDs, graph) {
+		message := fmt.Sprintf("Job '%s' has a dependency cycle: %s", cycle[0], strings.Join(cycle, " -> "))
+		issues = append(issues, newRuleIssue(file, wf.FindJobLine(cycle[0]), ruleNeedsCycle, message))
+	}
+
+	return issues, nil
+}
+
+// findCycles returns the dependency cycles in the graph, each as a path
+// starting and ending with the same job (e.g., [a b a]). Nodes are visited
+// in the given order, so the result is deterministic.
+func findCycles(nodes []string, graph map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make(map[string]int, len(nodes))
+	var path []string
+	var cycles [][]string
+
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = visiting
+		path = append(path, node)
+
+		for _, dep := range graph[node] {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				// Back edge: the cycle is the path from dep to the current node
+				start := slices.Index(path, dep)
+				cycle := slices.Clone(path[start:])
+				cycles = append(cycles, append(cycle, dep))
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[node] = done
+	}
+
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+
+	return cycles
+}
@@ -0,0 +1,135 @@
+package linter
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestNeedsLinter_Lint(t *testing.T) {
+	type expectedIssue struct {
+		line    int
+		message string
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []expectedIssue
+	}{
+		{
+			name: "valid dependencies",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  test:
+    needs: build
+    runs-on: ubuntu-latest
+  deploy:
+    needs: [build, test]
+    runs-on: ubuntu-latest
+`,
+			expected: nil,
+		},
+		{
+			name: "unknown job in string form",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  test:
+    needs: biuld
+    runs-on: ubuntu-latest
+`,
+			expected: []expectedIssue{{6, "Job 'test' needs unknown job 'biuld'"}},
+		},
+		{
+			name: "unknown job in list form",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  deploy:
+    needs:
+      - build
+      - lint
+    runs-on: ubuntu-latest
+`,
+			expected: []expectedIssue{{6, "Job 'deploy' needs unknown job 'lint'"}},
+		},
+		{
+			name: "two job cycle",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    needs: test
+    runs-on: ubuntu-latest
+  test:
+    needs: build
+    runs-on: ubuntu-latest
+`,
+			expected: []expectedIssue{{4, "Job 'build' has a dependency cycle: build -> test -> build"}},
+		},
+		{
+			name: "self dependency",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    needs: [build]
+    runs-on: ubuntu-latest
+`,
+			expected: []expectedIssue{{4, "Job 'build' has a dependency cycle: build -> build"}},
+		},
+		{
+			name: "longer cycle behind a valid dependency",
+			content: `name: Test
+on: push
+jobs:
+  a:
+    needs: b
+    runs-on: ubuntu-latest
+  b:
+    needs: c
+    runs-on: ubuntu-latest
+  c:
+    needs: d
+    runs-on: ubuntu-latest
+  d:
+    needs: b
+    runs-on: ubuntu-latest
+`,
+			expected: []expectedIssue{{7, "Job 'b' has a dependency cycle: b -> c -> d -> b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", tt.content)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			issues, err := NewNeedsLinter().LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var got []expectedIssue
+			for _, issue := range issues {
+				got = append(got, expectedIssue{issue.Line, issue.Message})
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("LintWorkflow() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
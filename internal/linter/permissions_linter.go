@@ -1,24 +1,168 @@
 package linter
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
-// PermissionsLinter checks for missing permissions configuration in workflows.
+// Rules reported by the permissions linter.
+const (
+	rulePermissionsMissing            = "permissions/missing"
+	rulePermissionsInvalid            = "permissions/invalid"
+	rulePermissionsWriteAll           = "permissions/write-all"
+	rulePermissionsWorkflowWrite      = "permissions/workflow-write"
+	rulePermissionsPersistCredentials = "permissions/persist-credentials"
+)
+
+// PermissionsLinter checks for missing, malformed, or overly broad permissions
+// configuration in workflows.
 type PermissionsLinter struct {
-	noOpFixer
+	settings *config.PermissionsSettings
 }
 
 // NewPermissionsLinter creates a new PermissionsLinter instance.
-func NewPermissionsLinter() *PermissionsLinter {
-	return &PermissionsLinter{}
+func NewPermissionsLinter(settings *config.PermissionsSettings) *PermissionsLinter {
+	if settings == nil {
+		settings = config.DefaultPermissionsSettings()
+	}
+	return &PermissionsLinter{settings: settings}
 }
 
-// LintWorkflow checks a single workflow for missing permissions configuration.
+// LintWorkflow checks a single workflow for missing, malformed, or overly broad permissions.
 func (l *PermissionsLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	file := wf.BaseName()
+	line := wf.FindPermissionsLine("")
+
+	var issues []*Issue
 	if !wf.HasPermissions() {
-		issue := newIssue(wf.BaseName(), 0, "Workflow is missing permissions configuration")
-		return []*Issue{issue}, nil
+		issues = missingJobPermissions(wf, file)
+	}
+
+	switch permissions := wf.Content.Permissions.(type) {
+	case []any:
+		issues = append(issues, newRuleIssue(file, line, rulePermissionsInvalid,
+			"Workflow permissions must be a string (e.g., read-all) or a map of scopes, not a list"))
+	case string:
+		if permissions == "write-all" {
+			issues = append(issues, newRuleIssue(file, line, rulePermissionsWriteAll,
+				"Workflow permissions grant write-all; grant only the scopes each job needs"))
+		}
+	case map[string]any:
+		if l.settings.WarnWorkflowWrites {
+			for _, scope := range writeScopes(permissions) {
+				message := fmt.Sprintf("Workflow permissions grant '%s: write' to every job; "+
+					"grant it only in the jobs that need it", scope)
+				issues = append(issues, newRuleIssue(file, line, rulePermissionsWorkflowWrite, message))
+			}
+		}
+	}
+
+	for _, jobID := range slices.Sorted(maps.Keys(wf.Content.Jobs)) {
+		job, ok := wf.Content.Jobs[jobID].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var ruleID, message string
+		switch permissions := job["permissions"].(type) {
+		case []any:
+			ruleID = rulePermissionsInvalid
+			message = fmt.Sprintf("Job '%s' permissions must be a string (e.g., read-all) or a map of scopes, not a list",
+				jobID)
+		case string:
+			if permissions == "write-all" {
+				ruleID = rulePermissionsWriteAll
+				message = fmt.Sprintf("Job '%s' permissions grant write-all; grant only the scopes it needs", jobID)
+			}
+		}
+		if issue := newRuleIssue(file, wf.FindPermissionsLine(jobID), ruleID, message); issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+
+	if l.settings.WarnPersistCredentials {
+		checkoutIssues, err := persistedCredentials(wf, file)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, checkoutIssues...)
+	}
+
+	return issues, nil
+}
+
+// FixWorkflow adds workflow-level permissions (read-all by default) to workflows
+// that are reported as missing permissions.
+func (l *PermissionsLinter) FixWorkflow(wf *workflow.Workflow) error {
+	if wf.HasPermissions() || len(missingJobPermissions(wf, wf.BaseName())) == 0 {
+		return nil
+	}
+	if err := wf.InsertPermissions(l.settings.GetFixPermissions()); err != nil {
+		return fmt.Errorf("failed to insert permissions: %w", err)
+	}
+	return nil
+}
+
+// missingJobPermissions reports each job that doesn't define permissions in a workflow
+// without workflow-level permissions. Without jobs, the workflow itself is reported.
+func missingJobPermissions(wf *workflow.Workflow, file string) []*Issue {
+	if len(wf.Content.Jobs) == 0 {
+		return []*Issue{newRuleIssue(file, wf.FindPermissionsInsertLine(), rulePermissionsMissing,
+			"Workflow is missing permissions configuration")}
+	}
+
+	var issues []*Issue
+	for _, jobID := range slices.Sorted(maps.Keys(wf.Content.Jobs)) {
+		job, _ := wf.Content.Jobs[jobID].(map[string]any)
+		if job["permissions"] != nil {
+			continue
+		}
+		message := fmt.Sprintf("Job '%s' is missing permissions configuration", jobID)
+		issues = append(issues, newRuleIssue(file, wf.FindJobLine(jobID), rulePermissionsMissing, message))
+	}
+	return issues
+}
+
+// persistedCredentials reports each actions/checkout step that doesn't set persist-credentials
+// to false, as a warning unless the linter's severity is configured. A value given as an
+// expression is only known at run time, so it is not reported.
+func persistedCredentials(wf *workflow.Workflow, file string) ([]*Issue, error) {
+	workflowActions, err := wf.FindActions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find actions: %w", err)
+	}
+
+	var issues []*Issue
+	for _, action := range workflowActions {
+		name, _, _ := strings.Cut(action.Uses, "@")
+		if !strings.EqualFold(name, "actions/checkout") {
+			continue
+		}
+		value, _ := action.Input("persist-credentials")
+		if strings.EqualFold(value, "false") || strings.Contains(value, "${{") {
+			continue
+		}
+		issue := newRuleIssue(file, action.Line, rulePermissionsPersistCredentials,
+			"Checkout step should set 'persist-credentials: false' so the token is not left in the git config")
+		issue.Severity = config.SeverityWarning
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// writeScopes returns the sorted scopes granted write access in a permissions map.
+func writeScopes(permissions map[string]any) []string {
+	var scopes []string
+	for scope, access := range permissions {
+		if access == "write" {
+			scopes = append(scopes, scope)
+		}
 	}
-	return nil, nil
+	slices.Sort(scopes)
+	return scopes
 }
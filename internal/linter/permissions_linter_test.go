@@ -1,10 +1,13 @@
 package linter
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
@@ -82,7 +85,7 @@ jobs:
 				t.Fatalf("LoadWorkflow() error = %v", err)
 			}
 
-			linter := NewPermissionsLinter()
+			linter := NewPermissionsLinter(nil)
 			issues, err := linter.LintWorkflow(wf)
 			if err != nil {
 				t.Fatalf("LintWorkflow() error = %v", err)
@@ -96,15 +99,25 @@ jobs:
 	}
 }
 
-func TestPermissionsLinter_Fix(t *testing.T) {
+func TestPermissionsLinter_ListPermissions(t *testing.T) {
 	tmpDir := t.TempDir()
 	workflowPath := filepath.Join(tmpDir, "test.yml")
 
 	content := `name: Test
 on: push
+permissions:
+  - contents: read
 jobs:
   build:
     runs-on: ubuntu-latest
+    permissions:
+      - packages: write
+    steps:
+      - uses: actions/checkout@v3
+  test:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
     steps:
       - uses: actions/checkout@v3
 `
@@ -117,10 +130,367 @@ jobs:
 		t.Fatalf("LoadWorkflow() error = %v", err)
 	}
 
-	linter := NewPermissionsLinter()
-	// FixWorkflow is a no-op, should not return an error
-	err = linter.FixWorkflow(wf)
+	issues, err := NewPermissionsLinter(nil).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+
+	expected := []string{
+		"3: Workflow permissions must be a string (e.g., read-all) or a map of scopes, not a list",
+		"8: Job 'build' permissions must be a string (e.g., read-all) or a map of scopes, not a list",
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("LintWorkflow() returned %d issues, want %d: %v", len(issues), len(expected), issues)
+	}
+	for i, issue := range issues {
+		if got := fmt.Sprintf("%d: %s", issue.Line, issue.Message); got != expected[i] {
+			t.Errorf("issue %d = %q, want %q", i, got, expected[i])
+		}
+	}
+}
+
+func TestPermissionsLinter_JobLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name: "jobs without permissions",
+			content: `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  release:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: write
+  test:
+    runs-on: ubuntu-latest
+`,
+			expected: []string{
+				"3: Job 'build' is missing permissions configuration",
+				"9: Job 'test' is missing permissions configuration",
+			},
+		},
+		{
+			name: "all jobs define permissions",
+			content: `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    permissions: read-all
+  release:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: write
+`,
+		},
+		{
+			name: "workflow permissions cover jobs",
+			content: `on: push
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+		},
+		{
+			name:     "no jobs",
+			content:  "on: workflow_dispatch\n",
+			expected: []string{"1: Workflow is missing permissions configuration"},
+		},
+		{
+			name:     "no jobs reported at insertion point",
+			content:  "name: Test\non: workflow_dispatch\n\njobs: {}\n",
+			expected: []string{"3: Workflow is missing permissions configuration"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowPath := filepath.Join(t.TempDir(), "test.yml")
+			if err := os.WriteFile(workflowPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write test workflow: %v", err)
+			}
+			wf, err := workflow.LoadWorkflow(workflowPath)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			issues, err := NewPermissionsLinter(nil).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPermissionsLinter_BroadGrants(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		workflowWrite bool
+		expected      []string
+	}{
+		{
+			name: "workflow write-all",
+			content: `on: push
+permissions: write-all
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+			expected: []string{"2: Workflow permissions grant write-all; grant only the scopes each job needs"},
+		},
+		{
+			name: "job write-all",
+			content: `on: push
+permissions: read-all
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    permissions: write-all
+`,
+			expected: []string{"6: Job 'build' permissions grant write-all; grant only the scopes it needs"},
+		},
+		{
+			name: "empty permissions grant nothing",
+			content: `on: push
+permissions: {}
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+		},
+		{
+			name: "workflow write scopes not checked by default",
+			content: `on: push
+permissions:
+  contents: write
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+		},
+		{
+			name: "workflow write scopes",
+			content: `on: push
+permissions:
+  contents: write
+  id-token: write
+  pull-requests: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    permissions:
+      packages: write
+`,
+			workflowWrite: true,
+			expected: []string{
+				"2: Workflow permissions grant 'contents: write' to every job; grant it only in the jobs that need it",
+				"2: Workflow permissions grant 'id-token: write' to every job; grant it only in the jobs that need it",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowPath := filepath.Join(t.TempDir(), "test.yml")
+			if err := os.WriteFile(workflowPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write test workflow: %v", err)
+			}
+			wf, err := workflow.LoadWorkflow(workflowPath)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			settings := &config.PermissionsSettings{WarnWorkflowWrites: tt.workflowWrite}
+			issues, err := NewPermissionsLinter(settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPermissionsLinter_PersistCredentials(t *testing.T) {
+	content := `on: push
+permissions: read-all
+x-checkout: &checkout
+  uses: actions/checkout@v4
+  with:
+    persist-credentials: false
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+      - uses: actions/checkout@v4
+        with:
+          persist-credentials: true
+      - uses: actions/checkout@v4
+        with:
+          persist-credentials: false
+      - uses: actions/checkout@v4
+        with:
+          persist-credentials: ${{ inputs.push }}
+      - uses: actions/setup-go@v5
+      - *checkout
+      - <<: *checkout
+        name: Checkout
+`
+	wf, err := workflow.ParseWorkflow("test.yml", []byte(content))
 	if err != nil {
-		t.Fatalf("FixWorkflow() error = %v", err)
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		settings *config.PermissionsSettings
+		expected []string
+	}{
+		{
+			name: "not checked by default",
+		},
+		{
+			name:     "enabled",
+			settings: &config.PermissionsSettings{WarnPersistCredentials: true},
+			expected: []string{
+				"11: Checkout step should set 'persist-credentials: false' so the token is not left in the git config",
+				"12: Checkout step should set 'persist-credentials: false' so the token is not left in the git config",
+				"15: Checkout step should set 'persist-credentials: false' so the token is not left in the git config",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := NewPermissionsLinter(tt.settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+				if issue.Severity != config.SeverityWarning {
+					t.Errorf("line %d: Severity = %q, want %q", issue.Line, issue.Severity, config.SeverityWarning)
+				}
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPermissionsLinter_Fix(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		settings *config.PermissionsSettings
+		expected string
+	}{
+		{
+			name: "inserts read-all after on block",
+			content: `name: Test
+on:
+  push:
+    branches: [main]
+
+# Build jobs
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+			expected: `name: Test
+on:
+  push:
+    branches: [main]
+permissions: read-all
+
+# Build jobs
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+		},
+		{
+			name:     "configured value",
+			content:  "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			settings: &config.PermissionsSettings{FixPermissions: "{contents: read}"},
+			expected: "on: push\npermissions: {contents: read}\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+		},
+		{
+			name:     "on after jobs",
+			content:  "jobs:\n  build:\n    runs-on: ubuntu-latest\non: push\n",
+			expected: "jobs:\n  build:\n    runs-on: ubuntu-latest\non: push\npermissions: read-all\n",
+		},
+		{
+			name:     "no on key",
+			content:  "name: Test\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			expected: "name: Test\npermissions: read-all\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+		},
+		{
+			name:     "already has permissions",
+			content:  "on: push\npermissions: {}\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			expected: "on: push\npermissions: {}\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+		},
+		{
+			name:     "all jobs define permissions",
+			content:  "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    permissions: read-all\n",
+			expected: "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    permissions: read-all\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowPath := filepath.Join(t.TempDir(), "test.yml")
+			if err := os.WriteFile(workflowPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write test workflow: %v", err)
+			}
+			wf, err := workflow.LoadWorkflow(workflowPath)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			linter := NewPermissionsLinter(tt.settings)
+			if err := linter.FixWorkflow(wf); err != nil {
+				t.Fatalf("FixWorkflow() error = %v", err)
+			}
+			if got := string(wf.RawBytes); got != tt.expected {
+				t.Errorf("FixWorkflow() result:\n%s\nwant:\n%s", got, tt.expected)
+			}
+
+			// The fixed workflow is no longer reported
+			issues, err := linter.LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+			if len(issues) != 0 {
+				t.Errorf("LintWorkflow() after fix returned %v", issues)
+			}
+		})
 	}
 }
@@ -0,0 +1,75 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/stringutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// rulePRSecretsUsed is the rule reported by the pr-secrets linter.
+const rulePRSecretsUsed = "pr-secrets/secret-in-pull-request"
+
+// expressionPattern matches a ${{ ... }} expression, capturing its contents.
+var expressionPattern = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+
+// secretRefPattern matches a secret referenced in an expression, as secrets.NAME or
+// secrets['NAME'], capturing the name.
+var secretRefPattern = regexp.MustCompile(`\bsecrets(?:\.([A-Za-z_][A-Za-z0-9_]*)|\[\s*'([^']+)'\s*\])`)
+
+// PRSecretsLinter checks workflows triggered by pull_request for secrets other than
+// GITHUB_TOKEN, which the code of the pull request can read when they are available.
+type PRSecretsLinter struct {
+	noOpFixer
+}
+
+// NewPRSecretsLinter creates a new PRSecretsLinter instance.
+func NewPRSecretsLinter() *PRSecretsLinter {
+	return &PRSecretsLinter{}
+}
+
+// LintWorkflow reports each secret referenced in a workflow triggered by pull_request,
+// at the line of the reference. Comment lines are skipped.
+func (l *PRSecretsLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	if !wf.HasTrigger("pull_request") {
+		return nil, nil
+	}
+
+	var issues []*Issue
+	file := wf.BaseName()
+	for i, line := range wf.Lines() {
+		if stringutil.IsBlankOrComment(line) {
+			continue
+		}
+		for _, name := range secretRefs(line) {
+			message := fmt.Sprintf("Secret '%s' is used in a workflow triggered by pull_request, "+
+				"where the pull request's code can read it", name)
+			issues = append(issues, newRuleIssue(file, i+1, rulePRSecretsUsed, message))
+		}
+	}
+
+	return issues, nil
+}
+
+// secretRefs returns the names of the secrets other than GITHUB_TOKEN referenced in the
+// expressions on a line, once each, in order of appearance.
+func secretRefs(line string) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	for _, expr := range expressionPattern.FindAllStringSubmatch(line, -1) {
+		for _, ref := range secretRefPattern.FindAllStringSubmatch(expr[1], -1) {
+			name := ref[1] + ref[2]
+			// Secret names are case-insensitive
+			key := strings.ToUpper(name)
+			if key == "GITHUB_TOKEN" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,90 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestPRSecretsLinter_LintWorkflow(t *testing.T) {
+	steps := `jobs:
+  test:
+    runs-on: ubuntu-latest
+    env:
+      NPM_TOKEN: ${{ secrets.NPM_TOKEN }}
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          token: ${{ secrets.GITHUB_TOKEN }}
+      # token: ${{ secrets.OLD_TOKEN }}
+      - run: ./deploy.sh "${{ secrets.DEPLOY_KEY }}" "${{ secrets['Deploy_Key'] }}" "${{ secrets.API_KEY }}"
+      - uses: codecov/codecov-action@v4
+        with:
+          token: ${{ secrets.github_token || secrets['CODECOV_TOKEN'] }}
+      - run: echo "secrets.NOT_AN_EXPRESSION"
+`
+
+	message := func(line int, name string) string {
+		return fmt.Sprintf("%d: Secret '%s' is used in a workflow triggered by pull_request, "+
+			"where the pull request's code can read it", line, name)
+	}
+
+	tests := []struct {
+		name     string
+		on       string
+		expected []string
+	}{
+		{
+			name: "pull_request",
+			on:   "on: pull_request\n",
+			expected: []string{
+				message(6, "NPM_TOKEN"),
+				message(12, "DEPLOY_KEY"),
+				message(12, "API_KEY"),
+				message(15, "CODECOV_TOKEN"),
+			},
+		},
+		{
+			name: "pull_request among other events",
+			on:   "on:\n  push:\n  pull_request:\n    branches: [main]\n",
+			expected: []string{
+				message(9, "NPM_TOKEN"),
+				message(15, "DEPLOY_KEY"),
+				message(15, "API_KEY"),
+				message(18, "CODECOV_TOKEN"),
+			},
+		},
+		{
+			name: "push",
+			on:   "on: push\n",
+		},
+		{
+			name: "pull_request_target",
+			on:   "on: [pull_request_target]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := workflow.ParseWorkflow("test.yml", []byte(tt.on+steps))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			issues, err := NewPRSecretsLinter().LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package linter
 import (
 	"context"
 
+	"github.com/reugn/github-ci/internal/actions"
 	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/workflow"
 )
@@ -21,33 +22,167 @@ type linterFactory func(ctx context.Context, cfg *config.Config) Linter
 
 // linterFactories maps linter names to their factory functions.
 var linterFactories = map[string]linterFactory{
-	config.LinterVersions: func(ctx context.Context, _ *config.Config) Linter {
-		return NewVersionsLinter(ctx)
+	config.LinterVersions: func(ctx context.Context, cfg *config.Config) Linter {
+		return newConfiguredVersionsLinter(ctx, cfg, actions.NewCache())
 	},
-	config.LinterPermissions: func(_ context.Context, _ *config.Config) Linter {
-		return NewPermissionsLinter()
+	config.LinterPermissions: func(_ context.Context, cfg *config.Config) Linter {
+		return NewPermissionsLinter(cfg.GetPermissionsSettings())
 	},
 	config.LinterFormat: func(_ context.Context, cfg *config.Config) Linter {
 		return NewFormatLinter(cfg.GetFormatSettings())
 	},
-	config.LinterSecrets: func(_ context.Context, _ *config.Config) Linter {
-		return NewSecretsLinter()
+	config.LinterSecrets: func(_ context.Context, cfg *config.Config) Linter {
+		return NewSecretsLinter(cfg.GetSecretsSettings())
 	},
-	config.LinterInjection: func(_ context.Context, _ *config.Config) Linter {
-		return NewInjectionLinter()
+	config.LinterInjection: func(_ context.Context, cfg *config.Config) Linter {
+		return NewInjectionLinter(cfg.GetInjectionSettings())
 	},
 	config.LinterStyle: func(_ context.Context, cfg *config.Config) Linter {
 		return NewStyleLinter(cfg.GetStyleSettings())
 	},
+	config.LinterRunners: func(_ context.Context, cfg *config.Config) Linter {
+		return NewRunnersLinter(cfg.GetRunnersSettings())
+	},
+	config.LinterNeeds: func(_ context.Context, _ *config.Config) Linter {
+		return NewNeedsLinter()
+	},
+	config.LinterHosts: func(_ context.Context, cfg *config.Config) Linter {
+		return NewHostsLinter(cfg.GetHostsSettings())
+	},
+	config.LinterTriggers: func(_ context.Context, _ *config.Config) Linter {
+		return NewTriggersLinter()
+	},
+	config.LinterMatrix: func(_ context.Context, _ *config.Config) Linter {
+		return NewMatrixLinter()
+	},
+	config.LinterPRSecrets: func(_ context.Context, _ *config.Config) Linter {
+		return NewPRSecretsLinter()
+	},
+	config.LinterCommands: func(_ context.Context, _ *config.Config) Linter {
+		return NewCommandsLinter()
+	},
+	config.LinterContinueOnError: func(_ context.Context, cfg *config.Config) Linter {
+		return NewContinueOnErrorLinter(cfg.GetContinueOnErrorSettings())
+	},
+	config.LinterTokenScopes: func(_ context.Context, _ *config.Config) Linter {
+		return NewTokenScopesLinter()
+	},
+	config.LinterCacheKeys: func(_ context.Context, cfg *config.Config) Linter {
+		return NewCacheKeysLinter(cfg.GetCacheKeysSettings())
+	},
+}
+
+// newConfiguredVersionsLinter creates a versions linter with its settings from config,
+// looking up versions through a GitHub client that uses cache.
+func newConfiguredVersionsLinter(ctx context.Context, cfg *config.Config, cache *actions.Cache) *VersionsLinter {
+	client := actions.NewClientWithCache(ctx, cache)
+	client.SetMaxRetryWait(cfg.GetMaxRetryWait())
+	l := NewVersionsLinterWithClient(client)
+	settings := cfg.GetVersionsSettings()
+	l.SetManagedComment(settings.ManagedComment)
+	if settings.SuggestUpgrades {
+		l.SetUpgradeSuggestions(cfg)
+	}
+	l.SetCheckReusableWorkflows(settings.CheckReusableWorkflows)
+	l.SetFixPinComments(settings.FixPinComments)
+	l.SetAllowedOwners(settings.AllowedOwners)
+	return l
+}
+
+// linterDescriptions provides a short description of each linter.
+var linterDescriptions = map[string]string{
+	config.LinterVersions:        "Actions using version tags instead of commit hashes",
+	config.LinterPermissions:     "Missing permissions configuration",
+	config.LinterFormat:          "Formatting issues (indentation, line length, trailing whitespace)",
+	config.LinterSecrets:         "Hardcoded secrets and sensitive information",
+	config.LinterInjection:       "Shell injection vulnerabilities from untrusted input",
+	config.LinterStyle:           "Naming conventions and style best practices",
+	config.LinterRunners:         "Deprecated or floating runner labels in runs-on",
+	config.LinterNeeds:           "Job needs referencing unknown jobs or forming cycles",
+	config.LinterHosts:           "Hardcoded internal IP addresses and hostnames (opt-in)",
+	config.LinterTriggers:        "Unknown or risky events in the on trigger block",
+	config.LinterMatrix:          "Matrix include/exclude entries with undefined axes or no jobs",
+	config.LinterPRSecrets:       "Secrets used in workflows triggered by pull_request (opt-in)",
+	config.LinterCommands:        "Deprecated set-output, save-state, set-env, and add-path workflow commands",
+	config.LinterContinueOnError: "Jobs and steps with continue-on-error: true",
+	config.LinterTokenScopes:     "Token permissions missing scopes known actions need, or granting unused writes",
+	config.LinterCacheKeys:       "Cache keys without a hashFiles(...) lockfile hash (opt-in)",
+	SyntaxLinter:                 "Workflow files that are not valid YAML or name unknown linters in markers",
+}
+
+// Description returns a short description of the linter, or an empty string if unknown.
+func Description(linterName string) string {
+	return linterDescriptions[linterName]
 }
 
 // lintersWithAutoFix lists linters that support automatic fixing.
 var lintersWithAutoFix = map[string]bool{
-	config.LinterVersions: true,
-	config.LinterFormat:   true,
+	config.LinterVersions:    true,
+	config.LinterPermissions: true,
+	config.LinterFormat:      true,
 }
 
-// SupportsAutoFix returns true if the linter supports automatic fixing.
-func SupportsAutoFix(linterName string) bool {
+// SupportsAutoFix returns true if the linter supports automatic fixing with cfg.
+// The style fixer only reorders step keys, which is opt-in, so style supports
+// automatic fixing only when fix-step-key-order is enabled. cfg may be nil.
+func SupportsAutoFix(linterName string, cfg *config.Config) bool {
+	if linterName == config.LinterStyle {
+		return cfg.GetStyleSettings().FixStepKeyOrder
+	}
 	return lintersWithAutoFix[linterName]
 }
+
+// lintersForActions lists linters that also check composite action metadata files.
+// The others check workflow-only structure, such as jobs, triggers, or permissions.
+var lintersForActions = map[string]bool{
+	config.LinterVersions:  true,
+	config.LinterSecrets:   true,
+	config.LinterInjection: true,
+	config.LinterFormat:    true,
+	config.LinterCommands:  true,
+	config.LinterCacheKeys: true,
+}
+
+// appliesTo returns true if the linter checks wf: every linter checks workflows,
+// and only lintersForActions check composite actions.
+func appliesTo(linterName string, wf *workflow.Workflow) bool {
+	return !wf.IsCompositeAction() || lintersForActions[linterName]
+}
+
+// lintersWithNetwork lists linters that call the GitHub API.
+var lintersWithNetwork = map[string]bool{
+	config.LinterVersions: true,
+}
+
+// RequiresNetwork returns true if the linter needs network access to the GitHub API.
+func RequiresNetwork(linterName string) bool {
+	return lintersWithNetwork[linterName]
+}
+
+// newOfflineLinter returns the checks of a network linter that still run in offline mode,
+// or nil if it has none: the versions linter checks allowed-owners without the GitHub API.
+func newOfflineLinter(linterName string, cfg *config.Config) Linter {
+	if linterName != config.LinterVersions {
+		return nil
+	}
+	if owners := cfg.GetVersionsSettings().AllowedOwners; len(owners) > 0 {
+		return &ownersLinter{allowedOwners: owners}
+	}
+	return nil
+}
+
+// SkippedOffline returns the names of enabled linters that are skipped
+// because cfg is in offline mode.
+func SkippedOffline(cfg *config.Config) []string {
+	if !cfg.IsOffline() {
+		return nil
+	}
+
+	var skipped []string
+	for _, name := range config.AllLinters() {
+		if RequiresNetwork(name) && cfg.IsLinterEnabled(name) {
+			skipped = append(skipped, name)
+		}
+	}
+	return skipped
+}
@@ -0,0 +1,105 @@
+package linter
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+)
+
+func TestLookupRule(t *testing.T) {
+	for _, id := range RuleIDs() {
+		rule, ok := LookupRule(id)
+		if !ok {
+			t.Errorf("LookupRule(%q) found no rule", id)
+			continue
+		}
+		if rule.ID != id {
+			t.Errorf("LookupRule(%q).ID = %q", id, rule.ID)
+		}
+		if Description(rule.Linter) == "" {
+			t.Errorf("LookupRule(%q).Linter = %q, want a known linter", id, rule.Linter)
+		}
+		for field, value := range map[string]string{
+			"Description": rule.Description,
+			"Rationale":   rule.Rationale,
+			"Fix":         rule.Fix,
+			"Before":      rule.Before,
+			"After":       rule.After,
+		} {
+			if value == "" {
+				t.Errorf("LookupRule(%q).%s is empty", id, field)
+			}
+		}
+	}
+
+	if rule, _ := LookupRule(ruleVersionsTagNotHash); rule.DocsURL != rulesDocsURL+"versions" {
+		t.Errorf("LookupRule(versions/tag-not-hash).DocsURL = %q", rule.DocsURL)
+	}
+	if rule, _ := LookupRule(ruleSyntaxInvalidYAML); rule.DocsURL != "" {
+		t.Errorf("LookupRule(syntax/invalid-yaml).DocsURL = %q, want none", rule.DocsURL)
+	}
+	if _, ok := LookupRule(config.LinterVersions); ok {
+		t.Error("LookupRule(versions) found a rule for a linter name")
+	}
+}
+
+func TestLinterRules(t *testing.T) {
+	for _, name := range append(config.AllLinters(), SyntaxLinter) {
+		if len(LinterRules(name)) == 0 {
+			t.Errorf("LinterRules(%q) returned no rules", name)
+		}
+	}
+	if rules := LinterRules("unknown"); len(rules) != 0 {
+		t.Errorf("LinterRules(unknown) = %v, want none", rules)
+	}
+}
+
+func TestCheckDisabledRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []string
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "known rule", rules: []string{ruleFormatLineLength}},
+		{name: "unknown rule", rules: []string{"format/line-lenght"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewDefaultConfig()
+			cfg.Linters.Settings = &config.LinterSettings{Format: &config.FormatSettings{
+				RuleSettings: config.RuleSettings{DisabledRules: tt.rules},
+			}}
+			if err := CheckDisabledRules(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("CheckDisabledRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestRuleDetails_Complete checks that every rule ID reported by a linter has metadata.
+func TestRuleDetails_Complete(t *testing.T) {
+	pattern := regexp.MustCompile(`(?m)^\s*(?:const )?rule[A-Z]\w*\s*=\s*"([^"]+)"`)
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), "_linter.go") {
+			continue
+		}
+		data, err := os.ReadFile(entry.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, match := range pattern.FindAllStringSubmatch(string(data), -1) {
+			if _, ok := LookupRule(match[1]); !ok {
+				t.Errorf("%s: rule %q has no metadata", entry.Name(), match[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package linter
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Rules reported by the runners linter.
+const (
+	ruleRunnersDeprecated = "runners/deprecated-label"
+	ruleRunnersFloating   = "runners/floating-label"
+)
+
+// deprecatedRunners maps retired GitHub-hosted runner labels to a suggested replacement.
+var deprecatedRunners = map[string]string{
+	"ubuntu-16.04": "ubuntu-24.04",
+	"ubuntu-18.04": "ubuntu-24.04",
+	"ubuntu-20.04": "ubuntu-24.04",
+	"macos-10.15":  "macos-15",
+	"macos-11":     "macos-15",
+	"macos-12":     "macos-15",
+	"macos-13":     "macos-15",
+	"windows-2016": "windows-2025",
+	"windows-2019": "windows-2025",
+}
+
+// floatingRunners lists labels that silently move to newer images.
+var floatingRunners = []string{
+	"ubuntu-latest",
+	"macos-latest",
+	"windows-latest",
+}
+
+// matrixRefPattern matches a runs-on expression referencing a matrix variable (e.g., ${{ matrix.os }}).
+var matrixRefPattern = regexp.MustCompile(`^\$\{\{\s*matrix\.([A-Za-z0-9_-]+)\s*\}\}$`)
+
+// RunnersLinter checks jobs for deprecated or floating runner labels in runs-on.
+type RunnersLinter struct {
+	noOpFixer
+	settings *config.RunnersSettings
+}
+
+// NewRunnersLinter creates a new RunnersLinter instance.
+func NewRunnersLinter(settings *config.RunnersSettings) *RunnersLinter {
+	if settings == nil {
+		settings = config.DefaultRunnersSettings()
+	}
+	return &RunnersLinter{settings: settings}
+}
+
+// LintWorkflow checks a single workflow for runner label issues.
+func (l *RunnersLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	var issues []*Issue
+
+	if wf.Content == nil || wf.Content.Jobs == nil {
+		return issues, nil
+	}
+
+	file := wf.BaseName()
+	for _, jobID := range slices.Sorted(maps.Keys(wf.Content.Jobs)) {
+		job, ok := wf.Content.Jobs[jobID].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		jobLine := wf.FindJobLine(jobID)
+		for _, label := range runnerLabels(job) {
+			if issue := l.checkLabel(file, jobLine, jobID, label); issue != nil {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// checkLabel returns an issue for the runner label of a job at jobLine, or nil if it is fine.
+func (l *RunnersLinter) checkLabel(file string, jobLine int, jobID, label string) *Issue {
+	if replacement, ok := deprecatedRunners[label]; ok {
+		message := fmt.Sprintf("Job '%s' uses deprecated runner '%s'; use '%s' instead",
+			jobID, label, replacement)
+		return newRuleIssue(file, jobLine, ruleRunnersDeprecated, message)
+	}
+	if l.settings.Strict && slices.Contains(floatingRunners, label) {
+		message := fmt.Sprintf("Job '%s' uses floating runner '%s'; pin a specific image version",
+			jobID, label)
+		return newRuleIssue(file, jobLine, ruleRunnersFloating, message)
+	}
+	return nil
+}
+
+// runnerLabels extracts the runner labels from a job's runs-on field.
+// Handles the scalar, list, and group (labels:) forms, and expands
+// ${{ matrix.<name> }} references using the job's strategy.matrix.
+// Each label is returned once.
+func runnerLabels(job map[string]any) []string {
+	runsOn := job["runs-on"]
+	if group, ok := runsOn.(map[string]any); ok {
+		runsOn = group["labels"]
+	}
+
+	var labels []string
+	for _, value := range scalarOrList(runsOn) {
+		if m := matrixRefPattern.FindStringSubmatch(value); m != nil {
+			labels = append(labels, matrixValues(job, m[1])...)
+			continue
+		}
+		labels = append(labels, value)
+	}
+
+	seen := make(map[string]bool, len(labels))
+	return slices.DeleteFunc(labels, func(label string) bool {
+		duplicate := seen[label]
+		seen[label] = true
+		return duplicate
+	})
+}
+
+// scalarOrList returns the string values of a field that is either a string or a list.
+func scalarOrList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// matrixValues returns the string values for a matrix variable, including
+// values added through matrix include entries.
+func matrixValues(job map[string]any, name string) []string {
+	strategy, ok := job["strategy"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	matrix, ok := strategy["matrix"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	values := scalarOrList(matrix[name])
+	if include, ok := matrix["include"].([]any); ok {
+		for _, entry := range include {
+			if m, ok := entry.(map[string]any); ok {
+				values = append(values, scalarOrList(m[name])...)
+			}
+		}
+	}
+	return values
+}
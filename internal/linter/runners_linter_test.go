@@ -0,0 +1,144 @@
+package linter
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestRunnersLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		settings *config.RunnersSettings
+		expected []string
+	}{
+		{
+			name: "supported runner",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-24.04
+`,
+			expected: nil,
+		},
+		{
+			name: "deprecated scalar runner",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-18.04
+`,
+			expected: []string{"Job 'build' uses deprecated runner 'ubuntu-18.04'; use 'ubuntu-24.04' instead"},
+		},
+		{
+			name: "deprecated runner in list",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: [self-hosted, macos-10.15]
+`,
+			expected: []string{"Job 'build' uses deprecated runner 'macos-10.15'; use 'macos-15' instead"},
+		},
+		{
+			name: "deprecated runner in group labels",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on:
+      group: large-runners
+      labels: windows-2019
+`,
+			expected: []string{"Job 'build' uses deprecated runner 'windows-2019'; use 'windows-2025' instead"},
+		},
+		{
+			name: "deprecated runner in matrix",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    strategy:
+      matrix:
+        os: [ubuntu-22.04, ubuntu-20.04]
+        include:
+          - os: macos-12
+          - os: ubuntu-20.04
+    runs-on: ${{ matrix.os }}
+`,
+			expected: []string{
+				"Job 'build' uses deprecated runner 'ubuntu-20.04'; use 'ubuntu-24.04' instead",
+				"Job 'build' uses deprecated runner 'macos-12'; use 'macos-15' instead",
+			},
+		},
+		{
+			name: "floating runner without strict mode",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+			expected: nil,
+		},
+		{
+			name: "floating runner in strict mode",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  test:
+    runs-on: [macos-latest]
+`,
+			settings: &config.RunnersSettings{Strict: true},
+			expected: []string{
+				"Job 'build' uses floating runner 'ubuntu-latest'; pin a specific image version",
+				"Job 'test' uses floating runner 'macos-latest'; pin a specific image version",
+			},
+		},
+		{
+			name: "unresolved expression",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ${{ inputs.runner }}
+`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", tt.content)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			linter := NewRunnersLinter(tt.settings)
+			issues, err := linter.LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, issue.Message)
+				if issue.Line != wf.FindJobLine("build") && issue.Line != wf.FindJobLine("test") {
+					t.Errorf("issue line = %d, want a job line", issue.Line)
+				}
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("LintWorkflow() messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
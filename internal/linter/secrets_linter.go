@@ -2,13 +2,21 @@ package linter
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strings"
 
+	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/stringutil"
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
+// Rules reported by the secrets linter.
+const (
+	ruleSecretsPattern = "secrets/known-pattern"
+	ruleSecretsEntropy = "secrets/high-entropy"
+)
+
 // secretPattern defines a regex pattern for detecting potential hardcoded secrets.
 type secretPattern struct {
 	re   *regexp.Regexp
@@ -29,14 +37,37 @@ var secretPatterns = []secretPattern{
 	{regexp.MustCompile(`(?i)(key|credential|auth)\s*[:=]\s*['"]?[a-zA-Z0-9+/=]{32,}['"]?`), "Potential credential"},
 }
 
+// quotedStringPattern matches single- or double-quoted strings, capturing their contents.
+var quotedStringPattern = regexp.MustCompile(`'([^']*)'|"([^"]*)"`)
+
+// tokenPattern matches strings made only of base64, URL-safe base64, or hex characters.
+var tokenPattern = regexp.MustCompile(`^[A-Za-z0-9+/=_-]+$`)
+
+// hexPattern matches strings made only of hex characters.
+var hexPattern = regexp.MustCompile(`^[A-Fa-f0-9]+$`)
+
+// checksumKeyPattern matches lines whose key names a checksum or digest (e.g., "sha256:", "file-hash:").
+var checksumKeyPattern = regexp.MustCompile(`(?i)^\s*(?:-\s+)?[\w-]*` +
+	`(checksum|digest|hash|sha\d+|md5|integrity)[\w-]*\s*:`)
+
+// placeholderWords mark a value as a documented example rather than a real secret.
+var placeholderWords = []string{"example", "changeme", "placeholder", "dummy"}
+
+// hexEntropyFactor scales the entropy threshold for hex strings: log2(16) / log2(64).
+const hexEntropyFactor = 4.0 / 6.0
+
 // SecretsLinter checks for hardcoded secrets in workflow files.
 type SecretsLinter struct {
 	noOpFixer
+	settings *config.SecretsSettings
 }
 
 // NewSecretsLinter creates a new SecretsLinter instance.
-func NewSecretsLinter() *SecretsLinter {
-	return &SecretsLinter{}
+func NewSecretsLinter(settings *config.SecretsSettings) *SecretsLinter {
+	if settings == nil {
+		settings = config.DefaultSecretsSettings()
+	}
+	return &SecretsLinter{settings: settings}
 }
 
 // LintWorkflow checks a single workflow for hardcoded secrets.
@@ -66,15 +97,105 @@ func (l *SecretsLinter) checkLine(file string, lineNum int, line string) *Issue
 	}
 
 	for _, p := range secretPatterns {
-		if p.re.MatchString(line) {
-			message := fmt.Sprintf("Potential hardcoded %s detected", p.name)
-			return newIssue(file, lineNum, message)
+		loc := p.re.FindStringIndex(line)
+		if loc == nil || l.isAllowed(assignedValue(line[loc[0]:loc[1]])) {
+			continue
 		}
+		message := fmt.Sprintf("Potential hardcoded %s detected", p.name)
+		return newRuleIssueAt(file, lineNum, loc[0]+1, ruleSecretsPattern, message)
+	}
+
+	if entropy, column, ok := l.highEntropyString(line); ok {
+		message := fmt.Sprintf("Possible secret: high-entropy string (%.2f bits per character)", entropy)
+		return newRuleIssueAt(file, lineNum, column, ruleSecretsEntropy, message)
 	}
 
 	return nil
 }
 
+// highEntropyString returns the entropy and 1-based column of the first quoted string
+// on the line that looks like a random token, and false if there is none. Values of
+// checksum-like keys (e.g., sha256:) are skipped, since digests are random by design.
+func (l *SecretsLinter) highEntropyString(line string) (float64, int, bool) {
+	if l.settings.MinEntropy <= 0 || checksumKeyPattern.MatchString(line) {
+		return 0, 0, false
+	}
+
+	for _, loc := range quotedStringPattern.FindAllStringSubmatchIndex(line, -1) {
+		// The value starts after the opening quote
+		value := line[loc[0]+1 : loc[1]-1]
+		if len(value) < l.settings.MinLength || !tokenPattern.MatchString(value) || l.isAllowed(value) {
+			continue
+		}
+
+		threshold := l.settings.MinEntropy
+		if hexPattern.MatchString(value) {
+			threshold *= hexEntropyFactor
+		}
+		if entropy := shannonEntropy(value); entropy > threshold {
+			return entropy, loc[0] + 2, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// isAllowed reports whether value is an obvious placeholder or contains an entry
+// of the configured allow list (case-insensitive).
+func (l *SecretsLinter) isAllowed(value string) bool {
+	lower := strings.ToLower(value)
+	for _, allowed := range l.settings.Allow {
+		if allowed != "" && strings.Contains(lower, strings.ToLower(allowed)) {
+			return true
+		}
+	}
+	return isPlaceholder(lower)
+}
+
+// isPlaceholder reports whether a lowercase value is an obvious placeholder: it names
+// itself as one (e.g., "changeme_example"), or repeats a single character apart from
+// separators (e.g., "xxxxxxxx" or "0000-0000").
+func isPlaceholder(value string) bool {
+	for _, word := range placeholderWords {
+		if strings.Contains(value, word) {
+			return true
+		}
+	}
+
+	chars := strings.Map(func(r rune) rune {
+		if strings.ContainsRune("-_.*", r) {
+			return -1
+		}
+		return r
+	}, value)
+	return chars != "" && strings.Count(chars, chars[:1]) == len(chars)
+}
+
+// assignedValue returns the value of a "key: value" or "key=value" match, without
+// surrounding quotes. Matches without an assignment are returned unchanged.
+func assignedValue(match string) string {
+	if i := strings.IndexAny(match, ":="); i >= 0 {
+		match = match[i+1:]
+	}
+	return strings.Trim(strings.TrimSpace(match), `'"`)
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var entropy float64
+	n := float64(len(s))
+	for _, count := range counts {
+		p := float64(count) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
 // isSecretReference returns true if the line references secrets via GitHub Actions expressions.
 func isSecretReference(line string) bool {
 	if !strings.Contains(line, "${{") {
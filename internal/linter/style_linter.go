@@ -2,14 +2,49 @@ package linter
 
 import (
 	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 
+	"github.com/reugn/github-ci/internal/actions"
 	"github.com/reugn/github-ci/internal/config"
 	"github.com/reugn/github-ci/internal/stringutil"
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
+// Rules reported by the style linter.
+const (
+	ruleStyleMissingName           = "style/missing-name"
+	ruleStyleNameLength            = "style/name-length"
+	ruleStyleNamingConvention      = "style/naming-convention"
+	ruleStyleNameFirst             = "style/name-first"
+	ruleStyleDefaultWorkflowName   = "style/default-workflow-name"
+	ruleStyleDuplicateWorkflowName = "style/duplicate-workflow-name"
+	ruleStyleCrypticJobID          = "style/cryptic-job-id"
+	ruleStyleJobIDCasing           = "style/job-id-casing"
+	ruleStyleDuplicateStepID       = "style/duplicate-step-id"
+	ruleStyleMissingTimeout        = "style/missing-timeout"
+	ruleStyleMaxTimeout            = "style/max-timeout"
+	ruleStyleMaxJobs               = "style/max-jobs"
+	ruleStyleConstantConcurrency   = "style/constant-concurrency"
+	ruleStyleMissingConcurrency    = "style/missing-concurrency"
+	ruleStyleCheckoutFirst         = "style/checkout-first"
+	ruleStyleRunLength             = "style/run-length"
+	ruleStyleWorkingDirectory      = "style/working-directory"
+	ruleStylePipefail              = "style/pipefail"
+	ruleStyleEnvShadowing          = "style/env-shadowing"
+	ruleStyleEnvName               = "style/env-name"
+	ruleStyleTokenOverride         = "style/token-override"
+	ruleStyleUnavailableEnv        = "style/unavailable-env"
+	ruleStyleActionCasing          = "style/action-casing"
+	ruleStyleMovedAction           = "style/moved-action"
+	ruleStyleFetchDepth            = "style/fetch-depth"
+)
+
 // Context labels for style issue messages.
 const (
 	ctxWorkflow = "Workflow"
@@ -17,9 +52,34 @@ const (
 	ctxStep     = "Step"
 )
 
+// envNamePattern matches env variable names that are valid in shells.
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envRefPattern matches env context property references (e.g., env.FOO) in expressions,
+// but not properties of other objects (e.g., steps.x.outputs.env).
+var envRefPattern = regexp.MustCompile(`(?:^|[^\w.])env\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// githubEnvFile is the variable naming the file that run steps write to export env variables.
+const githubEnvFile = "GITHUB_ENV"
+
+// githubTokenEnv is the env variable name actions read the workflow token from.
+const githubTokenEnv = "GITHUB_TOKEN"
+
+// pipePattern matches a shell pipe, but not the || operator.
+var pipePattern = regexp.MustCompile(`(?:^|[^|])\|(?:[^|]|$)`)
+
+// quotedPattern matches single- or double-quoted shell strings.
+var quotedPattern = regexp.MustCompile(`'[^']*'|"(?:[^"\\]|\\.)*"`)
+
+// defaultTokenPattern matches expressions that resolve to the default workflow token.
+var defaultTokenPattern = regexp.MustCompile(`^\$\{\{\s*(secrets\.GITHUB_TOKEN|github\.token)\s*\}\}$`)
+
+// stepKeyOrder is the canonical order of step keys applied by the fixer.
+// uses and run are mutually exclusive, so they share a position in practice.
+var stepKeyOrder = []string{"name", "id", "if", "uses", "run", "with", "env"}
+
 // StyleLinter checks for style and naming convention issues in workflow files.
 type StyleLinter struct {
-	noOpFixer
 	settings *config.StyleSettings
 }
 
@@ -36,24 +96,293 @@ func (l *StyleLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
 	var issues []*Issue
 	file := wf.BaseName()
 
+	model, err := wf.Model()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
 	// Check workflow-level issues
 	issues = append(issues, l.checkWorkflowName(wf, file)...)
 
 	// Check job-level issues
-	issues = append(issues, l.checkJobs(wf, file)...)
+	issues = append(issues, l.checkJobs(wf, model, file)...)
+	issues = append(issues, checkJobIDCasing(model, file)...)
+	if l.settings.WarnConstantConcurrency {
+		issues = append(issues, checkConstantConcurrency(wf, file)...)
+	}
+	if l.settings.RequireConcurrency {
+		if issue := checkMissingConcurrency(wf, file); issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+	if issue := l.checkJobCount(wf, file); issue != nil {
+		issues = append(issues, issue)
+	}
 
 	// Check env consistency
 	issues = append(issues, l.checkEnvConsistency(wf, file)...)
 
+	// Check env variable names and values
+	envIssues, err := l.checkEnvVars(wf, file)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, envIssues...)
+
+	// Check env references in if conditions
+	condIssues, err := checkConditionEnvRefs(wf, file)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, condIssues...)
+
+	// Check for archived or renamed actions
+	movedIssues, err := l.checkMovedActions(wf, file)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, movedIssues...)
+
+	// Check checkout steps of workflows that need the full history
+	fetchIssues, err := l.checkFetchDepth(wf, file)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, fetchIssues...)
+
 	return issues, nil
 }
 
+// FixWorkflow reorders step keys into the canonical order, if fix-step-key-order
+// is enabled. Other style issues can't be fixed automatically.
+func (l *StyleLinter) FixWorkflow(wf *workflow.Workflow) error {
+	if !l.settings.FixStepKeyOrder {
+		return nil
+	}
+	if _, err := wf.SortStepKeys(stepKeyOrder); err != nil {
+		return fmt.Errorf("failed to sort step keys: %w", err)
+	}
+	return nil
+}
+
+// LintWorkflows checks for style issues that span multiple workflows.
+func (l *StyleLinter) LintWorkflows(workflows []*workflow.Workflow) ([]*Issue, error) {
+	issues, err := l.checkActionCasing(workflows)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.settings.DistinctWorkflowNames {
+		issues = append(issues, l.checkDuplicateWorkflowNames(workflows)...)
+	}
+
+	return issues, nil
+}
+
+// checkActionCasing reports actions referenced with different owner/repo casing
+// across files, which fragments the version cache and confuses tooling.
+func (l *StyleLinter) checkActionCasing(workflows []*workflow.Workflow) ([]*Issue, error) {
+	usages, order, err := collectActionSpellings(workflows)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*Issue
+	for _, key := range order {
+		bySpelling := usages[key]
+		if len(bySpelling) < 2 {
+			continue
+		}
+
+		canonical := dominantSpelling(bySpelling)
+		summary := describeSpellings(bySpelling)
+		for _, spelling := range slices.Sorted(maps.Keys(bySpelling)) {
+			if spelling == canonical {
+				continue
+			}
+			first := bySpelling[spelling][0]
+			message := fmt.Sprintf("Action %s has inconsistent casing across workflows: %s",
+				canonical, summary)
+			issue := newRuleIssue(first.file, first.line, ruleStyleActionCasing, message)
+			issue.FullPath = first.path
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+// checkMovedActions reports actions that were archived or renamed, suggesting their successor.
+func (l *StyleLinter) checkMovedActions(wf *workflow.Workflow, file string) ([]*Issue, error) {
+	workflowActions, err := wf.FindActions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find actions: %w", err)
+	}
+
+	var issues []*Issue
+	for _, action := range workflowActions {
+		info, err := actions.ParseActionUses(action.Uses)
+		if err != nil {
+			continue
+		}
+		if successor, ok := actions.Successor(info.Name()); ok {
+			message := fmt.Sprintf("Action %s is archived or has moved; use %s instead", info.Name(), successor)
+			issues = append(issues, newRuleIssue(file, action.Line, ruleStyleMovedAction, message))
+		}
+	}
+
+	return issues, nil
+}
+
+// checkFetchDepth reports actions/checkout steps that don't set fetch-depth in workflows
+// matching full-history-workflows, which need more than the default shallow clone
+// (e.g., to generate a changelog from tags).
+func (l *StyleLinter) checkFetchDepth(wf *workflow.Workflow, file string) ([]*Issue, error) {
+	if !l.needsFullHistory(wf) {
+		return nil, nil
+	}
+
+	workflowActions, err := wf.FindActions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find actions: %w", err)
+	}
+
+	var issues []*Issue
+	for _, action := range workflowActions {
+		name, _, _ := strings.Cut(action.Uses, "@")
+		if !strings.EqualFold(name, "actions/checkout") {
+			continue
+		}
+		if _, ok := action.Input("fetch-depth"); ok {
+			continue
+		}
+		issues = append(issues, newRuleIssue(file, action.Line, ruleStyleFetchDepth,
+			"Checkout step should set 'fetch-depth' (e.g., 0 for the full history); "+
+				"the default fetches only the latest commit"))
+	}
+	return issues, nil
+}
+
+// needsFullHistory returns true if the workflow name or file name matches one of the
+// full-history-workflows patterns.
+func (l *StyleLinter) needsFullHistory(wf *workflow.Workflow) bool {
+	var name string
+	if wf.Content != nil {
+		name = wf.Content.Name
+	}
+	for _, pattern := range l.settings.FullHistoryWorkflows {
+		if matchesPattern(pattern, name) || matchesPattern(pattern, wf.BaseName()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern returns true if value is not empty and matches the path.Match pattern.
+func matchesPattern(pattern, value string) bool {
+	matched, _ := path.Match(pattern, value)
+	return matched && value != ""
+}
+
+// checkDuplicateWorkflowNames reports workflows whose name (case-insensitive)
+// is already used by another workflow, which makes runs indistinguishable in the UI.
+func (l *StyleLinter) checkDuplicateWorkflowNames(workflows []*workflow.Workflow) []*Issue {
+	var issues []*Issue
+	seen := make(map[string]string) // lowercased name -> first file
+
+	for _, wf := range workflows {
+		if wf.Content == nil || wf.Content.Name == "" {
+			continue
+		}
+
+		key := strings.ToLower(wf.Content.Name)
+		if first, ok := seen[key]; ok {
+			message := fmt.Sprintf("Workflow name '%s' is already used by %s", wf.Content.Name, first)
+			issue := newRuleIssue(wf.BaseName(), 1, ruleStyleDuplicateWorkflowName, message)
+			issue.FullPath = wf.File
+			issues = append(issues, issue)
+			continue
+		}
+		seen[key] = wf.BaseName()
+	}
+
+	return issues
+}
+
+// actionUsage records where a particular spelling of an action is referenced.
+type actionUsage struct {
+	file string // File name, as shown in messages
+	path string // Path of the workflow as loaded
+	line int
+}
+
+// collectActionSpellings groups action references by case-insensitive name.
+// Returns a map of normalized name to spelling to usages, and the normalized
+// names in discovery order.
+func collectActionSpellings(workflows []*workflow.Workflow) (map[string]map[string][]actionUsage, []string, error) {
+	usages := make(map[string]map[string][]actionUsage)
+	var order []string
+
+	for _, wf := range workflows {
+		wfActions, err := wf.FindActions()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find actions in %s: %w", wf.File, err)
+		}
+
+		for _, action := range wfActions {
+			info, err := actions.ParseActionUses(action.Uses)
+			if err != nil {
+				continue
+			}
+
+			name := info.Name()
+			key := strings.ToLower(name)
+			if usages[key] == nil {
+				usages[key] = make(map[string][]actionUsage)
+				order = append(order, key)
+			}
+			usages[key][name] = append(usages[key][name], actionUsage{file: wf.BaseName(), path: wf.File, line: action.Line})
+		}
+	}
+
+	return usages, order, nil
+}
+
+// dominantSpelling returns the most frequently used spelling.
+// Ties are broken by lexical order to keep output deterministic.
+func dominantSpelling(bySpelling map[string][]actionUsage) string {
+	var best string
+	for _, spelling := range slices.Sorted(maps.Keys(bySpelling)) {
+		if best == "" || len(bySpelling[spelling]) > len(bySpelling[best]) {
+			best = spelling
+		}
+	}
+	return best
+}
+
+// describeSpellings formats each spelling with the files that use it,
+// e.g., "actions/checkout (a.yml, c.yml), Actions/Checkout (b.yml)".
+func describeSpellings(bySpelling map[string][]actionUsage) string {
+	parts := make([]string, 0, len(bySpelling))
+	for _, spelling := range slices.Sorted(maps.Keys(bySpelling)) {
+		var files []string
+		for _, u := range bySpelling[spelling] {
+			if !slices.Contains(files, u.file) {
+				files = append(files, u.file)
+			}
+		}
+		slices.Sort(files)
+		parts = append(parts, fmt.Sprintf("%s (%s)", spelling, strings.Join(files, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // checkWorkflowName checks for missing or invalid workflow name.
 func (l *StyleLinter) checkWorkflowName(wf *workflow.Workflow, file string) []*Issue {
 	var issues []*Issue
 
 	if wf.Content == nil || wf.Content.Name == "" {
-		issues = append(issues, newIssue(file, 1, "Workflow is missing a name"))
+		issues = append(issues, newRuleIssue(file, 1, ruleStyleMissingName, "Workflow is missing a name"))
 	} else {
 		if issue := l.checkNameLength(wf.Content.Name, file, 1, ctxWorkflow); issue != nil {
 			issues = append(issues, issue)
@@ -61,13 +390,25 @@ func (l *StyleLinter) checkWorkflowName(wf *workflow.Workflow, file string) []*I
 		if issue := l.checkNamingConvention(wf.Content.Name, file, 1, ctxWorkflow); issue != nil {
 			issues = append(issues, issue)
 		}
+		if l.settings.DistinctWorkflowNames && wf.IsReusable() && isFileNameDefault(wf.Content.Name, file) {
+			message := fmt.Sprintf("Reusable workflow name '%s' only repeats the file name; "+
+				"use a descriptive name so callers see meaningful run names", wf.Content.Name)
+			issues = append(issues, newRuleIssue(file, 1, ruleStyleDefaultWorkflowName, message))
+		}
 	}
 
 	return issues
 }
 
+// isFileNameDefault returns true if name is just the workflow file name,
+// with or without its extension (what the Actions UI shows when name is missing).
+func isFileNameDefault(name, file string) bool {
+	stem := strings.TrimSuffix(file, filepath.Ext(file))
+	return strings.EqualFold(name, file) || strings.EqualFold(name, stem)
+}
+
 // checkJobs checks job-level and step-level style issues.
-func (l *StyleLinter) checkJobs(wf *workflow.Workflow, file string) []*Issue {
+func (l *StyleLinter) checkJobs(wf *workflow.Workflow, model *workflow.Model, file string) []*Issue {
 	var issues []*Issue
 
 	if wf.Content == nil || wf.Content.Jobs == nil {
@@ -88,7 +429,7 @@ func (l *StyleLinter) checkJobs(wf *workflow.Workflow, file string) []*Issue {
 		// Check for cryptic job ID without explicit name
 		if jobName == "" && stringutil.IsCrypticName(jobID) {
 			message := fmt.Sprintf("Job '%s' has cryptic ID and is missing a name", jobID)
-			issues = append(issues, newIssue(file, jobLine, message))
+			issues = append(issues, newRuleIssue(file, jobLine, ruleStyleCrypticJobID, message))
 		}
 
 		// Check job name length and convention
@@ -101,31 +442,191 @@ func (l *StyleLinter) checkJobs(wf *workflow.Workflow, file string) []*Issue {
 			}
 		}
 
+		if issue := l.checkJobTimeout(job, jobID, file, jobLine); issue != nil {
+			issues = append(issues, issue)
+		}
+
 		// Check steps
-		issues = append(issues, l.checkSteps(wf, job, file, jobID)...)
+		issues = append(issues, l.checkSteps(wf, job, model.Job(jobID), file)...)
+	}
+
+	return issues
+}
+
+// checkJobTimeout checks that a job sets timeout-minutes, if required, and that it doesn't
+// exceed the configured maximum. Jobs calling a reusable workflow use the called workflow's
+// timeouts, and expression values can't be checked statically, so both are skipped.
+func (l *StyleLinter) checkJobTimeout(job map[string]any, jobID, file string, line int) *Issue {
+	if _, ok := job["uses"]; ok {
+		return nil
+	}
+
+	value, ok := job["timeout-minutes"]
+	if !ok {
+		if l.settings.RequireJobTimeout {
+			msg := fmt.Sprintf("Job '%s' is missing timeout-minutes; it can run for up to 6 hours", jobID)
+			return newRuleIssue(file, line, ruleStyleMissingTimeout, msg)
+		}
+		return nil
+	}
+
+	if l.settings.MaxJobTimeout <= 0 {
+		return nil
+	}
+	var minutes float64
+	switch v := value.(type) {
+	case int:
+		minutes = float64(v)
+	case float64:
+		minutes = v
+	default:
+		return nil
+	}
+	if minutes > float64(l.settings.MaxJobTimeout) {
+		msg := fmt.Sprintf("Job '%s' has timeout-minutes %v (max %d)", jobID, value, l.settings.MaxJobTimeout)
+		return newRuleIssue(file, line, ruleStyleMaxTimeout, msg)
+	}
+	return nil
+}
+
+// checkJobCount checks if a workflow has more jobs than the configured maximum.
+func (l *StyleLinter) checkJobCount(wf *workflow.Workflow, file string) *Issue {
+	if l.settings.MaxJobs <= 0 || wf.Content == nil {
+		return nil
+	}
+
+	jobCount := len(wf.Content.Jobs)
+	if jobCount > l.settings.MaxJobs {
+		msg := fmt.Sprintf("Workflow has %d jobs (max %d); consider splitting it into smaller "+
+			"or reusable workflows", jobCount, l.settings.MaxJobs)
+		return newRuleIssue(file, 1, ruleStyleMaxJobs, msg)
+	}
+
+	return nil
+}
+
+// checkConstantConcurrency reports workflow- and job-level concurrency groups without
+// expressions. Groups are shared across the repository, so a constant group queues or
+// cancels runs of every branch and pull request together.
+func checkConstantConcurrency(wf *workflow.Workflow, file string) []*Issue {
+	var issues []*Issue
+
+	if wf.Content == nil {
+		return issues
+	}
+
+	if group, ok := constantConcurrencyGroup(wf.Content.Concurrency); ok {
+		message := fmt.Sprintf("Workflow concurrency group '%s' is constant and serializes runs "+
+			"repository-wide; include ${{ github.workflow }} and ${{ github.ref }}", group)
+		issues = append(issues, newRuleIssue(file, wf.FindConcurrencyLine(""), ruleStyleConstantConcurrency, message))
+	}
+
+	for _, jobID := range slices.Sorted(maps.Keys(wf.Content.Jobs)) {
+		job, ok := wf.Content.Jobs[jobID].(map[string]any)
+		if !ok {
+			continue
+		}
+		if group, ok := constantConcurrencyGroup(job["concurrency"]); ok {
+			message := fmt.Sprintf("Job '%s' concurrency group '%s' is constant and serializes runs "+
+				"repository-wide; include ${{ github.workflow }} and ${{ github.ref }}", jobID, group)
+			issues = append(issues, newRuleIssue(file, wf.FindConcurrencyLine(jobID), ruleStyleConstantConcurrency, message))
+		}
 	}
 
 	return issues
 }
 
-// checkSteps checks step-level style issues.
-func (l *StyleLinter) checkSteps(wf *workflow.Workflow, job map[string]any, file string, jobID string) []*Issue {
+// concurrencyEvents are the triggers whose rapid successive runs supersede each other.
+var concurrencyEvents = []string{"push", "pull_request"}
+
+// checkMissingConcurrency reports a workflow triggered by push or pull_request without a
+// concurrency group at the workflow or job level, so superseded runs are never cancelled.
+// The issue is reported at the first such trigger.
+func checkMissingConcurrency(wf *workflow.Workflow, file string) *Issue {
+	if wf.Content == nil || wf.Content.Concurrency != nil {
+		return nil
+	}
+	for _, jobData := range wf.Content.Jobs {
+		if job, ok := jobData.(map[string]any); ok && job["concurrency"] != nil {
+			return nil
+		}
+	}
+
+	for _, event := range concurrencyEvents {
+		if !wf.HasTrigger(event) {
+			continue
+		}
+		message := fmt.Sprintf("Workflow triggered by %s has no concurrency group, so superseded runs "+
+			"keep running; add concurrency with group ${{ github.workflow }}-${{ github.ref }} "+
+			"and cancel-in-progress: true", event)
+		return newRuleIssue(file, wf.FindTriggerLine(event), ruleStyleMissingConcurrency, message)
+	}
+	return nil
+}
+
+// constantConcurrencyGroup returns the group of a concurrency value, given either as
+// a group name or as a mapping with a group key, and true if it has no expression.
+func constantConcurrencyGroup(concurrency any) (string, bool) {
+	if m, ok := concurrency.(map[string]any); ok {
+		concurrency = m["group"]
+	}
+	group, ok := concurrency.(string)
+	if !ok || group == "" || strings.Contains(group, "${{") {
+		return "", false
+	}
+	return group, true
+}
+
+// checkJobIDCasing reports job IDs that differ from an earlier job ID only by case,
+// which is confusing and easy to mix up when referenced from needs.
+func checkJobIDCasing(model *workflow.Model, file string) []*Issue {
+	// Jobs are in file order, so the later of two colliding jobs is reported
+	var issues []*Issue
+	seen := make(map[string]string) // lowercased ID -> first job ID
+	for _, job := range model.Jobs {
+		key := strings.ToLower(job.ID)
+		if first, ok := seen[key]; ok {
+			message := fmt.Sprintf("Job ID '%s' differs only in case from job '%s'", job.ID, first)
+			issues = append(issues, newRuleIssue(file, job.Line, ruleStyleJobIDCasing, message))
+			continue
+		}
+		seen[key] = job.ID
+	}
+
+	return issues
+}
+
+// checkSteps checks step-level style issues. jobModel holds the lines and key order of the steps.
+func (l *StyleLinter) checkSteps(wf *workflow.Workflow, job map[string]any, jobModel *workflow.Job,
+	file string) []*Issue {
 	var issues []*Issue
 
 	stepsData, ok := job["steps"].([]any)
-	if !ok {
+	if !ok || jobModel == nil {
 		return issues
 	}
 
-	lines := wf.Lines()
+	jobID := jobModel.ID
 	checkoutFound := false
+	stepIDs := make(map[string]bool)
+	defaultShell := l.settings.RequirePipefail && usesDefaultPosixShell(wf, job)
 	for i, stepData := range stepsData {
 		step, ok := stepData.(map[string]any)
-		if !ok {
+		stepModel := jobModel.Step(i)
+		if !ok || stepModel == nil {
 			continue
 		}
 
-		stepLine := wf.FindStepLine(jobID, i)
+		stepLine := stepModel.Line
+
+		// Check for duplicate step IDs, which make steps.<id> references ambiguous
+		if stepID, _ := step["id"].(string); stepID != "" {
+			if stepIDs[stepID] {
+				message := fmt.Sprintf("Step ID '%s' is already used by another step in job '%s'", stepID, jobID)
+				issues = append(issues, newRuleIssue(file, stepLine, ruleStyleDuplicateStepID, message))
+			}
+			stepIDs[stepID] = true
+		}
 
 		// Get step properties
 		stepName, _ := step["name"].(string)
@@ -134,7 +635,7 @@ func (l *StyleLinter) checkSteps(wf *workflow.Workflow, job map[string]any, file
 		// Check missing step name (only if configured)
 		if stepName == "" {
 			if l.settings.RequireStepNames {
-				issues = append(issues, newIssue(file, stepLine, "Step is missing a name"))
+				issues = append(issues, newRuleIssue(file, stepLine, ruleStyleMissingName, "Step is missing a name"))
 			}
 		} else {
 			if issue := l.checkNameLength(stepName, file, stepLine, ctxStep); issue != nil {
@@ -150,7 +651,7 @@ func (l *StyleLinter) checkSteps(wf *workflow.Workflow, job map[string]any, file
 			isCheckout := strings.Contains(stepUses, "actions/checkout")
 			if isCheckout && !checkoutFound && i > 0 {
 				message := "Checkout action should typically be the first step"
-				issues = append(issues, newIssue(file, stepLine, message))
+				issues = append(issues, newRuleIssue(file, stepLine, ruleStyleCheckoutFirst, message))
 			}
 			if isCheckout {
 				checkoutFound = true
@@ -158,7 +659,12 @@ func (l *StyleLinter) checkSteps(wf *workflow.Workflow, job map[string]any, file
 		}
 
 		// Check if name comes first in step
-		if issue := l.checkNameFirst(lines, stepLine, file); issue != nil {
+		if issue := checkNameFirst(stepModel, file); issue != nil {
+			issues = append(issues, issue)
+		}
+
+		// Check working directories that escape the workspace
+		if issue := l.checkWorkingDirectory(step, file, stepLine); issue != nil {
 			issues = append(issues, issue)
 		}
 
@@ -166,6 +672,13 @@ func (l *StyleLinter) checkSteps(wf *workflow.Workflow, job map[string]any, file
 		if issue := l.checkRunLength(step, file, stepLine); issue != nil {
 			issues = append(issues, issue)
 		}
+
+		// Check piped run scripts under the default shell (configurable)
+		if defaultShell {
+			if issue := l.checkPipefail(step, file, stepLine); issue != nil {
+				issues = append(issues, issue)
+			}
+		}
 	}
 
 	return issues
@@ -195,7 +708,7 @@ func (l *StyleLinter) checkEnvConsistency(wf *workflow.Workflow, file string) []
 		for varName := range jobEnv {
 			if workflowEnv[varName] {
 				message := fmt.Sprintf("Job env var '%s' shadows workflow-level env var", varName)
-				issues = append(issues, newIssue(file, jobLine, message))
+				issues = append(issues, newRuleIssue(file, jobLine, ruleStyleEnvShadowing, message))
 			}
 		}
 	}
@@ -203,6 +716,137 @@ func (l *StyleLinter) checkEnvConsistency(wf *workflow.Workflow, file string) []
 	return issues
 }
 
+// checkEnvVars checks env variables declared at the workflow, job, and step levels.
+func (l *StyleLinter) checkEnvVars(wf *workflow.Workflow, file string) ([]*Issue, error) {
+	vars, err := wf.EnvVars()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract env variables: %w", err)
+	}
+
+	var issues []*Issue
+	for _, v := range vars {
+		if issue := checkEnvName(v, file); issue != nil {
+			issues = append(issues, issue)
+		}
+		if l.settings.WarnTokenOverride {
+			if issue := checkTokenOverride(v, file); issue != nil {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// checkConditionEnvRefs reports if conditions referencing env variables that are not
+// available there. The env context doesn't exist in job-level if conditions, and a step-level
+// if only sees workflow, job, and its own step env. To limit false positives, a step-level
+// reference is reported only if the variable is declared elsewhere in the workflow (e.g., in a
+// later step) and no earlier run step in the job writes to GITHUB_ENV.
+func checkConditionEnvRefs(wf *workflow.Workflow, file string) ([]*Issue, error) {
+	conditions, err := wf.Conditions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract if conditions: %w", err)
+	}
+	vars, err := wf.EnvVars()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract env variables: %w", err)
+	}
+
+	// Expression property names are case-insensitive
+	declared := make(map[string]bool)
+	for _, v := range vars {
+		declared[strings.ToLower(v.Name)] = true
+	}
+	visible := func(name string, cond *workflow.Condition) bool {
+		for _, v := range vars {
+			if !strings.EqualFold(v.Name, name) {
+				continue
+			}
+			switch {
+			case v.Level == workflow.EnvLevelWorkflow:
+				return true
+			case v.JobID != cond.JobID:
+			case v.Level == workflow.EnvLevelJob || v.Step == cond.Step:
+				return true
+			}
+		}
+		return false
+	}
+
+	var issues []*Issue
+	for _, cond := range conditions {
+		seen := make(map[string]bool)
+		for _, match := range envRefPattern.FindAllStringSubmatch(cond.Expr, -1) {
+			name := match[1]
+			if seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+
+			var message string
+			switch {
+			case cond.Step < 0:
+				message = fmt.Sprintf("Job '%s' if condition references env.%s, but the env context is not "+
+					"available in job-level if; use vars or job outputs instead", cond.JobID, name)
+			case declared[strings.ToLower(name)] && !visible(name, cond) &&
+				!writesGitHubEnvBefore(wf, cond.JobID, cond.Step):
+				message = fmt.Sprintf("Step if condition references env.%s, which is only declared "+
+					"in other steps or jobs and is not available here", name)
+			}
+			if issue := newRuleIssue(file, cond.Line, ruleStyleUnavailableEnv, message); issue != nil {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// writesGitHubEnvBefore reports whether a run step before the given step index in the job
+// mentions GITHUB_ENV, which can export env variables to the following steps.
+func writesGitHubEnvBefore(wf *workflow.Workflow, jobID string, stepIdx int) bool {
+	if wf.Content == nil {
+		return false
+	}
+	job, _ := wf.Content.Jobs[jobID].(map[string]any)
+	steps, _ := job["steps"].([]any)
+	for _, stepData := range steps[:min(stepIdx, len(steps))] {
+		step, _ := stepData.(map[string]any)
+		if run, _ := step["run"].(string); strings.Contains(run, githubEnvFile) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkEnvName reports env variables whose names are not valid shell identifiers
+// (e.g., containing hyphens or starting with a digit), which shells silently ignore.
+func checkEnvName(v *workflow.EnvVar, file string) *Issue {
+	if envNamePattern.MatchString(v.Name) {
+		return nil
+	}
+	message := fmt.Sprintf("Env var '%s' has an invalid name; use letters, digits, and underscores, "+
+		"not starting with a digit", v.Name)
+	return newRuleIssue(file, v.Line, ruleStyleEnvName, message)
+}
+
+// checkTokenOverride reports workflow- or job-level env that replaces GITHUB_TOKEN
+// with a custom value, which changes the credentials used by every action in scope.
+func checkTokenOverride(v *workflow.EnvVar, file string) *Issue {
+	if v.Name != githubTokenEnv || v.Level == workflow.EnvLevelStep || defaultTokenPattern.MatchString(v.Value) {
+		return nil
+	}
+
+	scope := "all jobs in the workflow"
+	if v.Level == workflow.EnvLevelJob {
+		scope = fmt.Sprintf("all steps in job '%s'", v.JobID)
+	}
+	message := fmt.Sprintf("Env overrides %s with a custom value; %s will authenticate with it "+
+		"instead of the default token, so scope it to the steps that need it", githubTokenEnv, scope)
+	return newRuleIssue(file, v.Line, ruleStyleTokenOverride, message)
+}
+
 // checkNameLength validates name length.
 func (l *StyleLinter) checkNameLength(name, file string, line int, context string) *Issue {
 	var msg string
@@ -212,7 +856,7 @@ func (l *StyleLinter) checkNameLength(name, file string, line int, context strin
 		msg = fmt.Sprintf("%s name exceeds maximum length of %d characters", context, l.settings.MaxNameLength)
 	}
 
-	return newIssue(file, line, msg)
+	return newRuleIssue(file, line, ruleStyleNameLength, msg)
 }
 
 // checkNamingConvention validates naming convention.
@@ -243,73 +887,129 @@ func (l *StyleLinter) checkNamingConvention(name, file string, line int, context
 		}
 	}
 
-	return newIssue(file, line, msg)
+	return newRuleIssue(file, line, ruleStyleNamingConvention, msg)
 }
 
 // checkNameFirst checks if 'name:' comes first in a step definition.
-func (l *StyleLinter) checkNameFirst(lines []string, stepLine int, file string) *Issue {
-	if stepLine <= 0 || stepLine > len(lines) {
+func checkNameFirst(step *workflow.Step, file string) *Issue {
+	if !slices.Contains(step.Keys, "name") || step.Keys[0] == "name" {
 		return nil
 	}
+	return newRuleIssue(file, step.Line, ruleStyleNameFirst, "Step 'name' should come first before other fields")
+}
 
-	// Find the step start (line with '- ')
-	idx := stepLine - 1
-	if idx >= len(lines) {
+// checkRunLength checks if a run script exceeds the maximum line count.
+func (l *StyleLinter) checkRunLength(step map[string]any, file string, line int) *Issue {
+	if l.settings.MaxRunLines <= 0 {
 		return nil
 	}
 
-	line := lines[idx]
-	trimmed := strings.TrimSpace(line)
-
-	// If step starts with '- name:', it's correct
-	if strings.HasPrefix(trimmed, "- name:") {
+	runScript, ok := step["run"].(string)
+	if !ok || runScript == "" {
 		return nil
 	}
 
-	// If step starts with something else and has a name somewhere
-	if strings.HasPrefix(trimmed, "- ") && !strings.HasPrefix(trimmed, "- name:") {
-		// Look ahead for 'name:' in this step
-		indent := stringutil.CountLeadingSpaces(line)
-		for i := idx + 1; i < len(lines) && i < idx+10; i++ {
-			nextLine := lines[i]
-			nextIndent := stringutil.CountLeadingSpaces(nextLine)
-			nextTrimmed := strings.TrimSpace(nextLine)
+	lineCount := strings.Count(strings.TrimSpace(runScript), "\n") + 1
+	if lineCount > l.settings.MaxRunLines {
+		msg := fmt.Sprintf("Run script has %d lines (max %d); consider extracting to a script file",
+			lineCount, l.settings.MaxRunLines)
+		return newRuleIssue(file, line, ruleStyleRunLength, msg)
+	}
+
+	return nil
+}
 
-			// Stop if we hit another step or less indented line
-			if strings.HasPrefix(nextTrimmed, "- ") || nextIndent <= indent {
-				break
-			}
+// checkWorkingDirectory checks if a step's working-directory uses ".." segments to
+// reach above the workspace root, unless the path is allowed by configuration.
+func (l *StyleLinter) checkWorkingDirectory(step map[string]any, file string, line int) *Issue {
+	dir, ok := step["working-directory"].(string)
+	if !ok || strings.Contains(dir, "${{") {
+		return nil
+	}
 
-			if strings.HasPrefix(nextTrimmed, "name:") {
-				return newIssue(file, stepLine, "Step 'name' should come first before other fields")
-			}
+	// Working directories are relative to the workspace; Windows runners accept backslashes
+	cleaned := path.Clean(strings.ReplaceAll(dir, "\\", "/"))
+	if path.IsAbs(cleaned) || (cleaned != ".." && !strings.HasPrefix(cleaned, "../")) {
+		return nil
+	}
+
+	for _, allowed := range l.settings.AllowedWorkingDirectories {
+		allowed = path.Clean(allowed)
+		if cleaned == allowed || strings.HasPrefix(cleaned, allowed+"/") {
+			return nil
 		}
 	}
 
-	return nil
+	msg := fmt.Sprintf("Step working-directory '%s' points outside the workspace", dir)
+	return newRuleIssue(file, line, ruleStyleWorkingDirectory, msg)
 }
 
-// checkRunLength checks if a run script exceeds the maximum line count.
-func (l *StyleLinter) checkRunLength(step map[string]any, file string, line int) *Issue {
-	if l.settings.MaxRunLines <= 0 {
+// checkPipefail checks if a multi-line run script with pipes relies on the default
+// shell (bash -e), where a failing command before a pipe doesn't fail the step.
+func (l *StyleLinter) checkPipefail(step map[string]any, file string, line int) *Issue {
+	if shell, _ := step["shell"].(string); shell != "" {
 		return nil
 	}
 
 	runScript, ok := step["run"].(string)
-	if !ok || runScript == "" {
+	if !ok || strings.Contains(runScript, "pipefail") {
 		return nil
 	}
 
-	lineCount := strings.Count(strings.TrimSpace(runScript), "\n") + 1
-	if lineCount > l.settings.MaxRunLines {
-		msg := fmt.Sprintf("Run script has %d lines (max %d); consider extracting to a script file",
-			lineCount, l.settings.MaxRunLines)
-		return newIssue(file, line, msg)
+	var commands []string
+	for _, cmd := range strings.Split(runScript, "\n") {
+		if cmd = strings.TrimSpace(cmd); cmd != "" && !strings.HasPrefix(cmd, "#") {
+			commands = append(commands, cmd)
+		}
+	}
+	if len(commands) < 2 {
+		return nil
+	}
+
+	for _, cmd := range commands {
+		cmd = quotedPattern.ReplaceAllString(cmd, "")
+		if idx := strings.Index(cmd, " #"); idx != -1 {
+			cmd = cmd[:idx]
+		}
+		if pipePattern.MatchString(cmd) {
+			msg := "Run script uses pipes without pipefail; add 'set -o pipefail' " +
+				"or set 'shell: bash', which enables it"
+			return newRuleIssue(file, line, ruleStylePipefail, msg)
+		}
 	}
 
 	return nil
 }
 
+// usesDefaultPosixShell returns true if run steps in the job use the default shell
+// on a Linux or macOS runner. Runners given as expressions or groups are unknown and
+// return false, as do jobs with a default shell set at the workflow or job level.
+func usesDefaultPosixShell(wf *workflow.Workflow, job map[string]any) bool {
+	if defaultRunShell(job["defaults"]) != "" ||
+		(wf.Content != nil && defaultRunShell(wf.Content.Defaults) != "") {
+		return false
+	}
+
+	labels := scalarOrList(job["runs-on"])
+	if len(labels) == 0 {
+		return false
+	}
+	for _, label := range labels {
+		if strings.Contains(label, "${{") || strings.Contains(strings.ToLower(label), "windows") {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultRunShell returns defaults.run.shell from a workflow or job defaults value.
+func defaultRunShell(defaults any) string {
+	d, _ := defaults.(map[string]any)
+	run, _ := d["run"].(map[string]any)
+	shell, _ := run["shell"].(string)
+	return shell
+}
+
 // extractJobEnv extracts env variable names from a job map.
 func extractJobEnv(job map[string]any) map[string]bool {
 	result := make(map[string]bool)
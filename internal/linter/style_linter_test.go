@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/testutil"
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
@@ -277,6 +279,21 @@ jobs:
         uses: actions/checkout@v3
       - name: Deploy
         run: echo "deploying"
+`,
+		},
+		{
+			name: "input called name",
+			content: `name: Build and Test
+on: push
+jobs:
+  build:
+    name: Build Project
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/upload-artifact@v4
+        with:
+          name: dist
+          path: dist/
 `,
 		},
 	}
@@ -592,6 +609,218 @@ jobs:
 	}
 }
 
+func TestStyleLinter_MaxJobs(t *testing.T) {
+	content := `name: Test Workflow
+on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+  test:
+    runs-on: ubuntu-latest
+  build:
+    runs-on: ubuntu-latest
+`
+	tests := []struct {
+		name     string
+		maxJobs  int
+		expected string
+	}{
+		{"too many jobs", 2, "Workflow has 3 jobs (max 2); consider splitting it into smaller or reusable workflows"},
+		{"exact boundary", 3, ""},
+		{"check disabled", 0, ""},
+	}
+
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := NewStyleLinter(&config.StyleSettings{MaxJobs: tt.maxJobs}).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var got *Issue
+			for _, issue := range issues {
+				if strings.HasPrefix(issue.Message, "Workflow has") {
+					got = issue
+				}
+			}
+
+			if tt.expected == "" {
+				if got != nil {
+					t.Errorf("unexpected issue: %s", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("expected a job count issue")
+			}
+			if got.Message != tt.expected || got.Line != 1 {
+				t.Errorf("issue = %d %q, want 1 %q", got.Line, got.Message, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStyleLinter_JobTimeout(t *testing.T) {
+	content := `name: Test
+on: push
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+  test:
+    name: Test
+    runs-on: ubuntu-latest
+    timeout-minutes: 30
+    steps:
+      - run: make test
+  nightly:
+    name: Nightly
+    runs-on: ubuntu-latest
+    timeout-minutes: 240
+    steps:
+      - run: make nightly
+  dynamic:
+    name: Dynamic
+    runs-on: ubuntu-latest
+    timeout-minutes: ${{ inputs.timeout }}
+    steps:
+      - run: make
+  call:
+    uses: ./.github/workflows/reusable.yml
+`
+	wf, err := workflow.ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		require    bool
+		maxTimeout int
+		expected   []string
+	}{
+		{name: "disabled by default"},
+		{
+			name:     "require timeout",
+			require:  true,
+			expected: []string{"4: Job 'build' is missing timeout-minutes; it can run for up to 6 hours"},
+		},
+		{
+			name:       "max timeout",
+			maxTimeout: 60,
+			expected:   []string{"15: Job 'nightly' has timeout-minutes 240 (max 60)"},
+		},
+		{
+			name:       "require and max timeout",
+			require:    true,
+			maxTimeout: 60,
+			expected: []string{
+				"4: Job 'build' is missing timeout-minutes; it can run for up to 6 hours",
+				"15: Job 'nightly' has timeout-minutes 240 (max 60)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := config.DefaultStyleSettings()
+			settings.RequireJobTimeout = tt.require
+			settings.MaxJobTimeout = tt.maxTimeout
+
+			issues, err := NewStyleLinter(settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			slices.SortFunc(issues, func(a, b *Issue) int { return a.Line - b.Line })
+			var messages []string
+			for _, issue := range issues {
+				if strings.Contains(issue.Message, "timeout-minutes") {
+					messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+				}
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStyleLinter_RequirePipefail(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults string
+		runsOn   string
+		shell    string
+		run      string
+		expected bool
+	}{
+		{"pipe without pipefail", "", "ubuntu-latest", "", "make build\ngo test ./... | tee out.txt", true},
+		{"macos runner", "", "macos-latest", "", "make build\ngo test ./... | tee out.txt", true},
+		{"pipefail set", "", "ubuntu-latest", "", "set -euo pipefail\ngo test ./... | tee out.txt", false},
+		{"explicit bash shell", "", "ubuntu-latest", "bash", "make build\ngo test ./... | tee out.txt", false},
+		{"workflow default shell", "defaults:\n  run:\n    shell: bash\n", "ubuntu-latest", "", "make build\ngo test | tee out.txt", false},
+		{"windows runner", "", "windows-latest", "", "make build\ngo test ./... | tee out.txt", false},
+		{"expression runner", "", "${{ matrix.os }}", "", "make build\ngo test ./... | tee out.txt", false},
+		{"single command", "", "ubuntu-latest", "", "go test ./... | tee out.txt", false},
+		{"or operator", "", "ubuntu-latest", "", "make build\nmake test || exit 1", false},
+		{"pipe in quotes", "", "ubuntu-latest", "", "make build\ngrep -E 'a|b' file.txt", false},
+		{"pipe in comment", "", "ubuntu-latest", "", "make build # a | b\nmake test", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shell := ""
+			if tt.shell != "" {
+				shell = "\n        shell: " + tt.shell
+			}
+			content := fmt.Sprintf(`name: Test Workflow
+on: push
+%sjobs:
+  build:
+    name: Build
+    runs-on: %s
+    steps:
+      - name: Test Step%s
+        run: |
+          %s
+`, tt.defaults, tt.runsOn, shell, strings.ReplaceAll(tt.run, "\n", "\n          "))
+
+			path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			issues, err := NewStyleLinter(&config.StyleSettings{RequirePipefail: true}).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var found *Issue
+			for _, issue := range issues {
+				if strings.Contains(issue.Message, "pipefail") {
+					found = issue
+				}
+			}
+			if (found != nil) != tt.expected {
+				t.Fatalf("got flagged=%v, want flagged=%v", found != nil, tt.expected)
+			}
+			if found != nil && found.Line != wf.FindStepLine("build", 0) {
+				t.Errorf("Line = %d, want step line %d", found.Line, wf.FindStepLine("build", 0))
+			}
+		})
+	}
+}
+
 func TestStyleLinter_EmptyName(t *testing.T) {
 	linter := NewStyleLinter(&config.StyleSettings{
 		NamingConvention: "title",
@@ -610,3 +839,875 @@ func TestStyleLinter_EmptyName(t *testing.T) {
 		t.Errorf("Expected nil for whitespace name, got: %s", issue.Message)
 	}
 }
+
+func TestStyleLinter_InconsistentActionCasing(t *testing.T) {
+	tmpDir := t.TempDir()
+	paths := []string{
+		testutil.CreateWorkflow(t, tmpDir, "a.yml", `name: A
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+`),
+		testutil.CreateWorkflow(t, tmpDir, "b.yml", `name: B
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/setup-go@v5
+      - uses: Actions/Checkout@v4
+`),
+		testutil.CreateWorkflow(t, tmpDir, "c.yml", `name: C
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+`),
+	}
+
+	var workflows []*workflow.Workflow
+	for _, path := range paths {
+		wf, err := workflow.LoadWorkflow(path)
+		if err != nil {
+			t.Fatalf("LoadWorkflow() error = %v", err)
+		}
+		workflows = append(workflows, wf)
+	}
+
+	linter := NewStyleLinter(nil)
+	issues, err := linter.LintWorkflows(workflows)
+	if err != nil {
+		t.Fatalf("LintWorkflows() error = %v", err)
+	}
+
+	if len(issues) != 1 {
+		t.Fatalf("LintWorkflows() returned %d issues, want 1", len(issues))
+	}
+
+	issue := issues[0]
+	if issue.File != "b.yml" || issue.Line != 8 {
+		t.Errorf("Issue location = %s:%d, want b.yml:8", issue.File, issue.Line)
+	}
+	want := "Actions/Checkout (b.yml), actions/checkout (a.yml, c.yml)"
+	if !strings.Contains(issue.Message, want) {
+		t.Errorf("Issue message = %q, want it to contain %q", issue.Message, want)
+	}
+}
+
+func TestStyleLinter_DistinctWorkflowNames(t *testing.T) {
+	tmpDir := t.TempDir()
+	paths := []string{
+		testutil.CreateWorkflow(t, tmpDir, "build.yml", `name: CI
+on: push
+jobs: {}
+`),
+		testutil.CreateWorkflow(t, tmpDir, "release.yml", `name: ci
+on: push
+jobs: {}
+`),
+		testutil.CreateWorkflow(t, tmpDir, "deploy.yml", `name: deploy
+on:
+  workflow_call:
+jobs: {}
+`),
+	}
+
+	var workflows []*workflow.Workflow
+	for _, path := range paths {
+		wf, err := workflow.LoadWorkflow(path)
+		if err != nil {
+			t.Fatalf("LoadWorkflow() error = %v", err)
+		}
+		workflows = append(workflows, wf)
+	}
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		wantIssues []string
+	}{
+		{
+			name:    "disabled by default",
+			enabled: false,
+		},
+		{
+			name:    "enabled",
+			enabled: true,
+			wantIssues: []string{
+				"deploy.yml:1: (style) Reusable workflow name 'deploy' only repeats the file name; " +
+					"use a descriptive name so callers see meaningful run names",
+				"release.yml:1: (style) Workflow name 'ci' is already used by build.yml",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := config.DefaultStyleSettings()
+			settings.DistinctWorkflowNames = tt.enabled
+			linter := NewStyleLinter(settings)
+
+			var got []string
+			for _, wf := range workflows {
+				issues, err := linter.LintWorkflow(wf)
+				if err != nil {
+					t.Fatalf("LintWorkflow() error = %v", err)
+				}
+				for _, issue := range issues {
+					if strings.Contains(issue.Message, "Reusable workflow") {
+						issue.Linter = config.LinterStyle
+						got = append(got, issue.String())
+					}
+				}
+			}
+
+			issues, err := linter.LintWorkflows(workflows)
+			if err != nil {
+				t.Fatalf("LintWorkflows() error = %v", err)
+			}
+			for _, issue := range issues {
+				if filepath.Base(issue.FullPath) != issue.File {
+					t.Errorf("issue FullPath = %q, want the path of %s", issue.FullPath, issue.File)
+				}
+				issue.FullPath = ""
+				issue.Linter = config.LinterStyle
+				got = append(got, issue.String())
+			}
+
+			if len(got) != len(tt.wantIssues) {
+				t.Fatalf("got %d issues %v, want %d", len(got), got, len(tt.wantIssues))
+			}
+			for i := range got {
+				if got[i] != tt.wantIssues[i] {
+					t.Errorf("issue %d = %q, want %q", i, got[i], tt.wantIssues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStyleLinter_InvalidEnvNames(t *testing.T) {
+	content := `name: Test
+on: push
+env:
+  GO_VERSION: "1.21"
+  _PRIVATE: "1"
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    env:
+      MY-VAR: value
+    steps:
+      - name: Run
+        env:
+          1ST_VAR: value
+          SECOND_VAR2: value
+        run: echo "$SECOND_VAR2"
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	issues, err := NewStyleLinter(nil).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+
+	expected := map[string]int{"MY-VAR": 11, "1ST_VAR": 15}
+	found := make(map[string]int)
+	for _, issue := range issues {
+		if !strings.Contains(issue.Message, "has an invalid name") {
+			continue
+		}
+		for name := range expected {
+			if strings.Contains(issue.Message, "'"+name+"'") {
+				found[name] = issue.Line
+			}
+		}
+	}
+
+	if len(found) != len(expected) {
+		t.Fatalf("invalid env name issues = %v, want %v", found, expected)
+	}
+	for name, line := range expected {
+		if found[name] != line {
+			t.Errorf("env var %s reported at line %d, want %d", name, found[name], line)
+		}
+	}
+}
+
+func TestStyleLinter_DuplicateStepIDs(t *testing.T) {
+	content := `name: Test
+on: push
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    steps:
+      - name: Build
+        id: build
+        run: make build
+      - name: Test
+        run: make test
+      - name: Build again
+        id: build
+        run: make build
+      - name: Package
+        id: package
+        run: make package
+  release:
+    name: Release
+    runs-on: ubuntu-latest
+    steps:
+      - name: Build
+        id: build
+        run: make release
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	issues, err := NewStyleLinter(nil).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+
+	var duplicates []*Issue
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, "is already used by another step") {
+			duplicates = append(duplicates, issue)
+		}
+	}
+
+	if len(duplicates) != 1 {
+		t.Fatalf("expected 1 duplicate step ID issue, got %d: %v", len(duplicates), duplicates)
+	}
+	expected := "Step ID 'build' is already used by another step in job 'build'"
+	if duplicates[0].Message != expected {
+		t.Errorf("Message = %q, want %q", duplicates[0].Message, expected)
+	}
+	if duplicates[0].Line != 13 {
+		t.Errorf("Line = %d, want 13", duplicates[0].Line)
+	}
+}
+
+func TestStyleLinter_JobIDCasing(t *testing.T) {
+	content := `name: Test
+on: push
+jobs:
+  Build:
+    name: Build
+    runs-on: ubuntu-latest
+  test:
+    name: Test
+    runs-on: ubuntu-latest
+  build:
+    name: Build Again
+    runs-on: ubuntu-latest
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	issues, err := NewStyleLinter(nil).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+
+	var collisions []*Issue
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, "differs only in case") {
+			collisions = append(collisions, issue)
+		}
+	}
+
+	if len(collisions) != 1 {
+		t.Fatalf("expected 1 job ID casing issue, got %d: %v", len(collisions), collisions)
+	}
+	expected := "Job ID 'build' differs only in case from job 'Build'"
+	if collisions[0].Message != expected {
+		t.Errorf("Message = %q, want %q", collisions[0].Message, expected)
+	}
+	if collisions[0].Line != 10 {
+		t.Errorf("Line = %d, want 10", collisions[0].Line)
+	}
+}
+
+func TestStyleLinter_TokenOverride(t *testing.T) {
+	content := `name: Test
+on: push
+env:
+  GITHUB_TOKEN: ${{ secrets.MY_PAT }}
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    env:
+      GITHUB_TOKEN: ${{ secrets.CROSS_REPO_TOKEN }}
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v4
+        env:
+          GITHUB_TOKEN: ${{ secrets.MY_PAT }}
+  test:
+    name: Test
+    runs-on: ubuntu-latest
+    env:
+      GITHUB_TOKEN: ${{ github.token }}
+`
+	tests := []struct {
+		name     string
+		settings *config.StyleSettings
+		expected map[int]string // line -> expected message fragment
+	}{
+		{
+			name:     "disabled by default",
+			settings: nil,
+			expected: map[int]string{},
+		},
+		{
+			name:     "enabled",
+			settings: &config.StyleSettings{WarnTokenOverride: true},
+			expected: map[int]string{
+				4:  "all jobs in the workflow",
+				10: "all steps in job 'build'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			issues, err := NewStyleLinter(tt.settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			found := make(map[int]string)
+			for _, issue := range issues {
+				if strings.Contains(issue.Message, "overrides GITHUB_TOKEN") {
+					found[issue.Line] = issue.Message
+				}
+			}
+
+			if len(found) != len(tt.expected) {
+				t.Fatalf("token override issues = %v, want lines %v", found, tt.expected)
+			}
+			for line, fragment := range tt.expected {
+				if !strings.Contains(found[line], fragment) {
+					t.Errorf("issue at line %d = %q, want it to contain %q", line, found[line], fragment)
+				}
+			}
+		})
+	}
+}
+
+func TestStyleLinter_MovedActions(t *testing.T) {
+	content := `name: Test
+on: push
+jobs:
+  release:
+    name: Release
+    runs-on: ubuntu-latest
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v4
+      - name: Create release
+        uses: Actions/Create-Release@v1
+      - name: Setup Haskell
+        uses: haskell/actions/setup@v2
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	issues, err := NewStyleLinter(nil).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+
+	var moved []*Issue
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, "has moved") {
+			moved = append(moved, issue)
+		}
+	}
+
+	expected := []struct {
+		line    int
+		message string
+	}{
+		{11, "Action Actions/Create-Release is archived or has moved; use softprops/action-gh-release instead"},
+		{13, "Action haskell/actions/setup is archived or has moved; use haskell-actions/setup instead"},
+	}
+	if len(moved) != len(expected) {
+		t.Fatalf("expected %d moved action issues, got %d: %v", len(expected), len(moved), moved)
+	}
+	for i, want := range expected {
+		if moved[i].Line != want.line || moved[i].Message != want.message {
+			t.Errorf("issue %d = %d: %q, want %d: %q", i, moved[i].Line, moved[i].Message, want.line, want.message)
+		}
+	}
+}
+
+func TestStyleLinter_FetchDepth(t *testing.T) {
+	content := `name: Release Packages
+on: push
+jobs:
+  release:
+    name: Release
+    runs-on: ubuntu-latest
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v4
+      - name: Checkout tools
+        uses: actions/checkout@v4
+        with:
+          repository: octo-org/tools
+      - name: Checkout history
+        uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+`
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []int
+	}{
+		{name: "disabled", patterns: nil},
+		{name: "workflow name", patterns: []string{"Release *"}, expected: []int{9, 11}},
+		{name: "file name", patterns: []string{"release*.yml"}, expected: []int{9, 11}},
+		{name: "no match", patterns: []string{"deploy*"}},
+	}
+
+	path := testutil.CreateWorkflow(t, t.TempDir(), "release.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := config.DefaultStyleSettings()
+			settings.FullHistoryWorkflows = tt.patterns
+			issues, err := NewStyleLinter(settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var lines []int
+			for _, issue := range issues {
+				if issue.RuleID == ruleStyleFetchDepth {
+					lines = append(lines, issue.Line)
+				}
+			}
+			if !slices.Equal(lines, tt.expected) {
+				t.Errorf("fetch-depth issues at lines %v, want %v", lines, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStyleLinter_ConstantConcurrency(t *testing.T) {
+	content := `name: Test
+on: push
+concurrency: ci
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    concurrency:
+      group: build
+      cancel-in-progress: true
+    steps:
+      - run: make
+  deploy:
+    name: Deploy
+    runs-on: ubuntu-latest
+    concurrency:
+      group: deploy-${{ github.ref }}
+    steps:
+      - run: make deploy
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		expected []string
+	}{
+		{name: "disabled by default"},
+		{
+			name:    "enabled",
+			enabled: true,
+			expected: []string{
+				"3: Workflow concurrency group 'ci' is constant and serializes runs repository-wide; " +
+					"include ${{ github.workflow }} and ${{ github.ref }}",
+				"8: Job 'build' concurrency group 'build' is constant and serializes runs repository-wide; " +
+					"include ${{ github.workflow }} and ${{ github.ref }}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := config.DefaultStyleSettings()
+			settings.WarnConstantConcurrency = tt.enabled
+
+			issues, err := NewStyleLinter(settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				if strings.Contains(issue.Message, "concurrency group") {
+					messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+				}
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStyleLinter_RequireConcurrency(t *testing.T) {
+	const missing = "Workflow triggered by %s has no concurrency group, so superseded runs keep running; " +
+		"add concurrency with group ${{ github.workflow }}-${{ github.ref }} and cancel-in-progress: true"
+
+	tests := []struct {
+		name     string
+		content  string
+		enabled  bool
+		expected []string
+	}{
+		{
+			name: "disabled by default",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+		},
+		{
+			name: "push without concurrency",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+			enabled:  true,
+			expected: []string{"2: " + fmt.Sprintf(missing, "push")},
+		},
+		{
+			name: "pull_request without concurrency",
+			content: `name: Test
+on:
+  workflow_dispatch:
+  pull_request:
+    branches: [main]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+			enabled:  true,
+			expected: []string{"4: " + fmt.Sprintf(missing, "pull_request")},
+		},
+		{
+			name: "workflow concurrency",
+			content: `name: Test
+on: [push, pull_request]
+concurrency:
+  group: ${{ github.workflow }}-${{ github.ref }}
+  cancel-in-progress: true
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+			enabled: true,
+		},
+		{
+			name: "job concurrency",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    concurrency: build-${{ github.ref }}
+    steps:
+      - run: make
+`,
+			enabled: true,
+		},
+		{
+			name: "other triggers",
+			content: `name: Test
+on:
+  schedule:
+    - cron: '0 0 * * *'
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := workflow.ParseWorkflow("test.yml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+			settings := config.DefaultStyleSettings()
+			settings.RequireConcurrency = tt.enabled
+
+			issues, err := NewStyleLinter(settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				if strings.Contains(issue.Message, "no concurrency group") {
+					messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+				}
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStyleLinter_WorkingDirectory(t *testing.T) {
+	tests := []struct {
+		name     string
+		dir      string
+		allowed  []string
+		expected []string
+	}{
+		{name: "subdirectory", dir: "app"},
+		{name: "parent segment staying inside", dir: "app/../tools"},
+		{
+			name:     "escaping path",
+			dir:      "../../../etc",
+			expected: []string{"Step working-directory '../../../etc' points outside the workspace"},
+		},
+		{
+			name:     "escaping after descending",
+			dir:      "app/../../other",
+			expected: []string{"Step working-directory 'app/../../other' points outside the workspace"},
+		},
+		{
+			name:     "windows separators",
+			dir:      `..\other`,
+			expected: []string{`Step working-directory '..\other' points outside the workspace`},
+		},
+		{name: "allowed sibling", dir: "../shared", allowed: []string{"../shared"}},
+		{name: "allowed sibling subdirectory", dir: "../shared/lib", allowed: []string{"../shared/"}},
+		{
+			name:     "other sibling",
+			dir:      "../shared-other",
+			allowed:  []string{"../shared"},
+			expected: []string{"Step working-directory '../shared-other' points outside the workspace"},
+		},
+		{name: "expression", dir: "${{ inputs.dir }}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := `name: Test
+on: push
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    steps:
+      - name: Build
+        working-directory: '` + tt.dir + `'
+        run: make
+`
+			path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			settings := config.DefaultStyleSettings()
+			settings.AllowedWorkingDirectories = tt.allowed
+			issues, err := NewStyleLinter(settings).LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				if strings.Contains(issue.Message, "working-directory") {
+					messages = append(messages, issue.Message)
+					if issue.Line != 8 {
+						t.Errorf("Line = %d, want 8", issue.Line)
+					}
+				}
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStyleLinter_ConditionEnvRefs(t *testing.T) {
+	content := `name: Test
+on: push
+env:
+  MODE: release
+jobs:
+  build:
+    name: Build
+    if: env.MODE == 'release'
+    runs-on: ubuntu-latest
+    env:
+      TARGET: linux
+    steps:
+      - name: Check
+        if: env.MODE == 'release' && env.target == 'linux'
+        run: make check
+      - name: Deploy
+        if: ${{ env.DEPLOY_KEY != '' && env.DEPLOY_KEY != 'none' }}
+        run: make deploy
+      - name: Publish
+        if: env.DEPLOY_KEY != ''
+        env:
+          DEPLOY_KEY: ${{ secrets.DEPLOY_KEY }}
+        run: make publish
+      - name: Unknown
+        if: env.SET_BY_ACTION == 'true' && steps.meta.outputs.env.DEPLOY_KEY
+        run: echo unknown
+  release:
+    name: Release
+    runs-on: ubuntu-latest
+    steps:
+      - name: Export
+        run: echo "DEPLOY_KEY=x" >> "$GITHUB_ENV"
+      - name: Use
+        if: env.DEPLOY_KEY != ''
+        run: make release
+`
+	path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", content)
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	issues, err := NewStyleLinter(nil).LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, "if condition") {
+			got = append(got, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+		}
+	}
+	expected := []string{
+		"8: Job 'build' if condition references env.MODE, but the env context is not available " +
+			"in job-level if; use vars or job outputs instead",
+		"17: Step if condition references env.DEPLOY_KEY, which is only declared in other steps " +
+			"or jobs and is not available here",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestStyleLinter_FixWorkflow(t *testing.T) {
+	content := "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n" +
+		"      - run: make\n        name: Build\n"
+	sorted := "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n" +
+		"      - name: Build\n        run: make\n"
+
+	tests := []struct {
+		name     string
+		settings *config.StyleSettings
+		expected string
+	}{
+		{
+			name:     "disabled by default",
+			settings: nil,
+			expected: content,
+		},
+		{
+			name:     "sorts step keys",
+			settings: &config.StyleSettings{FixStepKeyOrder: true},
+			expected: sorted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := workflow.ParseWorkflow("test.yml", []byte(content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			linter := NewStyleLinter(tt.settings)
+			if err := linter.FixWorkflow(wf); err != nil {
+				t.Fatalf("FixWorkflow() error = %v", err)
+			}
+			if got := string(wf.RawBytes); got != tt.expected {
+				t.Errorf("FixWorkflow() result:\n%s\nwant:\n%s", got, tt.expected)
+			}
+
+			// The name-first issue is gone only once the keys are sorted
+			issues, err := linter.LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+			nameFirst := slices.ContainsFunc(issues, func(issue *Issue) bool {
+				return strings.Contains(issue.Message, "'name'")
+			})
+			if nameFirst != (tt.expected == content) {
+				t.Errorf("LintWorkflow() after fix returned %v", issues)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package linter
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Rules reported by the token-scopes linter.
+const (
+	ruleTokenScopesMissing = "token-scopes/missing-scope"
+	ruleTokenScopesUnused  = "token-scopes/unused-write"
+)
+
+// Access levels of a GITHUB_TOKEN scope.
+const (
+	accessRead  = "read"
+	accessWrite = "write"
+)
+
+// actionScopes maps actions to the GITHUB_TOKEN scopes they need with their default inputs.
+// Actions that need no scopes map to an empty map, so a job that only uses known actions can
+// be checked for scopes it is granted but doesn't need.
+var actionScopes = map[string]map[string]string{
+	"actions/checkout":                       {"contents": accessRead},
+	"actions/setup-go":                       {},
+	"actions/setup-node":                     {},
+	"actions/setup-python":                   {},
+	"actions/setup-java":                     {},
+	"actions/setup-dotnet":                   {},
+	"actions/cache":                          {},
+	"actions/upload-artifact":                {},
+	"actions/download-artifact":              {},
+	"actions/upload-pages-artifact":          {},
+	"actions/deploy-pages":                   {"pages": accessWrite, "id-token": accessWrite},
+	"actions/labeler":                        {"contents": accessRead, "pull-requests": accessWrite},
+	"actions/stale":                          {"issues": accessWrite, "pull-requests": accessWrite},
+	"actions/dependency-review-action":       {"contents": accessRead},
+	"actions/attest-build-provenance":        {"id-token": accessWrite, "attestations": accessWrite},
+	"github/codeql-action/init":              {"security-events": accessWrite},
+	"github/codeql-action/analyze":           {"security-events": accessWrite},
+	"github/codeql-action/upload-sarif":      {"security-events": accessWrite},
+	"golangci/golangci-lint-action":          {"contents": accessRead},
+	"softprops/action-gh-release":            {"contents": accessWrite},
+	"peter-evans/create-pull-request":        {"contents": accessWrite, "pull-requests": accessWrite},
+	"marocchino/sticky-pull-request-comment": {"pull-requests": accessWrite},
+}
+
+// tokenInputs are the inputs through which actions commonly accept a token other than
+// the GITHUB_TOKEN.
+var tokenInputs = []string{"token", "github-token", "github_token", "repo-token"}
+
+// tokenUsePattern matches run scripts and env values that may use the GITHUB_TOKEN in ways
+// the linter can't follow, such as gh CLI calls or a push with the checkout credentials.
+var tokenUsePattern = regexp.MustCompile(`(?i)github\.token|secrets\.GITHUB_TOKEN|\bgit\s+push\b`)
+
+// TokenScopesLinter checks that the permissions granted to the GITHUB_TOKEN match the scopes
+// needed by the actions of each job. It reports scopes that known actions need but aren't
+// granted, and write access that none of the steps needs as advisory issues.
+type TokenScopesLinter struct {
+	noOpFixer
+}
+
+// NewTokenScopesLinter creates a new TokenScopesLinter instance.
+func NewTokenScopesLinter() *TokenScopesLinter {
+	return &TokenScopesLinter{}
+}
+
+// tokenGrant is the access a permissions value grants the GITHUB_TOKEN.
+type tokenGrant struct {
+	all    string            // Access to every scope, from read-all or write-all
+	scopes map[string]string // Access per scope, from a permissions map
+}
+
+// access returns the access granted to scope: read, write, or an empty string for none.
+func (g *tokenGrant) access(scope string) string {
+	if access, ok := g.scopes[scope]; ok && access != "none" {
+		return access
+	}
+	return g.all
+}
+
+// newTokenGrant returns the grant of a permissions value, or nil if it isn't known:
+// without permissions, the token gets the repository's default permissions.
+func newTokenGrant(permissions any) *tokenGrant {
+	switch p := permissions.(type) {
+	case string:
+		switch p {
+		case "read-all":
+			return &tokenGrant{all: accessRead}
+		case "write-all":
+			return &tokenGrant{all: accessWrite}
+		}
+	case map[string]any:
+		grant := &tokenGrant{scopes: make(map[string]string, len(p))}
+		for scope, access := range p {
+			grant.scopes[scope], _ = access.(string)
+		}
+		return grant
+	}
+	return nil
+}
+
+// tokenNeeds is the access the steps of one or more jobs need per scope.
+type tokenNeeds struct {
+	scopes map[string]string
+	known  bool // Whether every step's use of the token is known
+}
+
+// add records the access a step needs to scope, keeping the highest access.
+func (n *tokenNeeds) add(scope, access string) {
+	if n.scopes[scope] != accessWrite {
+		n.scopes[scope] = access
+	}
+}
+
+// LintWorkflow checks the permissions of each job against the scopes its actions need.
+// Jobs without permissions at either level get the repository's default permissions,
+// which the permissions linter reports, and are not checked.
+func (l *TokenScopesLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	var issues []*Issue
+
+	if wf.Content == nil || wf.Content.Jobs == nil {
+		return issues, nil
+	}
+
+	model, err := wf.Model()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	// A token in workflow env may be used by any step in ways the linter can't follow
+	envVars, err := wf.EnvVars()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	envKnown := true
+	for _, v := range envVars {
+		if v.Level == workflow.EnvLevelWorkflow && tokenUsePattern.MatchString(v.Value) {
+			envKnown = false
+		}
+	}
+
+	file := wf.BaseName()
+	workflowGrant := newTokenGrant(wf.Content.Permissions)
+	inherited := tokenNeeds{scopes: make(map[string]string), known: envKnown}
+	inherits := false
+
+	for _, jobModel := range model.Jobs {
+		job, ok := wf.Content.Jobs[jobModel.ID].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		grant := workflowGrant
+		if job["permissions"] != nil {
+			grant = newTokenGrant(job["permissions"])
+		}
+		if grant != nil {
+			issues = append(issues, missingScopes(file, jobModel, job, grant)...)
+		}
+
+		needs := jobTokenNeeds(job)
+		if job["permissions"] == nil {
+			inherits = true
+			inherited.known = inherited.known && needs.known
+			for scope, access := range needs.scopes {
+				inherited.add(scope, access)
+			}
+			continue
+		}
+		if grant == nil || grant.all != "" || !envKnown || !needs.known {
+			continue
+		}
+		line := wf.FindPermissionsLine(jobModel.ID)
+		for _, scope := range unneededWrites(grant, needs) {
+			message := fmt.Sprintf("Job '%s' grants '%s: write', but %s", jobModel.ID, scope,
+				unneededWriteReason(scope, needs.scopes[scope], "none of its steps need it", "its steps"))
+			issues = append(issues, newAdvisoryIssue(file, line, message))
+		}
+	}
+
+	// Workflow permissions only reach the jobs that don't set their own
+	if workflowGrant != nil && workflowGrant.all == "" && inherited.known {
+		notNeeded := "every job sets its own permissions"
+		if inherits {
+			notNeeded = "none of the jobs that inherit them need it"
+		}
+		line := wf.FindPermissionsLine("")
+		var workflowIssues []*Issue
+		for _, scope := range unneededWrites(workflowGrant, inherited) {
+			message := fmt.Sprintf("Workflow permissions grant '%s: write', but %s", scope,
+				unneededWriteReason(scope, inherited.scopes[scope], notNeeded, "the jobs that inherit them"))
+			workflowIssues = append(workflowIssues, newAdvisoryIssue(file, line, message))
+		}
+		issues = append(workflowIssues, issues...)
+	}
+
+	return issues, nil
+}
+
+// jobTokenNeeds returns the scopes the steps of a job need. The needs are unknown if the job
+// calls a reusable workflow, uses an action that isn't in actionScopes, or refers to the token
+// in a run script or env value.
+func jobTokenNeeds(job map[string]any) tokenNeeds {
+	needs := tokenNeeds{scopes: make(map[string]string), known: true}
+	if _, ok := job["uses"]; ok || tokenUsePattern.MatchString(envText(job["env"])) {
+		needs.known = false
+		return needs
+	}
+
+	steps, _ := job["steps"].([]any)
+	for _, stepData := range steps {
+		step, ok := stepData.(map[string]any)
+		if !ok {
+			needs.known = false
+			continue
+		}
+		if run, ok := step["run"].(string); ok {
+			if tokenUsePattern.MatchString(run) || tokenUsePattern.MatchString(envText(step["env"])) {
+				needs.known = false
+			}
+			continue
+		}
+
+		uses, _ := step["uses"].(string)
+		scopes, ok := actionScopes[actionName(uses)]
+		if !ok {
+			needs.known = false
+			continue
+		}
+		if usesOtherToken(step) {
+			continue
+		}
+		for scope, access := range scopes {
+			needs.add(scope, access)
+		}
+	}
+	return needs
+}
+
+// missingScopes reports each step of a job that uses a known action needing a scope that
+// grant doesn't give it.
+func missingScopes(file string, jobModel *workflow.Job, job map[string]any, grant *tokenGrant) []*Issue {
+	var issues []*Issue
+	steps, _ := job["steps"].([]any)
+	for i, stepData := range steps {
+		step, ok := stepData.(map[string]any)
+		if !ok || usesOtherToken(step) {
+			continue
+		}
+		uses, _ := step["uses"].(string)
+		name := actionName(uses)
+		scopes := actionScopes[name]
+
+		line := jobModel.Line
+		if stepModel := jobModel.Step(i); stepModel != nil {
+			line = stepModel.Line
+		}
+		for _, scope := range slices.Sorted(maps.Keys(scopes)) {
+			need, granted := scopes[scope], grant.access(scope)
+			if granted == accessWrite || granted == need {
+				continue
+			}
+			message := fmt.Sprintf("Action '%s' needs '%s: %s', but job '%s' has no '%s' access",
+				name, scope, need, jobModel.ID, scope)
+			if granted != "" {
+				message = fmt.Sprintf("Action '%s' needs '%s: %s', but job '%s' only has '%s: %s'",
+					name, scope, need, jobModel.ID, scope, granted)
+			}
+			issues = append(issues, newRuleIssue(file, line, ruleTokenScopesMissing, message))
+		}
+	}
+	return issues
+}
+
+// unneededWrites returns the sorted scopes that grant gives write access to but needs
+// doesn't include with write access.
+func unneededWrites(grant *tokenGrant, needs tokenNeeds) []string {
+	var scopes []string
+	for _, scope := range slices.Sorted(maps.Keys(grant.scopes)) {
+		if grant.scopes[scope] == accessWrite && needs.scopes[scope] != accessWrite {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// unneededWriteReason completes the message of a write grant that isn't needed: notNeeded
+// if the scope isn't needed at all, or what the users of the grant need otherwise.
+func unneededWriteReason(scope, need, notNeeded, users string) string {
+	if need == "" {
+		return notNeeded
+	}
+	return fmt.Sprintf("%s only need '%s: %s'", users, scope, need)
+}
+
+// usesOtherToken returns true if a step passes its action a token other than the GITHUB_TOKEN,
+// so the action doesn't need the GITHUB_TOKEN's scopes.
+func usesOtherToken(step map[string]any) bool {
+	with, _ := step["with"].(map[string]any)
+	for _, input := range tokenInputs {
+		if value, ok := with[input].(string); ok && !defaultTokenPattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// actionName returns the lowercase action of a uses value without its ref
+// (e.g., "actions/checkout" for "actions/checkout@v4").
+func actionName(uses string) string {
+	name, _, _ := strings.Cut(uses, "@")
+	return strings.ToLower(name)
+}
+
+// envText returns the values of a job or step env mapping joined into a single string
+// to match against.
+func envText(env any) string {
+	values, _ := env.(map[string]any)
+	var sb strings.Builder
+	for _, value := range values {
+		fmt.Fprintf(&sb, "%v\n", value)
+	}
+	return sb.String()
+}
+
+// newAdvisoryIssue creates an unused-write issue, which is reported as info unless the
+// linter's severity is configured.
+func newAdvisoryIssue(file string, line int, message string) *Issue {
+	issue := newRuleIssue(file, line, ruleTokenScopesUnused, message)
+	issue.Severity = config.SeverityInfo
+	return issue
+}
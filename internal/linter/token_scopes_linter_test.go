@@ -0,0 +1,190 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestTokenScopesLinter_LintWorkflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name: "granted scopes match",
+			content: `on: push
+permissions:
+  contents: write
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: softprops/action-gh-release@v2
+`,
+		},
+		{
+			name: "missing scopes",
+			content: `on: push
+permissions: {}
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+  release:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+    steps:
+      - uses: softprops/action-gh-release@v2
+  scan:
+    runs-on: ubuntu-latest
+    permissions: read-all
+    steps:
+      - uses: github/codeql-action/upload-sarif@v3
+`,
+			expected: []string{
+				"7: error: Action 'actions/checkout' needs 'contents: read', but job 'build' has no 'contents' access",
+				"13: error: Action 'softprops/action-gh-release' needs 'contents: write', " +
+					"but job 'release' only has 'contents: read'",
+				"18: error: Action 'github/codeql-action/upload-sarif' needs 'security-events: write', " +
+					"but job 'scan' only has 'security-events: read'",
+			},
+		},
+		{
+			name: "unused job writes",
+			content: `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: write
+      issues: write
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+      - run: go test ./...
+`,
+			expected: []string{
+				"5: info: Job 'build' grants 'contents: write', but its steps only need 'contents: read'",
+				"5: info: Job 'build' grants 'issues: write', but none of its steps need it",
+			},
+		},
+		{
+			name: "unused workflow writes",
+			content: `on: push
+permissions:
+  contents: read
+  pull-requests: write
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+  stale:
+    runs-on: ubuntu-latest
+    permissions:
+      issues: write
+      pull-requests: write
+    steps:
+      - uses: actions/stale@v9
+`,
+			expected: []string{
+				"2: info: Workflow permissions grant 'pull-requests: write', " +
+					"but none of the jobs that inherit them need it",
+			},
+		},
+		{
+			name: "unknown token use",
+			content: `on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    permissions:
+      issues: write
+    steps:
+      - uses: example/unknown-action@v1
+  comment:
+    runs-on: ubuntu-latest
+    permissions:
+      issues: write
+    steps:
+      - run: gh issue comment 1 --body done
+        env:
+          GH_TOKEN: ${{ github.token }}
+  push:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: write
+    steps:
+      - uses: actions/checkout@v4
+      - run: git push origin HEAD:main
+  call:
+    permissions:
+      contents: write
+    uses: ./.github/workflows/release.yml
+`,
+		},
+		{
+			name: "other token",
+			content: `on: push
+permissions: {}
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          token: ${{ secrets.DEPLOY_TOKEN }}
+      - uses: softprops/action-gh-release@v2
+        with:
+          token: ${{ secrets.GITHUB_TOKEN }}
+`,
+			expected: []string{
+				"10: error: Action 'softprops/action-gh-release' needs 'contents: write', " +
+					"but job 'release' has no 'contents' access",
+			},
+		},
+		{
+			name: "default permissions",
+			content: `on: push
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: softprops/action-gh-release@v2
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := workflow.ParseWorkflow("test.yml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			issues, err := NewTokenScopesLinter().LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				severity := issue.Severity
+				if severity == "" {
+					severity = "error"
+				}
+				messages = append(messages, fmt.Sprintf("%d: %s: %s", issue.Line, severity, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package linter
+
+import (
+	"fmt"
+
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Rules reported by the triggers linter.
+const (
+	ruleTriggersMissing           = "triggers/missing"
+	ruleTriggersUnknown           = "triggers/unknown-event"
+	ruleTriggersPullRequestTarget = "triggers/pull-request-target"
+)
+
+// knownEvents lists the events that can trigger a GitHub Actions workflow.
+var knownEvents = map[string]bool{
+	"branch_protection_rule":      true,
+	"check_run":                   true,
+	"check_suite":                 true,
+	"create":                      true,
+	"delete":                      true,
+	"deployment":                  true,
+	"deployment_status":           true,
+	"discussion":                  true,
+	"discussion_comment":          true,
+	"fork":                        true,
+	"gollum":                      true,
+	"issue_comment":               true,
+	"issues":                      true,
+	"label":                       true,
+	"merge_group":                 true,
+	"milestone":                   true,
+	"page_build":                  true,
+	"project":                     true,
+	"project_card":                true,
+	"project_column":              true,
+	"public":                      true,
+	"pull_request":                true,
+	"pull_request_review":         true,
+	"pull_request_review_comment": true,
+	"pull_request_target":         true,
+	"push":                        true,
+	"registry_package":            true,
+	"release":                     true,
+	"repository_dispatch":         true,
+	"schedule":                    true,
+	"status":                      true,
+	"watch":                       true,
+	"workflow_call":               true,
+	"workflow_dispatch":           true,
+	"workflow_run":                true,
+}
+
+// TriggersLinter checks the workflow's on field for unknown events, risky
+// events, and missing triggers.
+type TriggersLinter struct {
+	noOpFixer
+}
+
+// NewTriggersLinter creates a new TriggersLinter instance.
+func NewTriggersLinter() *TriggersLinter {
+	return &TriggersLinter{}
+}
+
+// LintWorkflow checks a single workflow's triggers.
+func (l *TriggersLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	if !wf.IsWorkflow() {
+		return nil, nil
+	}
+
+	file := wf.BaseName()
+	triggers := wf.Triggers()
+	if len(triggers) == 0 {
+		return []*Issue{newRuleIssue(file, wf.FindTriggerLine(""), ruleTriggersMissing,
+			"Workflow has no triggers; add events under 'on'")}, nil
+	}
+
+	var issues []*Issue
+	for _, event := range triggers {
+		var ruleID, message string
+		switch {
+		case !knownEvents[event]:
+			ruleID = ruleTriggersUnknown
+			message = fmt.Sprintf("Unknown trigger event '%s'", event)
+		case event == "pull_request_target":
+			ruleID = ruleTriggersPullRequestTarget
+			message = "Trigger 'pull_request_target' runs with secrets and a write token for pull requests " +
+				"from forks; never check out or run the pull request's code"
+		}
+		if issue := newRuleIssue(file, wf.FindTriggerLine(event), ruleID, message); issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,100 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+func TestTriggersLinter_LintWorkflow(t *testing.T) {
+	const jobs = `jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`
+	tests := []struct {
+		name     string
+		on       string
+		expected []string
+	}{
+		{
+			name: "string form",
+			on:   "on: push\n",
+		},
+		{
+			name:     "string form with typo",
+			on:       "on: pul_request\n",
+			expected: []string{"1: Unknown trigger event 'pul_request'"},
+		},
+		{
+			name:     "list form with typo",
+			on:       "on:\n  - push\n  - pul_request\n",
+			expected: []string{"3: Unknown trigger event 'pul_request'"},
+		},
+		{
+			name: "map form",
+			on:   "on:\n  push:\n    branches: [main]\n  workflow_dispatch:\n",
+		},
+		{
+			name: "map form with typo and pull_request_target",
+			on:   "on:\n  pull_request_target:\n    types: [opened]\n  workflow-dispatch:\n",
+			expected: []string{
+				"2: Trigger 'pull_request_target' runs with secrets and a write token for pull requests " +
+					"from forks; never check out or run the pull request's code",
+				"4: Unknown trigger event 'workflow-dispatch'",
+			},
+		},
+		{
+			name:     "no on field",
+			expected: []string{"0: Workflow has no triggers; add events under 'on'"},
+		},
+		{
+			name:     "empty on field",
+			on:       "on:\n",
+			expected: []string{"1: Workflow has no triggers; add events under 'on'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", tt.on+jobs)
+			wf, err := workflow.LoadWorkflow(path)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			issues, err := NewTriggersLinter().LintWorkflow(wf)
+			if err != nil {
+				t.Fatalf("LintWorkflow() error = %v", err)
+			}
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, fmt.Sprintf("%d: %s", issue.Line, issue.Message))
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTriggersLinter_SkipsNonWorkflows(t *testing.T) {
+	path := testutil.CreateWorkflow(t, t.TempDir(), "action.yml", "name: My Action\nruns:\n  using: node20\n")
+	wf, err := workflow.LoadWorkflow(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	issues, err := NewTriggersLinter().LintWorkflow(wf)
+	if err != nil {
+		t.Fatalf("LintWorkflow() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("LintWorkflow() returned %d issues, want 0", len(issues))
+	}
+}
@@ -4,14 +4,43 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/version"
 	"github.com/reugn/github-ci/internal/workflow"
 )
 
+// Rules reported by the versions linter.
+const (
+	ruleVersionsTagNotHash      = "versions/tag-not-hash"
+	ruleVersionsMutableRef      = "versions/mutable-ref"
+	ruleVersionsDockerDigest    = "versions/docker-digest"
+	ruleVersionsMissingWorkflow = "versions/missing-workflow"
+	ruleVersionsUntrustedOwner  = "versions/untrusted-owner"
+)
+
 // VersionsLinter checks for actions using version tags instead of commit hashes.
 type VersionsLinter struct {
-	client actions.Resolver
+	client         actions.Resolver
+	managedComment string
+
+	// upgradeConfig enables upgrade suggestions when non-nil
+	upgradeConfig *config.Config
+	suggestionsMu sync.Mutex
+	suggestions   map[string]string // suggested tag by owner/repo@ref, "" if none
+
+	// checkWorkflows enables checking that called reusable workflows exist
+	checkWorkflows bool
+	existsMu       sync.Mutex
+	exists         map[string]bool // existence by workflow uses string
+
+	// fixPinComments enables adding version comments to hash pins when fixing
+	fixPinComments bool
+
+	// allowedOwners lists the owners actions may come from; empty allows every owner
+	allowedOwners []string
 }
 
 // NewVersionsLinter creates a new VersionsLinter instance with the provided context.
@@ -29,7 +58,41 @@ func NewVersionsLinterWithClient(client actions.Resolver) *VersionsLinter {
 	}
 }
 
-// LintWorkflow checks a single workflow for actions using version tags instead of commit hashes.
+// SetManagedComment sets the marker appended to the version comment of pinned actions.
+// An empty marker adds nothing.
+func (l *VersionsLinter) SetManagedComment(marker string) {
+	l.managedComment = marker
+}
+
+// SetUpgradeSuggestions enables suggesting the latest version allowed by each action's
+// upgrade constraint in cfg for actions pinned to a version tag. A nil cfg disables suggestions.
+func (l *VersionsLinter) SetUpgradeSuggestions(cfg *config.Config) {
+	l.upgradeConfig = cfg
+	l.suggestions = make(map[string]string)
+}
+
+// SetCheckReusableWorkflows enables checking that called reusable workflows exist
+// at the referenced ref.
+func (l *VersionsLinter) SetCheckReusableWorkflows(enabled bool) {
+	l.checkWorkflows = enabled
+	l.exists = make(map[string]bool)
+}
+
+// SetFixPinComments enables adding the tag that points to the commit as a version
+// comment (e.g., "# v4.1.0") to actions already pinned to a commit hash without a
+// comment, when fixing a workflow.
+func (l *VersionsLinter) SetFixPinComments(enabled bool) {
+	l.fixPinComments = enabled
+}
+
+// SetAllowedOwners sets the owners whose actions and reusable workflows may be used.
+// Actions from other owners are reported; an empty list allows every owner.
+func (l *VersionsLinter) SetAllowedOwners(owners []string) {
+	l.allowedOwners = owners
+}
+
+// LintWorkflow checks a single workflow for actions and reusable workflows using version tags
+// instead of commit hashes, and for Docker images (docker://) not pinned to a digest.
 func (l *VersionsLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
 	workflowActions, err := wf.FindActions()
 	if err != nil {
@@ -38,64 +101,240 @@ func (l *VersionsLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
 
 	var issues []*Issue
 	for _, action := range workflowActions {
+		if actions.IsDockerUses(action.Uses) {
+			if issue := newRuleIssue(wf.BaseName(), action.Line, ruleVersionsDockerDigest,
+				dockerImageMessage(action.Uses)); issue != nil {
+				issues = append(issues, issue)
+			}
+			continue
+		}
+
 		actionInfo, err := actions.ParseActionUses(action.Uses)
 		if err != nil {
 			continue
 		}
 
-		if !actions.IsCommitHash(actionInfo.Ref) {
-			message := fmt.Sprintf("Action %s uses version tag '%s' instead of commit hash",
-				action.Uses, actionInfo.Ref)
-			issues = append(issues, newIssue(wf.BaseName(), action.Line, message))
+		kind := "Action"
+		if actionInfo.IsReusableWorkflow() {
+			kind = "Reusable workflow"
+			if issue := newRuleIssue(wf.BaseName(), action.Line, ruleVersionsMissingWorkflow,
+				l.missingWorkflowMessage(actionInfo)); issue != nil {
+				issues = append(issues, issue)
+			}
+		}
+		if issue := newRuleIssue(wf.BaseName(), action.Line, ruleVersionsUntrustedOwner,
+			untrustedOwnerMessage(l.allowedOwners, kind, action.Uses, actionInfo.Owner)); issue != nil {
+			issues = append(issues, issue)
+		}
+
+		var ruleID, message string
+		switch {
+		case actions.IsLatestRef(actionInfo.Ref):
+			ruleID = ruleVersionsMutableRef
+			message = fmt.Sprintf("%s %s uses mutable ref '%s' instead of commit hash",
+				kind, action.Uses, actionInfo.Ref)
+		case !actions.IsCommitHash(actionInfo.Ref):
+			ruleID = ruleVersionsTagNotHash
+			message = fmt.Sprintf("%s %s uses version tag '%s' instead of commit hash",
+				kind, action.Uses, actionInfo.Ref)
+			if tag := l.upgradeSuggestion(actionInfo); tag != "" {
+				message += fmt.Sprintf(" (latest compatible: %s)", tag)
+			}
+		}
+		if issue := newRuleIssue(wf.BaseName(), action.Line, ruleID, message); issue != nil {
+			issues = append(issues, issue)
 		}
 	}
 
 	return issues, nil
 }
 
+// untrustedOwnerMessage returns the issue message for an action whose owner is not
+// in the allowed owners, or an empty string if it is or no owners are configured.
+// Owners are compared case-insensitively, as GitHub does.
+func untrustedOwnerMessage(allowedOwners []string, kind, uses, owner string) string {
+	if len(allowedOwners) == 0 {
+		return ""
+	}
+	for _, allowed := range allowedOwners {
+		if strings.EqualFold(allowed, owner) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s %s is from owner '%s', which is not in allowed-owners", kind, uses, owner)
+}
+
+// missingWorkflowMessage returns the issue message for a reusable workflow that does
+// not exist at its ref, or an empty string if it exists, checking is disabled, or the
+// lookup fails. Lookups are cached.
+func (l *VersionsLinter) missingWorkflowMessage(info *actions.ActionInfo) string {
+	if !l.checkWorkflows {
+		return ""
+	}
+
+	key := info.FormatUses(info.Ref)
+
+	l.existsMu.Lock()
+	defer l.existsMu.Unlock()
+	exists, ok := l.exists[key]
+	if !ok {
+		var err error
+		exists, err = l.client.FileExists(info.Owner, info.Repo, info.Path, info.Ref)
+		if err != nil {
+			exists = true
+		}
+		l.exists[key] = exists
+	}
+
+	if exists {
+		return ""
+	}
+	return fmt.Sprintf("Reusable workflow %s not found at ref '%s'", info.Name(), info.Ref)
+}
+
+// upgradeSuggestion returns the latest version of the action allowed by its upgrade
+// constraint if it is newer than the current ref. Lookups are cached, and failed lookups
+// return an empty string so the issue is reported without a suggestion.
+func (l *VersionsLinter) upgradeSuggestion(info *actions.ActionInfo) string {
+	if l.upgradeConfig == nil {
+		return ""
+	}
+
+	ref := strings.TrimPrefix(info.Ref, "tags/")
+	key := info.Name() + "@" + ref
+
+	l.suggestionsMu.Lock()
+	defer l.suggestionsMu.Unlock()
+	if tag, ok := l.suggestions[key]; ok {
+		return tag
+	}
+
+	tag, err := l.latestAllowedVersion(info, ref)
+	if err != nil || version.Compare(tag, ref) <= 0 {
+		tag = ""
+	}
+	l.suggestions[key] = tag
+	return tag
+}
+
+// latestAllowedVersion fetches the latest version of the action the upgrade command
+// would move to: constrained by its configured pattern, or unconstrained if it has none.
+func (l *VersionsLinter) latestAllowedVersion(info *actions.ActionInfo, ref string) (string, error) {
+	name := info.Name()
+	if upgrade := l.upgradeConfig.Upgrade; upgrade != nil {
+		if actionCfg, ok := upgrade.Actions[name]; ok {
+			tag, _, err := l.client.GetLatestVersion(info.Owner, info.Repo, ref, actionCfg.Constraint)
+			return tag, err
+		}
+	}
+	tag, _, err := l.client.GetLatestVersionUnconstrained(info.Owner, info.Repo)
+	return tag, err
+}
+
+// dockerImageMessage returns the issue message for a Docker image reference,
+// or an empty string if the image is pinned to a digest or cannot be parsed.
+func dockerImageMessage(uses string) string {
+	image, err := actions.ParseDockerUses(uses)
+	if err != nil || image.IsPinned() {
+		return ""
+	}
+
+	if image.Tag == "" {
+		return fmt.Sprintf("Docker image %s has no tag and resolves to mutable 'latest'; pin it by digest "+
+			"(docker://%s@sha256:...)", uses, image.Name())
+	}
+	return fmt.Sprintf("Docker image %s uses mutable tag '%s' instead of digest; pin it by digest "+
+		"(docker://%s@sha256:...)", uses, image.Tag, image.Name())
+}
+
 // FixWorkflow fixes issues in a single workflow by replacing version tags with commit hashes.
+// With SetFixPinComments, actions already pinned to a commit hash get a version comment.
 func (l *VersionsLinter) FixWorkflow(wf *workflow.Workflow) error {
-	workflowActions, err := wf.FindActions()
+	workflowActions, err := wf.FindActionsRaw()
 	if err != nil {
 		return fmt.Errorf("failed to find actions: %w", err)
 	}
 
+	// Updates are collected and applied at once, since each one rewrites every
+	// occurrence of its uses value
+	var updates []workflow.UsesUpdate
+	commented := commentedUses(workflowActions)
+	queued := make(map[string]bool)
 	for _, action := range workflowActions {
+		if queued[action.Uses] {
+			continue
+		}
 		actionInfo, err := actions.ParseActionUses(action.Uses)
 		if err != nil {
 			continue
 		}
 
-		if !actions.IsCommitHash(actionInfo.Ref) {
-			if err := l.resolveAndUpdateAction(wf, action, actionInfo); err != nil {
+		var update *workflow.UsesUpdate
+		switch {
+		case !actions.IsCommitHash(actionInfo.Ref):
+			if update, err = l.resolveUpdate(action, actionInfo); err != nil {
 				return err
 			}
+		case l.fixPinComments && !commented[action.Uses]:
+			update = l.pinCommentUpdate(action, actionInfo)
+		}
+		if update != nil {
+			updates = append(updates, *update)
+			queued[action.Uses] = true
 		}
 	}
 
+	if len(updates) == 0 {
+		return nil
+	}
+	if err := wf.UpdateActionsUses(updates, l.managedComment); err != nil {
+		return fmt.Errorf("failed to update actions in %s: %w", wf.File, err)
+	}
 	return nil
 }
 
-// resolveAndUpdateAction resolves an action reference to a commit hash and updates the workflow.
+// commentedUses returns the uses strings that have a line comment in any of their occurrences.
+// UpdateActionsUses rewrites every occurrence, so such pins are left alone to keep the comment.
+func commentedUses(workflowActions []*workflow.Action) map[string]bool {
+	commented := make(map[string]bool)
+	for _, action := range workflowActions {
+		if action.Node.LineComment != "" {
+			commented[action.Uses] = true
+		}
+	}
+	return commented
+}
+
+// pinCommentUpdate returns the update that adds the tag pointing to the commit hash of an
+// action as its version comment. If no tag points to the commit, or the lookup fails,
+// it returns nil and the action is left as is.
+func (l *VersionsLinter) pinCommentUpdate(action *workflow.Action, info *actions.ActionInfo) *workflow.UsesUpdate {
+	tag, err := l.client.GetTagForCommit(info.Owner, info.Repo, info.Ref)
+	if err != nil || tag == "" {
+		return nil
+	}
+	return &workflow.UsesUpdate{Old: action.Uses, New: action.Uses, Comment: tag}
+}
+
+// resolveUpdate resolves an action reference to a commit hash and returns the update that pins it.
 // If the ref is a major version only (e.g., "v3"), it finds the latest minor version in that series.
-func (l *VersionsLinter) resolveAndUpdateAction(wf *workflow.Workflow, action *workflow.Action,
-	info *actions.ActionInfo) error {
+func (l *VersionsLinter) resolveUpdate(action *workflow.Action,
+	info *actions.ActionInfo) (*workflow.UsesUpdate, error) {
 	ref := strings.TrimPrefix(info.Ref, "tags/")
 	tag, hash, err := l.resolveVersion(info.Owner, info.Repo, ref)
 	if err != nil {
-		return fmt.Errorf("failed to get commit hash for %s: %w", action.Uses, err)
-	}
-
-	newUses := fmt.Sprintf("%s/%s@%s", info.Owner, info.Repo, hash)
-	if err := wf.UpdateActionUses(action.Uses, newUses, tag); err != nil {
-		return fmt.Errorf("failed to update action in %s: %w", wf.File, err)
+		return nil, fmt.Errorf("failed to get commit hash for %s: %w", action.Uses, err)
 	}
-
-	return nil
+	return &workflow.UsesUpdate{Old: action.Uses, New: info.FormatUses(hash), Comment: tag}, nil
 }
 
 // resolveVersion resolves a version ref to its tag name and commit hash.
 func (l *VersionsLinter) resolveVersion(owner, repo, ref string) (tag, hash string, err error) {
+	// The "latest" literal resolves to the newest release or tag
+	if actions.IsLatestRef(ref) {
+		return l.client.GetLatestVersionUnconstrained(owner, repo)
+	}
+
 	// For major versions (e.g., "v3"), find the latest minor version
 	if actions.IsMajorVersionOnly(ref) {
 		tag, hash, err = l.client.GetLatestMinorVersion(owner, repo, ref)
@@ -113,3 +352,42 @@ func (l *VersionsLinter) resolveVersion(owner, repo, ref string) (tag, hash stri
 func (l *VersionsLinter) GetCacheStats() actions.CacheStats {
 	return l.client.GetCacheStats()
 }
+
+// ownersLinter reports actions and reusable workflows from owners that are not in the
+// versions linter's allowed-owners. It runs in place of the versions linter in offline
+// mode, since checking owners needs no GitHub API calls.
+type ownersLinter struct {
+	noOpFixer
+	allowedOwners []string
+}
+
+// LintWorkflow checks a single workflow for actions and reusable workflows from owners
+// that are not allowed.
+func (l *ownersLinter) LintWorkflow(wf *workflow.Workflow) ([]*Issue, error) {
+	workflowActions, err := wf.FindActions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find actions: %w", err)
+	}
+
+	var issues []*Issue
+	for _, action := range workflowActions {
+		if actions.IsDockerUses(action.Uses) {
+			continue
+		}
+		actionInfo, err := actions.ParseActionUses(action.Uses)
+		if err != nil {
+			continue
+		}
+
+		kind := "Action"
+		if actionInfo.IsReusableWorkflow() {
+			kind = "Reusable workflow"
+		}
+		if issue := newRuleIssue(wf.BaseName(), action.Line, ruleVersionsUntrustedOwner,
+			untrustedOwnerMessage(l.allowedOwners, kind, action.Uses, actionInfo.Owner)); issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
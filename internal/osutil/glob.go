@@ -0,0 +1,108 @@
+package osutil
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// globstar is the pattern segment that matches zero or more directories.
+const globstar = "**"
+
+// IsGlobPattern returns true if path contains glob metacharacters.
+func IsGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// Glob returns the paths matching pattern in lexical order.
+// In addition to the filepath.Match syntax, a "**" path segment
+// matches zero or more directories (e.g., ".github/**/*.yml").
+func Glob(pattern string) ([]string, error) {
+	pattern = filepath.Clean(pattern)
+	if !strings.Contains(pattern, globstar) {
+		return filepath.Glob(pattern)
+	}
+
+	// Walk from the longest prefix without metacharacters
+	patternParts := splitPath(pattern)
+	var matches []string
+	err := filepath.WalkDir(GlobRoot(pattern), func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ok, err := matchSegments(patternParts, splitPath(path))
+		if err != nil {
+			return err
+		}
+		if ok {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	slices.Sort(matches)
+	return matches, nil
+}
+
+// GlobRoot returns the longest leading directory of pattern without metacharacters,
+// or "." if the first segment has one. Every path matching pattern is under it.
+func GlobRoot(pattern string) string {
+	pattern = filepath.Clean(pattern)
+	patternParts := splitPath(pattern)
+	rootParts := patternParts
+	for i, part := range patternParts {
+		if IsGlobPattern(part) {
+			rootParts = patternParts[:i]
+			break
+		}
+	}
+	if len(rootParts) == 0 {
+		return "."
+	}
+	root := filepath.Join(rootParts...)
+	if filepath.IsAbs(pattern) {
+		root = string(filepath.Separator) + root
+	}
+	return root
+}
+
+// Match reports whether path matches pattern, with the same syntax as Glob.
+func Match(pattern, path string) (bool, error) {
+	return matchSegments(splitPath(filepath.Clean(pattern)), splitPath(filepath.Clean(path)))
+}
+
+// matchSegments reports whether the path segments match the pattern segments.
+func matchSegments(pattern, path []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == globstar {
+			// Try to match the rest of the pattern at every remaining depth
+			for i := 0; i <= len(path); i++ {
+				ok, err := matchSegments(pattern[1:], path[i:])
+				if ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+
+		if len(path) == 0 {
+			return false, nil
+		}
+		ok, err := filepath.Match(pattern[0], path[0])
+		if !ok || err != nil {
+			return false, err
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0, nil
+}
+
+// splitPath splits a cleaned path into its non-empty segments.
+func splitPath(path string) []string {
+	return strings.FieldsFunc(filepath.ToSlash(path), func(r rune) bool { return r == '/' })
+}
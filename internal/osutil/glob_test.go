@@ -0,0 +1,151 @@
+package osutil
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestIsGlobPattern(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{".github/workflows", false},
+		{".github/workflows/ci.yml", false},
+		{"*.yml", true},
+		{"**/*.yml", true},
+		{"ci.y?l", true},
+		{"[ab].yml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := IsGlobPattern(tt.path); got != tt.expected {
+				t.Errorf("IsGlobPattern(%q) = %v, want %v", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGlob(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := []string{
+		".github/workflows/ci.yml",
+		".github/workflows/release.yaml",
+		"actions/build/action.yml",
+		"actions/deploy/nested/action.yml",
+		"README.md",
+	}
+	for _, f := range files {
+		path := filepath.Join(tmpDir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		expected []string
+	}{
+		{
+			name:     "single level",
+			pattern:  ".github/workflows/*.yml",
+			expected: []string{".github/workflows/ci.yml"},
+		},
+		{
+			name:     "globstar",
+			pattern:  "**/*.yml",
+			expected: []string{".github/workflows/ci.yml", "actions/build/action.yml", "actions/deploy/nested/action.yml"},
+		},
+		{
+			name:     "globstar in the middle",
+			pattern:  "actions/**/action.yml",
+			expected: []string{"actions/build/action.yml", "actions/deploy/nested/action.yml"},
+		},
+		{
+			name:     "wildcard directory",
+			pattern:  "actions/*/action.yml",
+			expected: []string{"actions/build/action.yml"},
+		},
+		{
+			name:     "no matches",
+			pattern:  "**/*.json",
+			expected: nil,
+		},
+		{
+			name:     "missing root",
+			pattern:  "missing/**/*.yml",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := Glob(filepath.Join(tmpDir, tt.pattern))
+			if err != nil {
+				t.Fatalf("Glob() error = %v", err)
+			}
+
+			var got []string
+			for _, m := range matches {
+				rel, err := filepath.Rel(tmpDir, m)
+				if err != nil {
+					t.Fatalf("Rel() error = %v", err)
+				}
+				got = append(got, filepath.ToSlash(rel))
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Glob(%q) = %v, want %v", tt.pattern, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGlobRoot(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: ".github/workflows/*.yml", expected: filepath.Join(".github", "workflows")},
+		{pattern: "actions/**/action.yml", expected: "actions"},
+		{pattern: "**/*.yml", expected: "."},
+		{pattern: "/repo/*/ci.yml", expected: string(filepath.Separator) + "repo"},
+	}
+
+	for _, tt := range tests {
+		if got := GlobRoot(tt.pattern); got != tt.expected {
+			t.Errorf("GlobRoot(%q) = %q, want %q", tt.pattern, got, tt.expected)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{pattern: ".github/workflows/*.yml", path: ".github/workflows/ci.yml", expected: true},
+		{pattern: ".github/workflows/*.yml", path: ".github/workflows/nested/ci.yml", expected: false},
+		{pattern: "**/*.yml", path: "actions/deploy/nested/action.yml", expected: true},
+		{pattern: "**/*.yml", path: "./ci.yml", expected: true},
+		{pattern: "actions/**/action.yml", path: "actions/action.yaml", expected: false},
+	}
+
+	for _, tt := range tests {
+		got, err := Match(tt.pattern, tt.path)
+		if err != nil {
+			t.Fatalf("Match(%q, %q) error = %v", tt.pattern, tt.path, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package upgrader
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/version"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Answers to an interactive upgrade prompt.
+const (
+	answerAccept = "a"
+	answerSkip   = "s"
+	answerPin    = "p"
+	answerQuit   = "q"
+)
+
+// InteractiveUpgrade prompts on out for each action with an available update and reads
+// the answers from in, one per line:
+//   - a: upgrade the action in the configured format
+//   - s: leave the action unchanged
+//   - p: upgrade the action pinned to the commit hash of the new version
+//   - q: stop prompting and leave the remaining actions unchanged
+//
+// Each uses value is asked about once and updated in all workflows. With saveConstraints,
+// the constraint of each upgraded action is set to the major version of its new tag
+// (e.g., "^4.0.0") and the config file is saved.
+func (u *Upgrader) InteractiveUpgrade(in io.Reader, out io.Writer, saveConstraints bool) error {
+	cfg, err := u.loadAndInitConfig()
+	if err != nil {
+		return err
+	}
+
+	updates, err := u.findUpdates(cfg)
+	if err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		fmt.Fprintln(out, "✓ No updates available")
+		return nil
+	}
+
+	groups := groupUpdates(updates)
+	accepted, err := promptUpdates(bufio.NewScanner(in), out, groups)
+	if err != nil {
+		return err
+	}
+
+	constraints := make(map[string]string)
+	applied := make(map[*workflow.Workflow]map[string]bool)
+	for _, upd := range accepted {
+		if applied[upd.Workflow] == nil {
+			applied[upd.Workflow] = make(map[string]bool)
+		}
+		// An update rewrites every occurrence of the uses value in its workflow
+		if applied[upd.Workflow][upd.Action.Uses] {
+			continue
+		}
+		if err := u.applyUpdate(upd); err != nil {
+			return err
+		}
+		applied[upd.Workflow][upd.Action.Uses] = true
+		constraints[upd.ActionInfo.Name()] = fmt.Sprintf("^%d.0.0", version.ExtractMajor(upd.NewTag))
+	}
+	u.normalizeAllCommentSpacing()
+
+	if saveConstraints && len(constraints) > 0 {
+		return u.saveConstraints(constraints)
+	}
+	return nil
+}
+
+// groupUpdates groups updates by uses value, in the order each value is first found.
+func groupUpdates(updates []updateInfo) [][]updateInfo {
+	var groups [][]updateInfo
+	index := make(map[string]int)
+	for _, upd := range updates {
+		i, ok := index[upd.Action.Uses]
+		if !ok {
+			i = len(groups)
+			index[upd.Action.Uses] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], upd)
+	}
+	return groups
+}
+
+// promptUpdates asks about each group of updates and returns the accepted updates,
+// with the version format set to "hash" for pinned ones. The end of input is
+// treated like quitting.
+func promptUpdates(scanner *bufio.Scanner, out io.Writer, groups [][]updateInfo) ([]updateInfo, error) {
+	var accepted []updateInfo
+	for _, group := range groups {
+		printUpdatePrompt(out, group)
+
+		answer, err := readAnswer(scanner, out)
+		if err != nil {
+			return nil, err
+		}
+		switch answer {
+		case answerAccept:
+			accepted = append(accepted, group...)
+		case answerPin:
+			for _, upd := range group {
+				upd.VersionFormat = "hash"
+				accepted = append(accepted, upd)
+			}
+		case answerQuit:
+			return accepted, nil
+		}
+	}
+	return accepted, nil
+}
+
+// printUpdatePrompt prints the current ref, the latest tag, and its commit hash for a
+// group of updates of the same uses value.
+func printUpdatePrompt(out io.Writer, group []updateInfo) {
+	first := group[0]
+	fmt.Fprintf(out, "\n%s (%s:%d", first.ActionInfo.Name(), first.Workflow.File, first.Action.Line)
+	if len(group) > 1 {
+		fmt.Fprintf(out, " and %d more", len(group)-1)
+	}
+	fmt.Fprintln(out, ")")
+	current := first.ActionInfo.Ref
+	if first.CurrentTag != current {
+		current = fmt.Sprintf("%s (%s)", shortHash(current), first.CurrentTag)
+	}
+	fmt.Fprintf(out, "  current: %s\n", current)
+	fmt.Fprintf(out, "  latest:  %s (%s)\n", first.NewTag, shortHash(first.NewHash))
+	if first.Warning != "" {
+		fmt.Fprintf(out, "  ⚠ Warning: %s\n", first.Warning)
+	}
+}
+
+// readAnswer prompts until a valid answer is read. An empty answer skips the action,
+// and the end of input quits.
+func readAnswer(scanner *bufio.Scanner, out io.Writer) (string, error) {
+	for {
+		fmt.Fprint(out, "Upgrade? [a]ccept, [s]kip, [p]in to hash, [q]uit (default: skip): ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			if err := scanner.Err(); err != nil {
+				return "", fmt.Errorf("failed to read answer: %w", err)
+			}
+			return answerQuit, nil
+		}
+
+		answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		switch answer {
+		case "":
+			return answerSkip, nil
+		case answerAccept, answerSkip, answerPin, answerQuit:
+			return answer, nil
+		}
+		fmt.Fprintf(out, "Invalid answer %q\n", answer)
+	}
+}
+
+// saveConstraints sets the version constraint of each action in the config file and saves it.
+// Only the given actions are added, not every action discovered in the workflows.
+func (u *Upgrader) saveConstraints(constraints map[string]string) error {
+	configFile := u.configFile
+	if configFile == "" {
+		configFile = config.FindConfigFile(".")
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	for name, constraint := range constraints {
+		cfg.SetActionConfig(name, config.ActionConfig{Constraint: constraint})
+	}
+
+	if err := config.SaveConfig(cfg, configFile); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package upgrader
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+const interactiveWorkflow = `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3
+      - uses: actions/setup-go@v3
+      - uses: actions/cache@v3
+      - uses: actions/upload-artifact@v3
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3
+`
+
+const interactiveConfig = `upgrade:
+  format: tag
+  actions:
+    actions/checkout:
+      constraint: ""
+    actions/setup-go:
+      constraint: ""
+    actions/cache:
+      constraint: ""
+    actions/upload-artifact:
+      constraint: ""
+`
+
+func TestUpgrader_InteractiveUpgrade(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		saveConstraints bool
+		prompts         int
+		expected        []string
+		constraints     map[string]string
+	}{
+		{
+			name:    "accept, pin, skip, and quit",
+			input:   "a\np\ns\nq\n",
+			prompts: 4,
+			expected: []string{
+				"      - uses: actions/checkout@v4.0.0",
+				"      - uses: actions/setup-go@" + testHash + " # v4.0.0",
+				"      - uses: actions/cache@v3",
+				"      - uses: actions/upload-artifact@v3",
+				"      - uses: actions/checkout@v4.0.0",
+			},
+		},
+		{
+			name:    "invalid answer is asked again, empty answer skips",
+			input:   "x\na\n\n",
+			prompts: 4, // the end of input quits at the third action
+			expected: []string{
+				"      - uses: actions/checkout@v4.0.0",
+				"      - uses: actions/setup-go@v3",
+				"      - uses: actions/cache@v3",
+				"      - uses: actions/upload-artifact@v3",
+				"      - uses: actions/checkout@v4.0.0",
+			},
+		},
+		{
+			name:            "save constraints",
+			input:           "a\np\n",
+			saveConstraints: true,
+			prompts:         3,
+			expected: []string{
+				"      - uses: actions/checkout@v4.0.0",
+				"      - uses: actions/setup-go@" + testHash + " # v4.0.0",
+				"      - uses: actions/cache@v3",
+				"      - uses: actions/upload-artifact@v3",
+				"      - uses: actions/checkout@v4.0.0",
+			},
+			constraints: map[string]string{
+				"actions/checkout":        "^4.0.0",
+				"actions/setup-go":        "^4.0.0",
+				"actions/cache":           "",
+				"actions/upload-artifact": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			workflowPath := testutil.CreateWorkflow(t, tmpDir, "test.yml", interactiveWorkflow)
+			configPath := testutil.CreateConfig(t, tmpDir, interactiveConfig)
+
+			wf, err := workflow.LoadWorkflow(workflowPath)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			mockClient := &actions.MockResolver{
+				GetLatestVersionFunc: func(_, _, _, _ string) (string, string, error) {
+					return testVersionV4, testHash, nil
+				},
+			}
+
+			var out bytes.Buffer
+			upgrader := NewWithClient([]*workflow.Workflow{wf}, configPath, mockClient)
+			if err := upgrader.InteractiveUpgrade(strings.NewReader(tt.input), &out, tt.saveConstraints); err != nil {
+				t.Fatalf("InteractiveUpgrade() error = %v", err)
+			}
+
+			// The checkout action is used twice but asked about once
+			if got := strings.Count(out.String(), "Upgrade?"); got != tt.prompts {
+				t.Errorf("prompted %d times, want %d:\n%s", got, tt.prompts, out.String())
+			}
+			if !strings.Contains(out.String(), "and 1 more)") {
+				t.Errorf("output does not describe the repeated checkout action:\n%s", out.String())
+			}
+
+			data, err := os.ReadFile(workflowPath)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			lines := strings.Split(string(data), "\n")
+			got := []string{lines[6], lines[7], lines[8], lines[9], lines[13]}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("line %d = %q, want %q", i, got[i], tt.expected[i])
+				}
+			}
+
+			cfg, err := config.LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			for name, want := range tt.constraints {
+				if got := cfg.GetActionConfig(name).Constraint; got != want {
+					t.Errorf("constraint of %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package upgrader
+
+import (
+	"fmt"
+
+	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// unpinInfo holds information about a pending reversion of a commit hash pin to its tag.
+type unpinInfo struct {
+	Workflow   *workflow.Workflow
+	Action     *workflow.Action
+	ActionInfo *actions.ActionInfo
+	Tag        string
+}
+
+// Unpin replaces the commit hashes of actions pinned in all workflows with their version
+// tags and removes the version comments.
+func (u *Upgrader) Unpin() error {
+	unpins, err := u.findUnpins()
+	if err != nil {
+		return err
+	}
+
+	for _, unp := range unpins {
+		newUses := unp.ActionInfo.FormatUses(unp.Tag)
+		if err := unp.Workflow.UpdateActionUses(unp.Action.Uses, newUses, "", ""); err != nil {
+			return fmt.Errorf("failed to update action in %s: %w", unp.Workflow.File, err)
+		}
+		if err := unp.Workflow.Save(); err != nil {
+			return fmt.Errorf("failed to save %s: %w", unp.Workflow.File, err)
+		}
+	}
+
+	return nil
+}
+
+// UnpinDryRun shows which pinned actions would be unpinned without modifying files.
+func (u *Upgrader) UnpinDryRun() error {
+	unpins, err := u.findUnpins()
+	if err != nil {
+		return err
+	}
+
+	if len(unpins) == 0 {
+		fmt.Println("✓ No pinned actions to unpin")
+		return nil
+	}
+
+	fmt.Printf("Would unpin %d action(s):\n\n", len(unpins))
+	for _, unp := range unpins {
+		fmt.Printf("  %s:%d\n", unp.Workflow.File, unp.Action.Line)
+		fmt.Printf("    %s\n", unp.Action.Uses)
+		fmt.Printf("    → %s\n\n", unp.ActionInfo.FormatUses(unp.Tag))
+	}
+
+	return nil
+}
+
+// findUnpins scans all workflows and returns the actions pinned to a commit hash, with
+// the tag to revert each to. Each uses string is returned once per workflow, since
+// UpdateActionUses replaces all of its occurrences.
+func (u *Upgrader) findUnpins() ([]unpinInfo, error) {
+	var unpins []unpinInfo
+
+	for _, wf := range u.workflows {
+		wfActions, err := wf.FindActionsRaw()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find actions in %s: %w", wf.File, err)
+		}
+
+		seen := make(map[string]bool)
+		for _, action := range wfActions {
+			actionInfo, err := actions.ParseActionUses(action.Uses)
+			if err != nil || !actions.IsCommitHash(actionInfo.Ref) || seen[action.Uses] {
+				continue
+			}
+			seen[action.Uses] = true
+
+			tag := u.pinnedTag(action, actionInfo)
+			if tag == "" {
+				u.printWarning("skipping %s in %s: cannot resolve hash %s to a tag",
+					actionInfo.Name(), wf.File, shortHash(actionInfo.Ref))
+				continue
+			}
+			unpins = append(unpins, unpinInfo{
+				Workflow:   wf,
+				Action:     action,
+				ActionInfo: actionInfo,
+				Tag:        tag,
+			})
+		}
+	}
+
+	return unpins, nil
+}
+
+// pinnedTag returns the tag an action is pinned to: the version in its comment, or else
+// the tag that points to its commit hash. It returns an empty string if neither is found.
+func (u *Upgrader) pinnedTag(action *workflow.Action, info *actions.ActionInfo) string {
+	if tag := action.VersionComment(); tag != "" {
+		return tag
+	}
+
+	tag, err := u.client.GetTagForCommit(info.Owner, info.Repo, info.Ref)
+	if err != nil {
+		return ""
+	}
+	return tag
+}
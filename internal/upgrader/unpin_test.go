@@ -0,0 +1,105 @@
+package upgrader
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/actions"
+	"github.com/reugn/github-ci/internal/testutil"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+const unpinWorkflow = `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11 # v4.1.1 managed by github-ci
+      - uses: actions/setup-go@v5
+      - uses: actions/cache@1bd1e32a3bdc45362d1e726936510720a7c30a57
+      - uses: actions/upload-artifact@0000000000000000000000000000000000000000 # unreleased fix
+      - uses: docker://alpine@sha256:0000000000000000000000000000000000000000000000000000000000000000
+  call:
+    uses: octo-org/shared/.github/workflows/ci.yml@def456789012345678901234567890abcdef1234
+`
+
+func TestUpgrader_Unpin(t *testing.T) {
+	workflowPath := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", unpinWorkflow)
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	var lookups []string
+	mockClient := &actions.MockResolver{
+		GetTagForCommitFunc: func(_, repo, hash string) (string, error) {
+			lookups = append(lookups, repo)
+			switch hash {
+			case "1bd1e32a3bdc45362d1e726936510720a7c30a57":
+				return "v4.0.0", nil
+			case testHash:
+				return "v1.2.0", nil
+			}
+			return "", errors.New("tag not found")
+		},
+	}
+
+	if err := NewWithClient([]*workflow.Workflow{wf}, "", mockClient).Unpin(); err != nil {
+		t.Fatalf("Unpin() error = %v", err)
+	}
+
+	got, err := os.ReadFile(workflowPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	want := `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4.1.1
+      - uses: actions/setup-go@v5
+      - uses: actions/cache@v4.0.0
+      - uses: actions/upload-artifact@0000000000000000000000000000000000000000 # unreleased fix
+      - uses: docker://alpine@sha256:0000000000000000000000000000000000000000000000000000000000000000
+  call:
+    uses: octo-org/shared/.github/workflows/ci.yml@v1.2.0
+`
+	if string(got) != want {
+		t.Errorf("Unpin() result:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The checkout tag comes from its comment, so only the others are looked up
+	if len(lookups) != 3 {
+		t.Errorf("GetTagForCommit() called for %v, want cache, upload-artifact, and shared", lookups)
+	}
+}
+
+func TestUpgrader_UnpinDryRun(t *testing.T) {
+	workflowPath := testutil.CreateWorkflow(t, t.TempDir(), "test.yml", unpinWorkflow)
+	wf, err := workflow.LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	mockClient := &actions.MockResolver{
+		GetTagForCommitFunc: func(_, _, _ string) (string, error) {
+			return "", nil
+		},
+	}
+
+	if err := NewWithClient([]*workflow.Workflow{wf}, "", mockClient).UnpinDryRun(); err != nil {
+		t.Fatalf("UnpinDryRun() error = %v", err)
+	}
+
+	got, err := os.ReadFile(workflowPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(got) != unpinWorkflow {
+		t.Errorf("UnpinDryRun() modified the workflow:\n%s", got)
+	}
+}
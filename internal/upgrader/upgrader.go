@@ -39,11 +39,18 @@ func New(ctx context.Context, workflowsDir string) (*Upgrader, error) {
 }
 
 // NewWithWorkflows creates a new Upgrader with the provided workflows.
+// Rate limit retries are configured from run.max-retry-wait; an invalid config is
+// reported later by Upgrade or DryRun.
 func NewWithWorkflows(ctx context.Context, workflows []*workflow.Workflow, configFile string) *Upgrader {
+	client := actions.NewClientWithContext(ctx)
+	if cfg, err := config.LoadConfig(configFile); err == nil {
+		client.SetMaxRetryWait(cfg.GetMaxRetryWait())
+	}
+
 	return &Upgrader{
 		workflows:  workflows,
 		configFile: configFile,
-		client:     actions.NewClientWithContext(ctx),
+		client:     client,
 	}
 }
 
@@ -136,7 +143,7 @@ func (u *Upgrader) findUpdates(cfg *config.Config) ([]updateInfo, error) {
 	var updates []updateInfo
 
 	for _, wf := range u.workflows {
-		wfActions, err := wf.FindActions()
+		wfActions, err := wf.FindActionsRaw()
 		if err != nil {
 			return nil, fmt.Errorf("failed to find actions in %s: %w", wf.File, err)
 		}
@@ -218,17 +225,21 @@ func (u *Upgrader) resolveCurrentVersion(info *actions.ActionInfo) (string, stri
 
 	tag, err := u.client.GetTagForCommit(info.Owner, info.Repo, info.Ref)
 	if err != nil || tag == "" {
-		refPreview := info.Ref
-		if len(refPreview) > 12 {
-			refPreview = refPreview[:12]
-		}
 		warning := fmt.Sprintf("cannot resolve hash %s to a tag (may be unreleased commit)",
-			refPreview)
+			shortHash(info.Ref))
 		return info.Ref, warning
 	}
 	return tag, ""
 }
 
+// shortHash returns the first 12 characters of a commit hash for messages.
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
 // getLatestVersion fetches the latest version based on config constraints.
 func (u *Upgrader) getLatestVersion(cfg *config.Config, info *actions.ActionInfo, actionName,
 	currentVersion, constraint string) (string, string, error) {
@@ -244,10 +255,14 @@ func (u *Upgrader) applyUpdate(upd updateInfo) error {
 
 	// Build the new uses string, preserving path for composite actions
 	newUses := upd.ActionInfo.FormatUses(newRef)
-	if err := upd.Workflow.UpdateActionUses(upd.Action.Uses, newUses, comment); err != nil {
+	if err := upd.Workflow.UpdateActionUses(upd.Action.Uses, newUses, comment, ""); err != nil {
 		return fmt.Errorf("failed to update action in %s: %w", upd.Workflow.File, err)
 	}
 
+	if err := upd.Workflow.Save(); err != nil {
+		return fmt.Errorf("failed to save %s: %w", upd.Workflow.File, err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,45 @@
+package workflow
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/stringutil"
+)
+
+// blockScalarHeader matches a line whose value starts a literal (|) or folded (>)
+// block scalar, e.g. "run: |", "- script: >-", or "- |2", with an optional comment.
+// The first group is the indentation and sequence markers before the key or value.
+var blockScalarHeader = regexp.MustCompile(`^( *(?:- +)*)(?:[^\s#][^#]*?:[ \t]+)?[|>][-+1-9]*[ \t]*(?:#.*)?$`)
+
+// BlockScalarLines returns the 1-based numbers of lines that hold the content of
+// block scalars. Content ends at the first non-blank line that is not indented
+// deeper than the key (or sequence entry) that started the block.
+func BlockScalarLines(lines []string) map[int]bool {
+	result := make(map[int]bool)
+	parentIndent := -1 // indentation of the current block's parent, -1 outside a block
+
+	for i, line := range lines {
+		if parentIndent >= 0 {
+			if strings.TrimSpace(line) == "" || stringutil.CountLeadingSpaces(line) > parentIndent {
+				result[i+1] = true
+				continue
+			}
+			parentIndent = -1
+		}
+
+		match := blockScalarHeader.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		prefix := match[1]
+		if rest := line[len(prefix):]; rest[0] == '|' || rest[0] == '>' {
+			// A bare "- |" entry: content is indented relative to the dash
+			parentIndent = strings.LastIndex(prefix, "-")
+		} else {
+			parentIndent = len(prefix)
+		}
+	}
+
+	return result
+}
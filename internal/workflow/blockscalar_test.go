@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBlockScalarLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []int
+	}{
+		{
+			name: "literal run script",
+			content: `jobs:
+  build:
+    steps:
+      - run: |
+          echo one
+
+          echo two
+      - name: Next
+`,
+			expected: []int{5, 6, 7},
+		},
+		{
+			name: "folded with chomping indicator and comment",
+			content: `env:
+  MESSAGE: >- # joined
+    hello
+    world
+name: Test
+`,
+			expected: []int{3, 4},
+		},
+		{
+			name: "bare sequence entry",
+			content: `args:
+  - |
+    line
+  - other
+`,
+			expected: []int{3},
+		},
+		{
+			name: "no block scalars",
+			content: `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "a | b"
+`,
+			expected: nil,
+		},
+		{
+			name: "pipe inside quoted value",
+			content: `name: "Build |"
+run: echo >
+`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Sorted(maps.Keys(BlockScalarLines(strings.Split(tt.content, "\n"))))
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("BlockScalarLines() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
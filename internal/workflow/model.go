@@ -0,0 +1,98 @@
+package workflow
+
+import "gopkg.in/yaml.v3"
+
+// Model is the job and step structure of a workflow with the line numbers of its parts.
+// It is built from the parsed YAML once per workflow content and shared by the linters,
+// so they don't scan the workflow lines again to locate each job and step.
+type Model struct {
+	Jobs []*Job // Jobs in file order
+
+	jobs map[string]*Job
+}
+
+// Job is a job of a workflow.
+type Job struct {
+	ID    string
+	Line  int      // Line of the job key
+	Keys  []string // Keys of the job in file order
+	Steps []*Step  // Steps in file order
+}
+
+// Step is a step of a job.
+type Step struct {
+	Index int      // Position in the job's steps
+	Line  int      // Line of the step
+	Keys  []string // Keys of the step in file order, empty for an alias (- *name)
+}
+
+// Model returns the job and step structure of the workflow. It is built on first use
+// and cached until the workflow content changes.
+func (w *Workflow) Model() (*Model, error) {
+	if w.model != nil {
+		return w.model, nil
+	}
+
+	node, err := w.getNode()
+	if err != nil {
+		return nil, err
+	}
+	w.model = newModel(node)
+	return w.model, nil
+}
+
+// Job returns the job with the given ID, or nil if the workflow has no such job.
+func (m *Model) Job(id string) *Job {
+	return m.jobs[id]
+}
+
+// Step returns the step at index in the job's steps, or nil if there is none.
+func (j *Job) Step(index int) *Step {
+	if j == nil || index < 0 || index >= len(j.Steps) {
+		return nil
+	}
+	return j.Steps[index]
+}
+
+// newModel builds the model of a parsed workflow document. Jobs and steps given as
+// aliases keep the line of the alias; the steps of an aliased job are not listed.
+func newModel(node *yaml.Node) *Model {
+	m := &Model{jobs: make(map[string]*Job)}
+	if len(node.Content) == 0 {
+		return m
+	}
+
+	jobs := mappingValue(node.Content[0], "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return m
+	}
+	for i := 0; i < len(jobs.Content)-1; i += 2 {
+		key, value := jobs.Content[i], jobs.Content[i+1]
+		job := &Job{ID: key.Value, Line: key.Line, Keys: mappingKeys(value)}
+
+		if steps := mappingValue(value, "steps"); steps != nil && steps.Kind == yaml.SequenceNode {
+			for index, step := range steps.Content {
+				job.Steps = append(job.Steps, &Step{Index: index, Line: step.Line, Keys: mappingKeys(step)})
+			}
+		}
+
+		m.Jobs = append(m.Jobs, job)
+		if _, ok := m.jobs[job.ID]; !ok {
+			m.jobs[job.ID] = job
+		}
+	}
+	return m
+}
+
+// mappingKeys returns the keys of a mapping node in order, or nil for other nodes.
+func mappingKeys(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	keys := make([]string, 0, len(node.Content)/2)
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
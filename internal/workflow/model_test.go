@@ -0,0 +1,158 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWorkflow_Model(t *testing.T) {
+	content := `on:
+  push:
+x-checkout: &checkout
+  uses: actions/checkout@v4
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    steps:
+      - *checkout
+      - uses: actions/setup-go@v5
+        name: Setup Go
+        with:
+          go-version: stable
+      - name: Test
+        run: |
+          go test ./...
+          - not a step
+  push:
+    runs-on: ubuntu-latest
+    needs: build
+    steps:
+      -
+        run: make release
+  Build: &job
+    runs-on: ubuntu-latest
+  lint: *job
+`
+	wf, err := ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	model, err := wf.Model()
+	if err != nil {
+		t.Fatalf("Model() error = %v", err)
+	}
+
+	var got []string
+	for _, job := range model.Jobs {
+		got = append(got, fmt.Sprintf("%d: %s [%s]", job.Line, job.ID, strings.Join(job.Keys, " ")))
+		for _, step := range job.Steps {
+			got = append(got, fmt.Sprintf("  %d: %d [%s]", step.Line, step.Index, strings.Join(step.Keys, " ")))
+		}
+	}
+	expected := []string{
+		"6: build [name runs-on steps]",
+		"  10: 0 []",
+		"  11: 1 [uses name with]",
+		"  15: 2 [name run]",
+		"19: push [runs-on needs steps]",
+		"  24: 0 [run]",
+		"25: Build [runs-on]",
+		"27: lint []",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Model() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if job := model.Job("push"); job == nil || job.Line != 19 {
+		t.Errorf("Job(%q) = %+v, want the job at line 19", "push", job)
+	}
+	if job := model.Job("deploy"); job != nil {
+		t.Errorf("Job(%q) = %+v, want nil", "deploy", job)
+	}
+	if step := model.Job("build").Step(3); step != nil {
+		t.Errorf("Step(3) = %+v, want nil", step)
+	}
+	if step := model.Job("deploy").Step(0); step != nil {
+		t.Errorf("Step(0) of missing job = %+v, want nil", step)
+	}
+}
+
+func TestWorkflow_Model_Invalidation(t *testing.T) {
+	content := "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"
+	wf, err := ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	first, err := wf.Model()
+	if err != nil {
+		t.Fatalf("Model() error = %v", err)
+	}
+	if cached, _ := wf.Model(); cached != first {
+		t.Error("Model() was rebuilt without changes")
+	}
+
+	if err := wf.SetRawBytes([]byte("on: push\n\njobs:\n  build:\n    runs-on: ubuntu-latest\n")); err != nil {
+		t.Fatalf("SetRawBytes() error = %v", err)
+	}
+	if got := wf.FindJobLine("build"); got != 4 {
+		t.Errorf("FindJobLine() after SetRawBytes = %d, want 4", got)
+	}
+
+	if err := wf.InsertPermissions("read-all"); err != nil {
+		t.Fatalf("InsertPermissions() error = %v", err)
+	}
+	if got := wf.FindJobLine("build"); got != 5 {
+		t.Errorf("FindJobLine() after InsertPermissions = %d, want 5", got)
+	}
+}
+
+func TestWorkflow_FindLines_LargeWorkflow(t *testing.T) {
+	const jobs, steps = 50, 20
+
+	var b strings.Builder
+	b.WriteString("on: push\njobs:\n")
+	line := 2
+	jobLines := make(map[string]int)
+	stepLines := make(map[string][]int)
+	for j := range jobs {
+		id := fmt.Sprintf("job-%d", j)
+		fmt.Fprintf(&b, "  %s:\n    runs-on: ubuntu-latest\n    steps:\n", id)
+		jobLines[id] = line + 1
+		line += 3
+		for s := range steps {
+			fmt.Fprintf(&b, "      - name: Step %d\n        run: echo %d\n", s, s)
+			stepLines[id] = append(stepLines[id], line+1)
+			line += 2
+		}
+	}
+
+	wf, err := ParseWorkflow("test.yml", []byte(b.String()))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+	model, err := wf.Model()
+	if err != nil {
+		t.Fatalf("Model() error = %v", err)
+	}
+
+	for id, want := range jobLines {
+		if got := wf.FindJobLine(id); got != want {
+			t.Errorf("FindJobLine(%q) = %d, want %d", id, got, want)
+		}
+		for i, want := range stepLines[id] {
+			if got := wf.FindStepLine(id, i); got != want {
+				t.Errorf("FindStepLine(%q, %d) = %d, want %d", id, i, got, want)
+			}
+		}
+	}
+
+	// The lookups read the index built by the first call
+	if cached, _ := wf.Model(); cached != model {
+		t.Error("FindJobLine() or FindStepLine() rebuilt the model")
+	}
+}
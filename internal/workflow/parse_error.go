@@ -0,0 +1,90 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlLinePattern matches the line number in a YAML error message (e.g., "yaml: line 5: ...").
+var yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+
+// ParseError reports a workflow file that isn't valid YAML.
+type ParseError struct {
+	File   string // Path of the workflow file
+	Line   int    // 1-based line of the error (0 if unknown)
+	Reason string // Description of the error, without the line number
+	Err    error  // Underlying YAML error
+}
+
+// newParseError describes a YAML error in the workflow file at path. YAML doesn't allow
+// tabs for indentation, and the parser's message for them is obscure (and sometimes
+// points at the line before), so the first tab-indented line is reported if any.
+func newParseError(path string, data []byte, err error) *ParseError {
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "\t") {
+			return &ParseError{
+				File:   path,
+				Line:   i + 1,
+				Reason: "tab used for indentation, but YAML only allows spaces",
+				Err:    err,
+			}
+		}
+	}
+
+	// A type error lists one message per mismatched value; report the first
+	message := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		message = typeErr.Errors[0]
+	}
+
+	parseErr := &ParseError{File: path, Reason: strings.TrimPrefix(message, "yaml: "), Err: err}
+	if m := yamlLinePattern.FindStringSubmatch(message); m != nil {
+		parseErr.Line, _ = strconv.Atoi(m[1])
+		parseErr.Reason = message[len(m[0]):]
+	}
+	return parseErr
+}
+
+// Error returns the error message, including the line number if known.
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("failed to parse YAML: line %d: %s", e.Line, e.Reason)
+	}
+	return "failed to parse YAML: " + e.Reason
+}
+
+// Unwrap returns the underlying YAML error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors returns the parse errors in err, which may join several errors, and
+// reports whether err consists only of parse errors. A nil err has none and returns true.
+func ParseErrors(err error) ([]*ParseError, bool) {
+	switch e := err.(type) {
+	case nil:
+		return nil, true
+	case *ParseError:
+		return []*ParseError{e}, true
+	case interface{ Unwrap() []error }:
+		var parseErrs []*ParseError
+		onlyParse := true
+		for _, inner := range e.Unwrap() {
+			errs, ok := ParseErrors(inner)
+			parseErrs = append(parseErrs, errs...)
+			onlyParse = onlyParse && ok
+		}
+		return parseErrs, onlyParse
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			return ParseErrors(inner)
+		}
+	}
+	return nil, false
+}
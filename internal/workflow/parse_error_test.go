@@ -0,0 +1,122 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseWorkflow_ParseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantLine   int
+		wantReason string
+	}{
+		{
+			name:       "syntax error",
+			content:    "name: Test\non: push\njobs: {build: [\n",
+			wantLine:   3,
+			wantReason: "did not find expected node content",
+		},
+		{
+			name:       "type error",
+			content:    "name: Test\non: push\njobs: [build]\n",
+			wantLine:   3,
+			wantReason: "cannot unmarshal !!seq into map[string]interface {}",
+		},
+		{
+			name:       "tab indentation",
+			content:    "name: Test\non: push\njobs:\n  build:\n\truns-on: ubuntu-latest\n",
+			wantLine:   5,
+			wantReason: "tab used for indentation, but YAML only allows spaces",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWorkflow("ci.yml", []byte(tt.content))
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseWorkflow() error = %v, want a *ParseError", err)
+			}
+			if parseErr.File != "ci.yml" || parseErr.Line != tt.wantLine || parseErr.Reason != tt.wantReason {
+				t.Errorf("ParseError = {%s %d %q}, want {ci.yml %d %q}",
+					parseErr.File, parseErr.Line, parseErr.Reason, tt.wantLine, tt.wantReason)
+			}
+			if want := fmt.Sprintf("failed to parse YAML: line %d: %s", tt.wantLine, tt.wantReason); err.Error() != want {
+				t.Errorf("ParseWorkflow() error = %q, want %q", err, want)
+			}
+		})
+	}
+}
+
+func TestLoadWorkflows_ParseErrors(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"good.yml":   "name: Good\non: push\njobs: {}\n",
+		"broken.yml": "name: Broken\non: push\njobs: {build: [\n",
+		"typed.yaml": "name: Typed\non: push\njobs: [build]\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	workflows, err := LoadWorkflows(dir)
+	if len(workflows) != 1 || workflows[0].BaseName() != "good.yml" {
+		t.Errorf("LoadWorkflows() = %v, want only good.yml", workflows)
+	}
+
+	parseErrs, onlyParse := ParseErrors(err)
+	if !onlyParse || len(parseErrs) != 2 {
+		t.Fatalf("ParseErrors(%v) = %v, %v, want 2 parse errors only", err, parseErrs, onlyParse)
+	}
+	for _, parseErr := range parseErrs {
+		if parseErr.Line == 0 {
+			t.Errorf("ParseError for %s has no line", parseErr.File)
+		}
+	}
+
+	// LoadPaths keeps the workflows that loaded as well
+	workflows, err = LoadPaths([]string{dir})
+	if len(workflows) != 1 {
+		t.Errorf("LoadPaths() returned %d workflows, want 1", len(workflows))
+	}
+	if parseErrs, onlyParse := ParseErrors(err); !onlyParse || len(parseErrs) != 2 {
+		t.Errorf("LoadPaths() error = %v, want 2 parse errors", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	parseErr := &ParseError{File: "ci.yml", Line: 1, Reason: "bad"}
+	other := errors.New("permission denied")
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCount     int
+		wantOnlyParse bool
+	}{
+		{"nil", nil, 0, true},
+		{"parse error", parseErr, 1, true},
+		{"wrapped", fmt.Errorf("failed to load workflow: %w", parseErr), 1, true},
+		{"joined", errors.Join(parseErr, fmt.Errorf("wrapped: %w", parseErr)), 2, true},
+		{"wrapped join", fmt.Errorf("load: %w", errors.Join(parseErr, parseErr)), 2, true},
+		{"other error", other, 0, false},
+		{"mixed", errors.Join(parseErr, other), 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parseErrs, onlyParse := ParseErrors(tt.err)
+			if len(parseErrs) != tt.wantCount || onlyParse != tt.wantOnlyParse {
+				t.Errorf("ParseErrors() = %d error(s), %v, want %d, %v",
+					len(parseErrs), onlyParse, tt.wantCount, tt.wantOnlyParse)
+			}
+		})
+	}
+}
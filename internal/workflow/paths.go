@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/reugn/github-ci/internal/osutil"
+)
+
+// LoadPath loads workflows from the specified path, which can be a directory or a file.
+// For a directory, the workflows that loaded are returned along with any errors, as for
+// LoadWorkflows.
+func LoadPath(path string) ([]*Workflow, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access path %s: %w", path, err)
+	}
+
+	if info.IsDir() {
+		return LoadWorkflows(path)
+	}
+
+	// Single file
+	wf, err := LoadWorkflow(path)
+	if err != nil {
+		return nil, err
+	}
+	return []*Workflow{wf}, nil
+}
+
+// LoadPaths loads and merges workflows from multiple paths.
+// Each path can be a directory, a file, or a glob pattern (e.g., "**/*.yml").
+// Files matched by more than one path are loaded only once. Composite action metadata
+// files (action.yml) are loaded along with workflows. Other files (non-YAML files, or
+// YAML without "on" or "jobs") are skipped silently, so changed-file lists from tools
+// such as pre-commit can be passed as-is.
+// Files that aren't valid YAML don't stop the others from loading: the workflows that
+// loaded are returned along with the joined parse errors (see ParseErrors).
+func LoadPaths(paths []string) ([]*Workflow, error) {
+	var workflows []*Workflow
+	var errs []error
+	seen := make(map[string]bool)
+
+	for _, path := range paths {
+		loaded, err := loadPathOrPattern(path)
+		if err != nil {
+			if _, onlyParse := ParseErrors(err); !onlyParse {
+				return nil, err
+			}
+			errs = append(errs, err)
+		}
+
+		for _, wf := range loaded {
+			if !wf.IsWorkflow() && !wf.IsCompositeAction() {
+				continue
+			}
+			key := wf.File
+			if abs, err := filepath.Abs(wf.File); err == nil {
+				key = abs
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			workflows = append(workflows, wf)
+		}
+	}
+
+	return workflows, errors.Join(errs...)
+}
+
+// loadPathOrPattern loads workflows from a directory, a file, or a glob pattern.
+func loadPathOrPattern(path string) ([]*Workflow, error) {
+	if !osutil.IsGlobPattern(path) {
+		return loadWorkflowPath(path)
+	}
+
+	matches, err := osutil.Glob(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", path, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("pattern %q matched no files", path)
+	}
+
+	var workflows []*Workflow
+	var errs []error
+	for _, match := range matches {
+		loaded, err := loadWorkflowPath(match)
+		if err != nil {
+			if _, onlyParse := ParseErrors(err); !onlyParse {
+				return nil, err
+			}
+			errs = append(errs, err)
+		}
+		workflows = append(workflows, loaded...)
+	}
+	return workflows, errors.Join(errs...)
+}
+
+// loadWorkflowPath loads workflows from a directory or a file, returning no
+// workflows for files without a YAML extension.
+func loadWorkflowPath(path string) ([]*Workflow, error) {
+	info, err := os.Stat(path)
+	if err == nil && !info.IsDir() && !IsYAMLFile(path) {
+		return nil, nil
+	}
+	return LoadPath(path)
+}
@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/testutil"
+)
+
+func TestLoadPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	actionsDir := filepath.Join(tmpDir, "ci", "nested")
+	compositeDir := filepath.Join(tmpDir, "ci", "build")
+	for _, dir := range []string{workflowsDir, actionsDir, compositeDir} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+	}
+
+	content := "name: Test\non: push\n"
+	testutil.CreateWorkflow(t, workflowsDir, "ci.yml", content)
+	testutil.CreateWorkflow(t, workflowsDir, "release.yaml", content)
+	testutil.CreateWorkflow(t, actionsDir, "extra.yml", content)
+	testutil.CreateWorkflow(t, actionsDir, "action.yml", "name: Action\nruns:\n  using: node20\n")
+	testutil.CreateWorkflow(t, compositeDir, "action.yml",
+		"name: Build\nruns:\n  using: composite\n  steps:\n    - run: make\n      shell: bash\n")
+	testutil.CreateWorkflow(t, tmpDir, "README.md", "# Readme\n")
+	t.Chdir(tmpDir)
+
+	tests := []struct {
+		name     string
+		paths    []string
+		expected []string
+		wantErr  string
+	}{
+		{
+			name:     "directory",
+			paths:    []string{".github/workflows"},
+			expected: []string{"ci.yml", "release.yaml"},
+		},
+		{
+			name:     "multiple files",
+			paths:    []string{".github/workflows/release.yaml", "ci/nested/extra.yml"},
+			expected: []string{"release.yaml", "extra.yml"},
+		},
+		{
+			name: "mixed file args skip non-workflow files",
+			paths: []string{
+				"README.md", ".github/workflows/ci.yml", "ci/nested/action.yml", "ci/nested/extra.yml",
+			},
+			expected: []string{"ci.yml", "extra.yml"},
+		},
+		{
+			name:     "only non-workflow files",
+			paths:    []string{"README.md", "ci/nested/action.yml"},
+			expected: []string{},
+		},
+		{
+			name:     "composite action",
+			paths:    []string{"ci/build/action.yml", "ci/nested/action.yml"},
+			expected: []string{"action.yml"},
+		},
+		{
+			name:     "globstar pattern",
+			paths:    []string{"**/*.yml"},
+			expected: []string{"ci.yml", "action.yml", "extra.yml"},
+		},
+		{
+			name:     "overlapping paths are deduplicated",
+			paths:    []string{".github/workflows", "**/*.yml", "./.github/workflows/ci.yml"},
+			expected: []string{"ci.yml", "release.yaml", "action.yml", "extra.yml"},
+		},
+		{
+			name:    "pattern without matches",
+			paths:   []string{".github/workflows", "**/*.json"},
+			wantErr: `pattern "**/*.json" matched no files`,
+		},
+		{
+			name:    "missing path",
+			paths:   []string{"missing.yml"},
+			wantErr: "failed to access path missing.yml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflows, err := LoadPaths(tt.paths)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadPaths() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadPaths() error = %v", err)
+			}
+
+			got := make([]string, 0, len(workflows))
+			for _, wf := range workflows {
+				got = append(got, wf.BaseName())
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("LoadPaths() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
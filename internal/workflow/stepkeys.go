@@ -0,0 +1,177 @@
+package workflow
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/reugn/github-ci/internal/stringutil"
+	"gopkg.in/yaml.v3"
+)
+
+// keyBlock is the range of lines holding a mapping key, its value, and the comments
+// directly above it.
+type keyBlock struct {
+	key        string
+	line       int // 0-based line index of the key
+	start, end int // 0-based line indexes, inclusive
+}
+
+// SortStepKeys reorders the keys of each step so that the keys listed in order come
+// first, in that order, followed by any other keys in their original order. Each key
+// moves with its value and the comments directly above it, so values and comments are
+// kept as written. Steps in flow style, using merge keys, or laid out unusually (e.g.,
+// with "-" on a line of its own) are left alone. Returns true if the workflow changed.
+func (w *Workflow) SortStepKeys(order []string) (bool, error) {
+	node, err := w.getNode()
+	if err != nil {
+		return false, err
+	}
+	if len(node.Content) == 0 {
+		return false, nil
+	}
+	jobs := mappingValue(node.Content[0], "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return false, nil
+	}
+
+	// Reordering keeps each step on the same lines, so steps can be sorted one by one
+	lines := w.Lines()
+	changed := false
+	for i := 1; i < len(jobs.Content); i += 2 {
+		steps := mappingValue(jobs.Content[i], "steps")
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, step := range steps.Content {
+			if sortStepKeyLines(lines, step, order) {
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+	return true, w.SetRawBytes([]byte(strings.Join(lines, "\n")))
+}
+
+// sortStepKeyLines reorders the lines of a step's keys in place. Returns false, leaving
+// lines unchanged, if the keys are already in order or the step can't be reordered.
+func sortStepKeyLines(lines []string, step *yaml.Node, order []string) bool {
+	blocks, ok := stepKeyBlocks(lines, step)
+	if !ok {
+		return false
+	}
+
+	rank := func(key string) int {
+		if i := slices.Index(order, key); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	sorted := slices.SortedStableFunc(slices.Values(blocks), func(a, b keyBlock) int {
+		return rank(a.key) - rank(b.key)
+	})
+	if slices.Equal(sorted, blocks) {
+		return false
+	}
+
+	// The first key shares its line with the "-" of the sequence item
+	keyIndent := step.Content[0].Column - 1
+	dashPrefix := lines[blocks[0].start][:keyIndent]
+	dashIndent := strings.Repeat(" ", strings.Index(dashPrefix, "-"))
+	keyPrefix := strings.Repeat(" ", keyIndent)
+
+	reordered := make([]string, 0, blocks[len(blocks)-1].end-blocks[0].start+1)
+	for i, block := range sorted {
+		for j, line := range lines[block.start : block.end+1] {
+			switch {
+			case i > 0 && j == 0:
+				line = keyPrefix + line[keyIndent:]
+			case i == 0 && block.start+j < block.line:
+				// Comments above the new first key go above the "-"
+				line = dashIndent + line[keyIndent:]
+			case i == 0 && block.start+j == block.line:
+				line = dashPrefix + line[keyIndent:]
+			}
+			reordered = append(reordered, line)
+		}
+	}
+	copy(lines[blocks[0].start:], reordered)
+	return true
+}
+
+// stepKeyBlocks splits the lines of a block-style step into one block per key.
+// Returns false if the step isn't laid out as "- key: ..." followed by one key per line.
+func stepKeyBlocks(lines []string, step *yaml.Node) ([]keyBlock, bool) {
+	if step.Kind != yaml.MappingNode || step.Style&yaml.FlowStyle != 0 || len(step.Content) < 4 {
+		return nil, false
+	}
+
+	first := step.Content[0]
+	keyIndent := first.Column - 1
+	if first.Line > len(lines) || len(lines[first.Line-1]) < keyIndent ||
+		strings.TrimSpace(lines[first.Line-1][:keyIndent]) != "-" {
+		return nil, false
+	}
+
+	blocks := make([]keyBlock, 0, len(step.Content)/2)
+	for i := 0; i < len(step.Content)-1; i += 2 {
+		key := step.Content[i]
+		if key.Value == "<<" || key.Column != first.Column {
+			return nil, false
+		}
+
+		block := keyBlock{key: key.Value, line: key.Line - 1, start: key.Line - 1}
+		if n := len(blocks); n > 0 {
+			prev := &blocks[n-1]
+			if block.line <= prev.line || len(lines[block.line]) < keyIndent ||
+				strings.TrimSpace(lines[block.line][:keyIndent]) != "" {
+				return nil, false
+			}
+			// Comments directly above a key move with it
+			for block.start-1 > prev.line && isCommentAt(lines[block.start-1], keyIndent) {
+				block.start--
+			}
+			prev.end = block.start - 1
+		}
+		blocks = append(blocks, block)
+	}
+
+	last := &blocks[len(blocks)-1]
+	last.end = valueEnd(lines, step.Content[len(step.Content)-1], keyIndent)
+	return blocks, true
+}
+
+// valueEnd returns the 0-based index of the last line of a value whose key is indented
+// by keyIndent: the last line of its nodes, extended over deeper-indented lines such as
+// block scalar content, excluding trailing blank lines.
+func valueEnd(lines []string, value *yaml.Node, keyIndent int) int {
+	start := lastNodeLine(value) - 1
+	end := start
+	for end+1 < len(lines) {
+		next := lines[end+1]
+		if strings.TrimSpace(next) != "" && stringutil.CountLeadingSpaces(next) <= keyIndent {
+			break
+		}
+		end++
+	}
+	for end > start && strings.TrimSpace(lines[end]) == "" {
+		end--
+	}
+	return end
+}
+
+// lastNodeLine returns the largest line number of node and its descendants.
+func lastNodeLine(node *yaml.Node) int {
+	line := node.Line
+	for _, child := range node.Content {
+		line = max(line, lastNodeLine(child))
+	}
+	return line
+}
+
+// isCommentAt checks if line is a comment indented by exactly indent spaces.
+func isCommentAt(line string, indent int) bool {
+	return stringutil.CountLeadingSpaces(line) == indent && strings.HasPrefix(line[indent:], "#")
+}
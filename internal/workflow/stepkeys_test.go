@@ -0,0 +1,161 @@
+package workflow
+
+import "testing"
+
+func TestWorkflow_SortStepKeys(t *testing.T) {
+	order := []string{"name", "id", "if", "uses", "run", "with", "env"}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		changed  bool
+	}{
+		{
+			name: "moves name first",
+			input: `jobs:
+  build:
+    steps:
+      - uses: actions/checkout@v4
+        name: Checkout
+      - run: make
+`,
+			expected: `jobs:
+  build:
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v4
+      - run: make
+`,
+			changed: true,
+		},
+		{
+			name: "orders known keys and keeps others last",
+			input: `jobs:
+  build:
+    steps:
+      - env:
+          GOFLAGS: -mod=mod
+        continue-on-error: true
+        with:
+          go-version: stable
+        uses: actions/setup-go@v5
+        if: github.event_name == 'push'
+        id: setup
+        name: Setup Go
+`,
+			expected: `jobs:
+  build:
+    steps:
+      - name: Setup Go
+        id: setup
+        if: github.event_name == 'push'
+        uses: actions/setup-go@v5
+        with:
+          go-version: stable
+        env:
+          GOFLAGS: -mod=mod
+        continue-on-error: true
+`,
+			changed: true,
+		},
+		{
+			name: "keeps comments with their keys",
+			input: `jobs:
+  build:
+    steps:
+      # Build everything
+      - run: make # all targets
+        # Shown in the UI
+        name: Build
+`,
+			expected: `jobs:
+  build:
+    steps:
+      # Build everything
+      # Shown in the UI
+      - name: Build
+        run: make # all targets
+`,
+			changed: true,
+		},
+		{
+			name: "keeps block scalar content",
+			input: `jobs:
+  build:
+    steps:
+      - run: |
+          make build
+
+          make test
+        name: Build and test
+
+      - name: Lint
+        run: make lint
+`,
+			expected: `jobs:
+  build:
+    steps:
+      - name: Build and test
+        run: |
+          make build
+
+          make test
+
+      - name: Lint
+        run: make lint
+`,
+			changed: true,
+		},
+		{
+			name: "leaves flow steps alone",
+			input: `jobs:
+  build:
+    steps:
+      - {run: make, name: Build}
+`,
+			expected: `jobs:
+  build:
+    steps:
+      - {run: make, name: Build}
+`,
+			changed: false,
+		},
+		{
+			name: "leaves sorted steps alone",
+			input: `jobs:
+  build:
+    steps:
+      - name: Build
+        run: make
+`,
+			expected: `jobs:
+  build:
+    steps:
+      - name: Build
+        run: make
+`,
+			changed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := ParseWorkflow("test.yml", []byte(tt.input))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			changed, err := wf.SortStepKeys(order)
+			if err != nil {
+				t.Fatalf("SortStepKeys() error = %v", err)
+			}
+			if changed != tt.changed {
+				t.Errorf("SortStepKeys() changed = %v, want %v", changed, tt.changed)
+			}
+			if string(wf.RawBytes) != tt.expected {
+				t.Errorf("SortStepKeys() result:\n%s\nwant:\n%s", wf.RawBytes, tt.expected)
+			}
+		})
+	}
+}
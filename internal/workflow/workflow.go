@@ -1,11 +1,15 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/reugn/github-ci/internal/stringutil"
 	"gopkg.in/yaml.v3"
 )
 
@@ -15,6 +19,8 @@ type Workflow struct {
 	Content  *Content // Parsed workflow structure
 	RawBytes []byte   // Raw YAML bytes for manipulation
 	node     *yaml.Node
+	expanded *yaml.Node // node with aliases and merge keys resolved
+	model    *Model     // job and step structure built from node
 }
 
 // Content represents the parsed structure of a GitHub Actions workflow.
@@ -23,6 +29,9 @@ type Content struct {
 	On          any            `yaml:"on"`
 	Jobs        map[string]any `yaml:"jobs"`
 	Permissions any            `yaml:"permissions"`
+	Concurrency any            `yaml:"concurrency"`
+	Defaults    map[string]any `yaml:"defaults"`
+	Runs        map[string]any `yaml:"runs"` // Set in action metadata files (action.yml)
 }
 
 // Action represents a GitHub Action usage in a workflow file.
@@ -30,6 +39,41 @@ type Action struct {
 	Uses string     // Action reference (e.g., "actions/checkout@v3")
 	Line int        // Line number in the YAML file
 	Node *yaml.Node // YAML node reference for updates
+	Step *yaml.Node // Mapping that holds the uses key: the step, or the job of a reusable workflow call
+}
+
+// Input returns the value of an input given under the with: key next to the action's uses key,
+// and whether it is set.
+func (a *Action) Input(name string) (string, bool) {
+	if a.Step == nil || a.Step.Kind != yaml.MappingNode {
+		return "", false
+	}
+
+	for i := 0; i < len(a.Step.Content)-1; i += 2 {
+		if a.Step.Content[i].Value != "with" || a.Step.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		with := a.Step.Content[i+1]
+		for j := 0; j < len(with.Content)-1; j += 2 {
+			if with.Content[j].Value == name && with.Content[j+1].Kind == yaml.ScalarNode {
+				return with.Content[j+1].Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// VersionComment returns the version tag that starts the line comment of the action's uses
+// value (e.g., "v4.1.1" for "# v4.1.1 managed by github-ci"), or an empty string if there is none.
+func (a *Action) VersionComment() string {
+	if a.Node == nil {
+		return ""
+	}
+	fields := strings.Fields(strings.TrimPrefix(a.Node.LineComment, "#"))
+	if len(fields) == 0 || !isVersionTag(fields[0]) {
+		return ""
+	}
+	return fields[0]
 }
 
 // Lines returns the workflow content as individual lines.
@@ -42,7 +86,9 @@ func (w *Workflow) BaseName() string {
 	return filepath.Base(w.File)
 }
 
-// LoadWorkflows loads all workflow files from the specified directory.
+// LoadWorkflows loads all workflow files from the specified directory. Files that fail
+// to load don't stop the others from loading: the workflows that loaded are returned
+// along with the joined errors of those that didn't.
 func LoadWorkflows(dir string) ([]*Workflow, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -50,23 +96,25 @@ func LoadWorkflows(dir string) ([]*Workflow, error) {
 	}
 
 	workflows := make([]*Workflow, 0, len(entries))
+	var errs []error
 	for _, entry := range entries {
-		if entry.IsDir() || !isYAMLFile(entry.Name()) {
+		if entry.IsDir() || !IsYAMLFile(entry.Name()) {
 			continue
 		}
 
 		wf, err := LoadWorkflow(filepath.Join(dir, entry.Name()))
 		if err != nil {
-			return nil, fmt.Errorf("failed to load workflow %s: %w", entry.Name(), err)
+			errs = append(errs, fmt.Errorf("failed to load workflow %s: %w", entry.Name(), err))
+			continue
 		}
 		workflows = append(workflows, wf)
 	}
 
-	return workflows, nil
+	return workflows, errors.Join(errs...)
 }
 
-// isYAMLFile checks if a filename has a YAML extension.
-func isYAMLFile(name string) bool {
+// IsYAMLFile checks if a file name or path has a YAML extension.
+func IsYAMLFile(name string) bool {
 	return strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")
 }
 
@@ -76,19 +124,43 @@ func LoadWorkflow(path string) (*Workflow, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return ParseWorkflow(path, data)
+}
 
-	var content Content
-	if err := yaml.Unmarshal(data, &content); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+// ParseWorkflow parses workflow content read from elsewhere (e.g., stdin).
+// The path is used for reporting and by Save. The YAML is parsed once into the node
+// tree, which is cached for the linters, and Content is decoded from the nodes.
+func ParseWorkflow(path string, data []byte) (*Workflow, error) {
+	node, content, err := parseContent(path, data)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Workflow{
 		File:     path,
-		Content:  &content,
+		Content:  content,
 		RawBytes: data,
+		node:     node,
 	}, nil
 }
 
+// parseContent parses data once into its YAML node and decodes Content from the node.
+func parseContent(path string, data []byte) (*yaml.Node, *Content, error) {
+	node := &yaml.Node{}
+	if err := yaml.Unmarshal(data, node); err != nil {
+		return nil, nil, newParseError(path, data, err)
+	}
+
+	// An empty document has no nodes to decode
+	var content Content
+	if node.Kind != 0 {
+		if err := node.Decode(&content); err != nil {
+			return nil, nil, newParseError(path, data, err)
+		}
+	}
+	return node, &content, nil
+}
+
 // getNode returns the cached YAML node, parsing if necessary.
 func (w *Workflow) getNode() (*yaml.Node, error) {
 	if w.node != nil {
@@ -103,13 +175,62 @@ func (w *Workflow) getNode() (*yaml.Node, error) {
 	return w.node, nil
 }
 
-// invalidateNode clears the cached node after modifications.
+// getExpandedNode returns the parsed YAML node with anchors and aliases resolved,
+// caching the result. Content reached through an alias has the line of the alias.
+func (w *Workflow) getExpandedNode() (*yaml.Node, error) {
+	if w.expanded != nil {
+		return w.expanded, nil
+	}
+
+	node, err := w.getNode()
+	if err != nil {
+		return nil, err
+	}
+	w.expanded = expandAliases(node, 0)
+	return w.expanded, nil
+}
+
+// invalidateNode clears the cached nodes and model after modifications.
 func (w *Workflow) invalidateNode() {
 	w.node = nil
+	w.expanded = nil
+	w.model = nil
+}
+
+// SetRawBytes replaces the raw workflow content, e.g., after a fixer rewrote its lines,
+// re-parsing it so Content and the cached node stay in sync. If data isn't valid YAML,
+// the workflow is left unchanged and a *ParseError is returned.
+func (w *Workflow) SetRawBytes(data []byte) error {
+	node, content, err := parseContent(w.File, data)
+	if err != nil {
+		return err
+	}
+
+	w.RawBytes = data
+	w.Content = content
+	w.invalidateNode()
+	w.node = node
+	return nil
 }
 
-// FindActions finds all GitHub Actions used in the workflow.
+// FindActions finds all GitHub Actions used in the workflow, including those reached
+// through YAML aliases (*name) and merge keys (<<: *name). An action used through an
+// alias is reported at the line of the alias, in addition to its anchored definition.
 func (w *Workflow) FindActions() ([]*Action, error) {
+	node, err := w.getExpandedNode()
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []*Action
+	findActionsInNode(node, &actions)
+	return actions, nil
+}
+
+// FindActionsRaw finds the GitHub Actions written in the workflow source, once per
+// occurrence, without following aliases. Fixers that rewrite the source text use it,
+// since an anchored action is updated once for all of its aliases.
+func (w *Workflow) FindActionsRaw() ([]*Action, error) {
 	node, err := w.getNode()
 	if err != nil {
 		return nil, err
@@ -120,6 +241,69 @@ func (w *Workflow) FindActions() ([]*Action, error) {
 	return actions, nil
 }
 
+// expandAliases returns a copy of node with aliases replaced by copies of their anchored
+// content and merge keys merged into their mappings; explicit keys take precedence.
+// A non-zero line overrides the line of every copied node, so that content reached
+// through an alias is attributed to the alias.
+func expandAliases(node *yaml.Node, line int) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.AliasNode {
+		if line == 0 {
+			line = node.Line
+		}
+		return expandAliases(node.Alias, line)
+	}
+
+	expanded := *node
+	expanded.Content = nil
+	if line > 0 {
+		expanded.Line = line
+	}
+
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			expanded.Content = append(expanded.Content, expandAliases(child, line))
+		}
+		return &expanded
+	}
+
+	var merged []*yaml.Node
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge" {
+			merged = append(merged, mergedPairs(expandAliases(value, line))...)
+			continue
+		}
+		expanded.Content = append(expanded.Content, expandAliases(key, line), expandAliases(value, line))
+	}
+	for i := 0; i < len(merged)-1; i += 2 {
+		if mappingValue(&expanded, merged[i].Value) == nil {
+			expanded.Content = append(expanded.Content, merged[i], merged[i+1])
+		}
+	}
+	return &expanded
+}
+
+// mergedPairs returns the key/value nodes merged by a merge key value:
+// a mapping, or a sequence of mappings.
+func mergedPairs(value *yaml.Node) []*yaml.Node {
+	switch value.Kind {
+	case yaml.MappingNode:
+		return value.Content
+	case yaml.SequenceNode:
+		var pairs []*yaml.Node
+		for _, item := range value.Content {
+			if item.Kind == yaml.MappingNode {
+				pairs = append(pairs, item.Content...)
+			}
+		}
+		return pairs
+	}
+	return nil
+}
+
 // findActionsInNode recursively finds all "uses" keys in a YAML node tree.
 func findActionsInNode(node *yaml.Node, actions *[]*Action) {
 	if node == nil {
@@ -142,6 +326,7 @@ func findActionsInNode(node *yaml.Node, actions *[]*Action) {
 					Uses: valueNode.Value,
 					Line: valueNode.Line,
 					Node: valueNode,
+					Step: node,
 				})
 			} else {
 				findActionsInNode(valueNode, actions)
@@ -160,46 +345,199 @@ func (w *Workflow) HasPermissions() bool {
 	return w.Content.Permissions != nil
 }
 
+// IsWorkflow returns true if the file looks like a workflow, i.e. it defines
+// triggers ("on") or jobs. Other YAML files, such as action metadata, return false.
+func (w *Workflow) IsWorkflow() bool {
+	return w.Content != nil && (w.Content.On != nil || w.Content.Jobs != nil)
+}
+
+// IsCompositeAction returns true if the file is the metadata of a composite action,
+// i.e. it defines runs with "using: composite" and steps under runs.steps.
+func (w *Workflow) IsCompositeAction() bool {
+	if w.Content == nil || w.IsWorkflow() {
+		return false
+	}
+	using, _ := w.Content.Runs["using"].(string)
+	return using == "composite"
+}
+
+// Triggers returns the event names from the workflow's "on" field.
+// Handles the string (on: push), list (on: [push, pull_request]),
+// and map (on: {push: {...}}) forms. Map keys are returned in sorted order.
+func (w *Workflow) Triggers() []string {
+	if w.Content == nil {
+		return nil
+	}
+
+	switch on := w.Content.On.(type) {
+	case string:
+		return []string{on}
+	case []any:
+		triggers := make([]string, 0, len(on))
+		for _, item := range on {
+			if event, ok := item.(string); ok {
+				triggers = append(triggers, event)
+			}
+		}
+		return triggers
+	case map[string]any:
+		return slices.Sorted(maps.Keys(on))
+	}
+	return nil
+}
+
+// HasTrigger returns true if the workflow is triggered by the given event.
+func (w *Workflow) HasTrigger(event string) bool {
+	return slices.Contains(w.Triggers(), event)
+}
+
+// IsReusable returns true if the workflow can be called from other workflows (on.workflow_call).
+func (w *Workflow) IsReusable() bool {
+	return w.HasTrigger("workflow_call")
+}
+
 // Save writes the workflow to disk using the current RawBytes.
 // This preserves original formatting including empty lines.
 func (w *Workflow) Save() error {
 	return os.WriteFile(w.File, w.RawBytes, 0600)
 }
 
+// UsesUpdate replaces the uses value Old of an action with New, followed by Comment
+// as the line comment (none if empty).
+type UsesUpdate struct {
+	Old     string
+	New     string
+	Comment string
+}
+
 // UpdateActionUses updates an action reference and optionally adds a comment.
+// A non-empty marker (e.g., "managed by github-ci") is appended to the comment
+// unless the comment already contains it, so repeated updates don't duplicate it.
 // Uses line-based replacement to preserve original formatting including empty lines.
-func (w *Workflow) UpdateActionUses(oldUses, newUses, comment string) error {
-	lines := strings.Split(string(w.RawBytes), "\n")
-	updated := false
+// The change is made in memory; call Save to write it to disk.
+func (w *Workflow) UpdateActionUses(oldUses, newUses, comment, marker string) error {
+	return w.UpdateActionsUses([]UsesUpdate{{Old: oldUses, New: newUses, Comment: comment}}, marker)
+}
+
+// UpdateActionsUses applies updates in a single pass over the workflow lines, like
+// UpdateActionUses for each update, so the content is rewritten and re-parsed once.
+// A line containing several Old values is updated with the longest, so updating
+// "actions/checkout@v4" leaves "actions/checkout@v4.1.1" alone. If the Old value of
+// an update is not found, an error is returned and the workflow is left unchanged.
+func (w *Workflow) UpdateActionsUses(updates []UsesUpdate, marker string) error {
+	lines := w.Lines()
+	found := make([]bool, len(updates))
 
 	for i, line := range lines {
-		// Find lines containing the old uses value
-		if strings.Contains(line, oldUses) {
-			// Replace the uses value
-			newLine := strings.Replace(line, oldUses, newUses, 1)
-
-			// Remove any existing line comment and trailing whitespace
-			if idx := strings.Index(newLine, " #"); idx != -1 {
-				newLine = newLine[:idx]
-			}
-			newLine = strings.TrimRight(newLine, " \t")
-			if comment != "" {
-				newLine += " # " + comment
+		match := -1
+		for j, update := range updates {
+			if strings.Contains(line, update.Old) && (match < 0 || len(update.Old) > len(updates[match].Old)) {
+				match = j
 			}
+		}
+		if match < 0 {
+			continue
+		}
 
-			lines[i] = newLine
-			updated = true
+		update := updates[match]
+		// Replace the uses value
+		newLine := strings.Replace(line, update.Old, update.New, 1)
+
+		// Remove any existing line comment and trailing whitespace
+		if idx := strings.Index(newLine, " #"); idx != -1 {
+			newLine = newLine[:idx]
 		}
+		newLine = strings.TrimRight(newLine, " \t")
+		if comment := withMarker(update.Comment, marker); comment != "" {
+			newLine += " # " + comment
+		}
+
+		lines[i] = newLine
+		found[match] = true
 	}
 
-	if !updated {
-		return fmt.Errorf("action %s not found", oldUses)
+	for j, update := range updates {
+		if !found[j] {
+			return fmt.Errorf("action %s not found", update.Old)
+		}
 	}
 
-	w.RawBytes = []byte(strings.Join(lines, "\n"))
-	w.invalidateNode()
+	return w.SetRawBytes([]byte(strings.Join(lines, "\n")))
+}
 
-	return os.WriteFile(w.File, w.RawBytes, 0600)
+// withMarker appends marker to comment, separated by a space, unless it is empty
+// or already present.
+func withMarker(comment, marker string) string {
+	marker = strings.TrimSpace(marker)
+	switch {
+	case marker == "" || strings.Contains(comment, marker):
+		return comment
+	case comment == "":
+		return marker
+	default:
+		return comment + " " + marker
+	}
+}
+
+// InsertPermissions adds a top-level "permissions: <value>" line after the "on" block,
+// or before "jobs" if there is no "on". Comments and blank lines before the next key
+// stay with that key. The change is made in memory; call Save to write it to disk.
+func (w *Workflow) InsertPermissions(value string) error {
+	node, err := w.getNode()
+	if err != nil {
+		return err
+	}
+	if len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode || node.Content[0].Style == yaml.FlowStyle {
+		return fmt.Errorf("workflow is not a block mapping")
+	}
+
+	lines := w.Lines()
+	root := node.Content[0]
+	indent := strings.Repeat(" ", max(root.Column-1, 0))
+
+	at := permissionsInsertIndex(root, lines)
+	lines = slices.Insert(lines, at, indent+"permissions: "+value)
+	return w.SetRawBytes([]byte(strings.Join(lines, "\n")))
+}
+
+// FindPermissionsInsertLine returns the line where InsertPermissions would add top-level
+// permissions, or the last line if they would be appended at the end of the file.
+// Returns 0 if the workflow is not a block mapping.
+func (w *Workflow) FindPermissionsInsertLine() int {
+	node, err := w.getNode()
+	if err != nil || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode ||
+		node.Content[0].Style == yaml.FlowStyle {
+		return 0
+	}
+
+	lines := w.Lines()
+	at := permissionsInsertIndex(node.Content[0], lines)
+	if at >= len(lines) || (at == len(lines)-1 && lines[at] == "") {
+		return max(at, 1)
+	}
+	return at + 1
+}
+
+// permissionsInsertIndex returns the index in lines before which top-level permissions
+// go: before the key following "on", or before "jobs" if there is no "on".
+func permissionsInsertIndex(root *yaml.Node, lines []string) int {
+	at := len(lines)
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		if root.Content[i].Value == "on" {
+			at = len(lines)
+			if i+2 < len(root.Content) {
+				at = root.Content[i+2].Line - 1
+			}
+			break
+		}
+		if root.Content[i].Value == "jobs" {
+			at = root.Content[i].Line - 1
+		}
+	}
+	for at > 0 && stringutil.IsBlankOrComment(lines[at-1]) {
+		at--
+	}
+	return at
 }
 
 // NormalizeCommentSpacing normalizes spacing before version tag comments on uses: lines.
@@ -266,51 +604,115 @@ func isVersionTag(s string) bool {
 	return false
 }
 
-// FindJobLine finds the line number where a job is defined.
+// FindJobLine finds the line number where a job is defined, or 0 if absent.
 func (w *Workflow) FindJobLine(jobID string) int {
-	lines := w.Lines()
-	prefix := "  " + jobID + ":"
-	for i, line := range lines {
-		if strings.HasPrefix(line, prefix) {
-			return i + 1
+	model, err := w.Model()
+	if err != nil {
+		return 0
+	}
+	if job := model.Job(jobID); job != nil {
+		return job.Line
+	}
+	return 0
+}
+
+// FindMatrixLine finds the line number of a job's strategy.matrix key, or 0 if absent.
+func (w *Workflow) FindMatrixLine(jobID string) int {
+	node, err := w.getNode()
+	if err != nil || len(node.Content) == 0 {
+		return 0
+	}
+
+	job := mappingValue(mappingValue(node.Content[0], "jobs"), jobID)
+	strategy := mappingValue(job, "strategy")
+	if strategy == nil || strategy.Kind != yaml.MappingNode {
+		return 0
+	}
+	for i := 0; i < len(strategy.Content)-1; i += 2 {
+		if strategy.Content[i].Value == "matrix" {
+			return strategy.Content[i].Line
 		}
 	}
 	return 0
 }
 
-// FindStepLine finds the line number where a step is defined within a job.
-func (w *Workflow) FindStepLine(jobID string, stepIndex int) int {
-	lines := w.Lines()
-	inJob := false
-	inSteps := false
-	stepCount := 0
-	jobPrefix := "  " + jobID + ":"
+// FindPermissionsLine finds the line number of the permissions key of a job, or of the
+// workflow if jobID is empty. Returns 0 if absent.
+func (w *Workflow) FindPermissionsLine(jobID string) int {
+	return w.findKeyLine(jobID, "permissions")
+}
 
-	for i, line := range lines {
-		if strings.HasPrefix(line, jobPrefix) {
-			inJob = true
-			continue
+// FindConcurrencyLine finds the line number of the concurrency key of a job, or of the
+// workflow if jobID is empty. Returns 0 if absent.
+func (w *Workflow) FindConcurrencyLine(jobID string) int {
+	return w.findKeyLine(jobID, "concurrency")
+}
+
+// findKeyLine finds the line number of a top-level key of a job, or of the workflow
+// if jobID is empty. Returns 0 if absent.
+func (w *Workflow) findKeyLine(jobID, key string) int {
+	node, err := w.getNode()
+	if err != nil || len(node.Content) == 0 {
+		return 0
+	}
+
+	parent := node.Content[0]
+	if jobID != "" {
+		parent = mappingValue(mappingValue(parent, "jobs"), jobID)
+	}
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return 0
+	}
+	for i := 0; i < len(parent.Content)-1; i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i].Line
 		}
+	}
+	return 0
+}
 
-		if inJob {
-			trimmed := strings.TrimSpace(line)
-			// Check if we've exited the job
-			if len(line) > 0 && !strings.HasPrefix(line, " ") {
-				break
-			}
-			if strings.HasPrefix(trimmed, "steps:") {
-				inSteps = true
-				continue
+// FindTriggerLine finds the line number where an event is listed in the workflow's
+// "on" field. Falls back to the line of the "on" key, or 0 if the workflow has none.
+func (w *Workflow) FindTriggerLine(event string) int {
+	node, err := w.getNode()
+	if err != nil || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		return 0
+	}
+
+	root := node.Content[0]
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		if root.Content[i].Value != "on" {
+			continue
+		}
+		on := root.Content[i+1]
+		switch on.Kind {
+		case yaml.SequenceNode:
+			for _, item := range on.Content {
+				if item.Value == event {
+					return item.Line
+				}
 			}
-			if inSteps && strings.HasPrefix(trimmed, "- ") {
-				if stepCount == stepIndex {
-					return i + 1
+		case yaml.MappingNode:
+			for j := 0; j < len(on.Content)-1; j += 2 {
+				if on.Content[j].Value == event {
+					return on.Content[j].Line
 				}
-				stepCount++
 			}
 		}
+		return root.Content[i].Line
 	}
+	return 0
+}
 
+// FindStepLine finds the line number where a step is defined within a job, or 0 if absent.
+func (w *Workflow) FindStepLine(jobID string, stepIndex int) int {
+	model, err := w.Model()
+	if err != nil {
+		return 0
+	}
+	if step := model.Job(jobID).Step(stepIndex); step != nil {
+		return step.Line
+	}
 	return 0
 }
 
@@ -350,3 +752,184 @@ func (w *Workflow) ExtractWorkflowEnv() map[string]bool {
 
 	return result
 }
+
+// Env variable levels reported by EnvVars.
+const (
+	EnvLevelWorkflow = "workflow"
+	EnvLevelJob      = "job"
+	EnvLevelStep     = "step"
+)
+
+// EnvVar represents an env entry declared at the workflow, job, or step level.
+type EnvVar struct {
+	Name  string // Variable name
+	Value string // Scalar value (empty for non-scalar values)
+	Level string // One of EnvLevelWorkflow, EnvLevelJob, or EnvLevelStep
+	JobID string // Enclosing job ID (empty for workflow-level variables)
+	Step  int    // Index of the enclosing step in the job (step-level variables only)
+	Line  int    // Line number of the variable key
+}
+
+// EnvVars extracts env variables declared at the workflow, job, and step levels
+// in document order.
+func (w *Workflow) EnvVars() ([]*EnvVar, error) {
+	node, err := w.getNode()
+	if err != nil {
+		return nil, err
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+
+	root := node.Content[0]
+	vars := envVarsInNode(mappingValue(root, "env"), EnvLevelWorkflow, "")
+
+	jobs := mappingValue(root, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return vars, nil
+	}
+	for i := 0; i < len(jobs.Content)-1; i += 2 {
+		jobID := jobs.Content[i].Value
+		job := jobs.Content[i+1]
+		vars = append(vars, envVarsInNode(mappingValue(job, "env"), EnvLevelJob, jobID)...)
+
+		steps := mappingValue(job, "steps")
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			continue
+		}
+		for stepIdx, step := range steps.Content {
+			stepVars := envVarsInNode(mappingValue(step, "env"), EnvLevelStep, jobID)
+			for _, v := range stepVars {
+				v.Step = stepIdx
+			}
+			vars = append(vars, stepVars...)
+		}
+	}
+
+	return vars, nil
+}
+
+// Condition represents an if condition of a job or step.
+type Condition struct {
+	JobID string // Enclosing job ID
+	Step  int    // Index of the step in the job, or -1 for a job-level condition
+	Expr  string // Condition as written, with or without ${{ }}
+	Line  int    // Line number of the condition value
+}
+
+// Conditions extracts the scalar if conditions of jobs and steps in document order.
+func (w *Workflow) Conditions() ([]*Condition, error) {
+	node, err := w.getNode()
+	if err != nil {
+		return nil, err
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+
+	jobs := mappingValue(node.Content[0], "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var conditions []*Condition
+	add := func(n *yaml.Node, jobID string, step int) {
+		cond := mappingValue(n, "if")
+		if cond != nil && cond.Kind == yaml.ScalarNode {
+			conditions = append(conditions, &Condition{JobID: jobID, Step: step, Expr: cond.Value, Line: cond.Line})
+		}
+	}
+	for i := 0; i < len(jobs.Content)-1; i += 2 {
+		jobID := jobs.Content[i].Value
+		job := jobs.Content[i+1]
+		add(job, jobID, -1)
+
+		steps := mappingValue(job, "steps")
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			continue
+		}
+		for stepIdx, step := range steps.Content {
+			add(step, jobID, stepIdx)
+		}
+	}
+
+	return conditions, nil
+}
+
+// ValueLines returns the 1-based numbers of lines holding the values of the given keys
+// anywhere in the workflow (e.g., "run", "with", "env"), including every content
+// line of block scalars such as multi-line run scripts.
+func (w *Workflow) ValueLines(keys ...string) (map[int]bool, error) {
+	node, err := w.getNode()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]bool)
+	blockLines := BlockScalarLines(w.Lines())
+
+	var walk func(n *yaml.Node, inValue bool)
+	walk = func(n *yaml.Node, inValue bool) {
+		switch n.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range n.Content {
+				walk(child, inValue)
+			}
+		case yaml.MappingNode:
+			for i := 0; i < len(n.Content)-1; i += 2 {
+				walk(n.Content[i+1], inValue || slices.Contains(keys, n.Content[i].Value))
+			}
+		case yaml.ScalarNode:
+			if !inValue {
+				return
+			}
+			if n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				result[n.Line] = true
+				return
+			}
+			// Block scalar content starts on the line after the | or > indicator
+			for line := n.Line + 1; blockLines[line]; line++ {
+				result[line] = true
+			}
+		}
+	}
+	walk(node, false)
+
+	return result, nil
+}
+
+// envVarsInNode returns the keys of an env mapping node.
+func envVarsInNode(env *yaml.Node, level, jobID string) []*EnvVar {
+	if env == nil || env.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	vars := make([]*EnvVar, 0, len(env.Content)/2)
+	for i := 0; i < len(env.Content)-1; i += 2 {
+		key, value := env.Content[i], env.Content[i+1]
+		v := &EnvVar{
+			Name:  key.Value,
+			Level: level,
+			JobID: jobID,
+			Line:  key.Line,
+		}
+		if value.Kind == yaml.ScalarNode {
+			v.Value = value.Value
+		}
+		vars = append(vars, v)
+	}
+	return vars
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil if absent.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -1,9 +1,16 @@
 package workflow
 
 import (
+	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadWorkflow(t *testing.T) {
@@ -63,6 +70,18 @@ func TestLoadWorkflow_InvalidYAML(t *testing.T) {
 	}
 }
 
+func TestParseWorkflow_TabIndentation(t *testing.T) {
+	content := "name: Test\non: push\njobs:\n  build:\n\truns-on: ubuntu-latest\n"
+
+	_, err := ParseWorkflow("test.yml", []byte(content))
+	if err == nil {
+		t.Fatal("ParseWorkflow() expected error for tab indentation")
+	}
+	if !strings.Contains(err.Error(), "line 5: tab used for indentation") {
+		t.Errorf("ParseWorkflow() error = %v, want it to name the tab-indented line", err)
+	}
+}
+
 func TestLoadWorkflows(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -167,6 +186,158 @@ jobs:
 	}
 }
 
+func TestWorkflow_FindActions_Aliases(t *testing.T) {
+	content := `on: push
+x-setup: &setup
+  uses: actions/setup-go@v5
+  with:
+    go-version: stable
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - &checkout
+        uses: actions/checkout@v4
+      - *setup
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - *checkout
+      - <<: *setup
+        name: Setup
+      - <<: *setup
+        uses: actions/setup-node@v4
+`
+	wf, err := ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		find     func() ([]*Action, error)
+		expected []string
+	}{
+		{
+			name: "expanded",
+			find: wf.FindActions,
+			expected: []string{
+				"3: actions/setup-go@v5",
+				"11: actions/checkout@v4",
+				"12: actions/setup-go@v5",
+				"16: actions/checkout@v4",
+				"17: actions/setup-go@v5",
+				"20: actions/setup-node@v4",
+			},
+		},
+		{
+			name: "raw",
+			find: wf.FindActionsRaw,
+			expected: []string{
+				"3: actions/setup-go@v5",
+				"11: actions/checkout@v4",
+				"20: actions/setup-node@v4",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions, err := tt.find()
+			if err != nil {
+				t.Fatalf("find error = %v", err)
+			}
+
+			var got []string
+			for _, a := range actions {
+				got = append(got, fmt.Sprintf("%d: %s", a.Line, a.Uses))
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("actions = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAction_Input(t *testing.T) {
+	content := `on: push
+x-setup: &setup
+  uses: actions/setup-go@v5
+  with:
+    go-version: stable
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          persist-credentials: false
+          fetch-depth: 0
+      - uses: actions/cache@v4
+      - <<: *setup
+        name: Setup
+  call:
+    uses: org/repo/.github/workflows/build.yml@main
+    with:
+      target: release
+`
+	wf, err := ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+	actions, err := wf.FindActions()
+	if err != nil {
+		t.Fatalf("FindActions() error = %v", err)
+	}
+
+	var got []string
+	for _, a := range actions {
+		for _, input := range []string{"persist-credentials", "go-version", "target"} {
+			if value, ok := a.Input(input); ok {
+				got = append(got, fmt.Sprintf("%d: %s=%s", a.Line, input, value))
+			}
+		}
+	}
+	expected := []string{
+		"3: go-version=stable",
+		"10: persist-credentials=false",
+		"15: go-version=stable",
+		"18: target=release",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("inputs = %v, want %v", got, expected)
+	}
+}
+
+func TestAction_VersionComment(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    steps:
+      - uses: actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11 # v4.1.1
+      - uses: actions/setup-go@0c52d547c9bc32b1aa3301fd7a9cb496313a4491 #v5.0.0 managed by github-ci
+      - uses: actions/cache@1bd1e32a3bdc45362d1e726936510720a7c30a57 # pinned for the fix
+      - uses: actions/upload-artifact@v4
+`
+	wf, err := ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+	actions, err := wf.FindActionsRaw()
+	if err != nil {
+		t.Fatalf("FindActionsRaw() error = %v", err)
+	}
+
+	var got []string
+	for _, a := range actions {
+		got = append(got, a.VersionComment())
+	}
+	expected := []string{"v4.1.1", "v5.0.0", "", ""}
+	if !slices.Equal(got, expected) {
+		t.Errorf("VersionComment() = %q, want %q", got, expected)
+	}
+}
+
 func TestWorkflow_HasPermissions(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -236,6 +407,109 @@ jobs:
 	}
 }
 
+func TestWorkflow_IsCompositeAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{
+			name:     "composite action",
+			content:  "name: Build\nruns:\n  using: composite\n  steps:\n    - run: make\n      shell: bash\n",
+			expected: true,
+		},
+		{
+			name:    "javascript action",
+			content: "name: Build\nruns:\n  using: node20\n  main: index.js\n",
+		},
+		{
+			name:    "workflow",
+			content: "name: Test\non: push\njobs: {}\n",
+		},
+		{
+			name:    "other YAML",
+			content: "key: value\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := ParseWorkflow("action.yml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+			if got := wf.IsCompositeAction(); got != tt.expected {
+				t.Errorf("IsCompositeAction() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Triggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "string form",
+			content:  "name: Test\non: push\njobs: {}\n",
+			expected: []string{"push"},
+		},
+		{
+			name:     "list form",
+			content:  "name: Test\non: [push, pull_request]\njobs: {}\n",
+			expected: []string{"push", "pull_request"},
+		},
+		{
+			name: "map form",
+			content: `name: Test
+on:
+  workflow_call:
+    inputs:
+      env:
+        type: string
+  push:
+    branches: [main]
+jobs: {}
+`,
+			expected: []string{"push", "workflow_call"},
+		},
+		{
+			name:     "no triggers",
+			content:  "name: Test\njobs: {}\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			workflowPath := filepath.Join(tmpDir, "test.yml")
+			if err := os.WriteFile(workflowPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write test workflow: %v", err)
+			}
+
+			wf, err := LoadWorkflow(workflowPath)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			if got := wf.Triggers(); !slices.Equal(got, tt.expected) {
+				t.Errorf("Triggers() = %v, want %v", got, tt.expected)
+			}
+			for _, event := range tt.expected {
+				if !wf.HasTrigger(event) {
+					t.Errorf("HasTrigger(%q) = false, want true", event)
+				}
+			}
+			if wf.IsReusable() != slices.Contains(tt.expected, "workflow_call") {
+				t.Errorf("IsReusable() = %v", wf.IsReusable())
+			}
+		})
+	}
+}
+
 func TestWorkflow_UpdateActionUses(t *testing.T) {
 	tmpDir := t.TempDir()
 	workflowPath := filepath.Join(tmpDir, "test.yml")
@@ -262,10 +536,13 @@ jobs:
 	newUses := "actions/checkout@abc123def456789012345678901234567890abcd"
 	comment := "v3"
 
-	err = wf.UpdateActionUses(oldUses, newUses, comment)
+	err = wf.UpdateActionUses(oldUses, newUses, comment, "")
 	if err != nil {
 		t.Fatalf("UpdateActionUses() error = %v", err)
 	}
+	if err := wf.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
 
 	// Reload and verify
 	wf2, err := LoadWorkflow(workflowPath)
@@ -287,6 +564,121 @@ jobs:
 	}
 }
 
+func TestWorkflow_UpdateActionsUses(t *testing.T) {
+	const (
+		checkoutHash = "b4ffde65f46336ab88eb53be808477a3936bae11"
+		setupGoHash  = "0c52d547c9bc32b1aa3301fd7a9cb496313a4491"
+	)
+	content := `steps:
+  - uses: actions/checkout@v4
+  - uses: actions/checkout@v4.1.1 # pinned
+  - uses: actions/setup-go@v5
+  - uses: actions/checkout@v4
+`
+	updates := []UsesUpdate{
+		{Old: "actions/checkout@v4", New: "actions/checkout@" + checkoutHash, Comment: "v4.2.0"},
+		{Old: "actions/checkout@v4.1.1", New: "actions/checkout@v4.1.1", Comment: "v4.1.1"},
+		{Old: "actions/setup-go@v5", New: "actions/setup-go@" + setupGoHash, Comment: "v5.0.1"},
+	}
+
+	wf := &Workflow{RawBytes: []byte(content)}
+	if err := wf.UpdateActionsUses(updates, "managed"); err != nil {
+		t.Fatalf("UpdateActionsUses() error = %v", err)
+	}
+
+	expected := []string{
+		"steps:",
+		"  - uses: actions/checkout@" + checkoutHash + " # v4.2.0 managed",
+		"  - uses: actions/checkout@v4.1.1 # v4.1.1 managed",
+		"  - uses: actions/setup-go@" + setupGoHash + " # v5.0.1 managed",
+		"  - uses: actions/checkout@" + checkoutHash + " # v4.2.0 managed",
+		"",
+	}
+	if got := wf.Lines(); !slices.Equal(got, expected) {
+		t.Errorf("lines = %q, want %q", got, expected)
+	}
+
+	// An update that matches nothing leaves the workflow unchanged
+	wf = &Workflow{RawBytes: []byte(content)}
+	updates = append(updates, UsesUpdate{Old: "actions/cache@v4", New: "actions/cache@v5"})
+	if err := wf.UpdateActionsUses(updates, ""); err == nil {
+		t.Error("UpdateActionsUses() with a missing action should fail")
+	}
+	if string(wf.RawBytes) != content {
+		t.Errorf("RawBytes = %q, want unchanged %q", wf.RawBytes, content)
+	}
+}
+
+func TestWorkflow_UpdateActionUses_Marker(t *testing.T) {
+	const hash = "b4ffde65f46336ab88eb53be808477a3936bae11"
+	tests := []struct {
+		name     string
+		line     string
+		oldUses  string
+		comment  string
+		marker   string
+		expected string
+	}{
+		{
+			name:     "appends marker after version",
+			line:     "      - uses: actions/checkout@v4",
+			oldUses:  "actions/checkout@v4",
+			comment:  "v4.1.0",
+			marker:   "managed by github-ci",
+			expected: "      - uses: actions/checkout@" + hash + " # v4.1.0 managed by github-ci",
+		},
+		{
+			name:     "marker without version",
+			line:     "      - uses: actions/checkout@v4",
+			oldUses:  "actions/checkout@v4",
+			marker:   "managed by github-ci",
+			expected: "      - uses: actions/checkout@" + hash + " # managed by github-ci",
+		},
+		{
+			name:     "replaces existing marker instead of duplicating it",
+			line:     "      - uses: actions/checkout@" + hash + " # v4.0.0 managed by github-ci",
+			oldUses:  "actions/checkout@" + hash,
+			comment:  "v4.1.0",
+			marker:   "managed by github-ci",
+			expected: "      - uses: actions/checkout@" + hash + " # v4.1.0 managed by github-ci",
+		},
+		{
+			name:     "comment already containing marker",
+			line:     "      - uses: actions/checkout@v4",
+			oldUses:  "actions/checkout@v4",
+			comment:  "v4.1.0 managed by github-ci",
+			marker:   "managed by github-ci",
+			expected: "      - uses: actions/checkout@" + hash + " # v4.1.0 managed by github-ci",
+		},
+		{
+			name:     "empty marker",
+			line:     "      - uses: actions/checkout@v4",
+			oldUses:  "actions/checkout@v4",
+			comment:  "v4.1.0",
+			expected: "      - uses: actions/checkout@" + hash + " # v4.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf := &Workflow{RawBytes: []byte("steps:\n" + tt.line + "\n")}
+			newUses := "actions/checkout@" + hash
+
+			if err := wf.UpdateActionUses(tt.oldUses, newUses, tt.comment, tt.marker); err != nil {
+				t.Fatalf("UpdateActionUses() error = %v", err)
+			}
+			// Running the update again must leave the line unchanged
+			if err := wf.UpdateActionUses(newUses, newUses, tt.comment, tt.marker); err != nil {
+				t.Fatalf("UpdateActionUses() second run error = %v", err)
+			}
+
+			if got := wf.Lines()[1]; got != tt.expected {
+				t.Errorf("UpdateActionUses() line = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestWorkflow_Save(t *testing.T) {
 	tmpDir := t.TempDir()
 	workflowPath := filepath.Join(tmpDir, "test.yml")
@@ -545,6 +937,293 @@ jobs:
 	}
 }
 
+func TestWorkflow_FindMatrixLine(t *testing.T) {
+	content := `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    strategy:
+      fail-fast: false
+      matrix:
+        go: ['1.23', '1.24']
+    steps:
+      - uses: actions/checkout@v3
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/setup-go@v4
+`
+	tmpDir := t.TempDir()
+	workflowPath := filepath.Join(tmpDir, "test.yml")
+	if err := os.WriteFile(workflowPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write test workflow: %v", err)
+	}
+
+	wf, err := LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		jobID    string
+		expected int
+	}{
+		{"build", 8},
+		{"test", 0},
+		{"nonexistent", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jobID, func(t *testing.T) {
+			line := wf.FindMatrixLine(tt.jobID)
+			if line != tt.expected {
+				t.Errorf("FindMatrixLine(%q) = %d, want %d", tt.jobID, line, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkflow_FindPermissionsLine(t *testing.T) {
+	content := `name: Test
+on: push
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    permissions: write-all
+    steps:
+      - uses: actions/checkout@v3
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/setup-go@v4
+`
+	tmpDir := t.TempDir()
+	workflowPath := filepath.Join(tmpDir, "test.yml")
+	if err := os.WriteFile(workflowPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write test workflow: %v", err)
+	}
+
+	wf, err := LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		jobID    string
+		expected int
+	}{
+		{"", 3},
+		{"build", 8},
+		{"test", 0},
+		{"nonexistent", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jobID, func(t *testing.T) {
+			line := wf.FindPermissionsLine(tt.jobID)
+			if line != tt.expected {
+				t.Errorf("FindPermissionsLine(%q) = %d, want %d", tt.jobID, line, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkflow_FindPermissionsInsertLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"after on", "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n", 2},
+		{"before comment preceding next key", "on:\n  push:\n\n# Jobs\njobs: {}\n", 3},
+		{"on is last key", "name: Test\non: push\n", 2},
+		{"no on key", "name: Test\njobs: {}\n", 2},
+		{"flow mapping", "{on: push, jobs: {}}\n", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := ParseWorkflow("test.yml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+			if got := wf.FindPermissionsInsertLine(); got != tt.want {
+				t.Errorf("FindPermissionsInsertLine() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflow_InsertPermissions(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "after on",
+			content:  "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			expected: "on: push\npermissions: read-all\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+		},
+		{
+			name:     "before comment preceding next key",
+			content:  "on:\n  push:\n\n# Jobs\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			expected: "on:\n  push:\npermissions: read-all\n\n# Jobs\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+		},
+		{
+			name:     "on is last key",
+			content:  "jobs:\n  build:\n    runs-on: ubuntu-latest\non: push\n",
+			expected: "jobs:\n  build:\n    runs-on: ubuntu-latest\non: push\npermissions: read-all\n",
+		},
+		{
+			name:     "no on key",
+			content:  "name: Test\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			expected: "name: Test\npermissions: read-all\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+		},
+		{
+			name:    "flow mapping",
+			content: "{on: push, jobs: {}}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := ParseWorkflow("test.yml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseWorkflow() error = %v", err)
+			}
+
+			err = wf.InsertPermissions("read-all")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InsertPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := string(wf.RawBytes); got != tt.expected {
+				t.Errorf("InsertPermissions() result:\n%s\nwant:\n%s", got, tt.expected)
+			}
+			if !wf.HasPermissions() {
+				t.Error("HasPermissions() = false after InsertPermissions()")
+			}
+		})
+	}
+}
+
+func TestWorkflow_Conditions(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    if: github.event_name == 'push'
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+      - if: ${{ success() }}
+        run: make deploy
+  test:
+    runs-on: ubuntu-latest
+`
+	wf, err := ParseWorkflow("test.yml", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	conditions, err := wf.Conditions()
+	if err != nil {
+		t.Fatalf("Conditions() error = %v", err)
+	}
+
+	var got []string
+	for _, c := range conditions {
+		got = append(got, fmt.Sprintf("%s/%d:%d: %s", c.JobID, c.Step, c.Line, c.Expr))
+	}
+	expected := []string{
+		"build/-1:4: github.event_name == 'push'",
+		"build/1:8: ${{ success() }}",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Conditions() = %v, want %v", got, expected)
+	}
+}
+
+func TestWorkflow_FindTriggerLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		event    string
+		expected int
+	}{
+		{"string form", "name: Test\non: push\n", "push", 2},
+		{"list form", "name: Test\non:\n  - push\n  - pull_request\n", "pull_request", 4},
+		{"map form", "name: Test\non:\n  push:\n    branches: [main]\n  workflow_dispatch:\n", "workflow_dispatch", 5},
+		{"missing event falls back to on key", "name: Test\non:\n  push:\n", "schedule", 2},
+		{"no on field", "name: Test\njobs: {}\n", "push", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowPath := filepath.Join(t.TempDir(), "test.yml")
+			if err := os.WriteFile(workflowPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write test workflow: %v", err)
+			}
+			wf, err := LoadWorkflow(workflowPath)
+			if err != nil {
+				t.Fatalf("LoadWorkflow() error = %v", err)
+			}
+
+			if line := wf.FindTriggerLine(tt.event); line != tt.expected {
+				t.Errorf("FindTriggerLine(%q) = %d, want %d", tt.event, line, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkflow_ValueLines(t *testing.T) {
+	content := `name: Test
+on: push
+env:
+  MODE: ci
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - name: Build
+        run: |
+          make build
+
+          make test
+      - uses: actions/setup-go@v5
+        with:
+          go-version: '1.24'
+      - run: echo done
+`
+	tmpDir := t.TempDir()
+	workflowPath := filepath.Join(tmpDir, "test.yml")
+	if err := os.WriteFile(workflowPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write test workflow: %v", err)
+	}
+
+	wf, err := LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	lines, err := wf.ValueLines("run", "with", "env")
+	if err != nil {
+		t.Fatalf("ValueLines() error = %v", err)
+	}
+
+	expected := []int{4, 11, 12, 13, 16, 17}
+	if got := slices.Sorted(maps.Keys(lines)); !slices.Equal(got, expected) {
+		t.Errorf("ValueLines() = %v, want %v", got, expected)
+	}
+}
+
 func TestWorkflow_FindStepLine(t *testing.T) {
 	content := `name: Test
 on: push
@@ -669,3 +1348,158 @@ jobs:
 		})
 	}
 }
+
+func TestWorkflow_EnvVars(t *testing.T) {
+	content := `name: Test
+on: push
+env:
+  GO_VERSION: "1.21"
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    env:
+      CGO_ENABLED: "0"
+    steps:
+      - name: Build
+        env:
+          GOOS: linux
+          GOARCH: amd64
+        run: go build
+      - run: go test
+  lint:
+    runs-on: ubuntu-latest
+    env: ${{ fromJSON(inputs.env) }}
+`
+	tmpDir := t.TempDir()
+	workflowPath := filepath.Join(tmpDir, "test.yml")
+	if err := os.WriteFile(workflowPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write test workflow: %v", err)
+	}
+
+	wf, err := LoadWorkflow(workflowPath)
+	if err != nil {
+		t.Fatalf("LoadWorkflow() error = %v", err)
+	}
+
+	vars, err := wf.EnvVars()
+	if err != nil {
+		t.Fatalf("EnvVars() error = %v", err)
+	}
+
+	expected := []EnvVar{
+		{Name: "GO_VERSION", Value: "1.21", Level: EnvLevelWorkflow, Line: 4},
+		{Name: "CGO_ENABLED", Value: "0", Level: EnvLevelJob, JobID: "build", Line: 9},
+		{Name: "GOOS", Value: "linux", Level: EnvLevelStep, JobID: "build", Line: 13},
+		{Name: "GOARCH", Value: "amd64", Level: EnvLevelStep, JobID: "build", Line: 14},
+	}
+	if len(vars) != len(expected) {
+		t.Fatalf("EnvVars() returned %d vars, want %d", len(vars), len(expected))
+	}
+	for i, v := range vars {
+		if *v != expected[i] {
+			t.Errorf("EnvVars()[%d] = %+v, want %+v", i, *v, expected[i])
+		}
+	}
+}
+
+func TestParseWorkflow_SingleParse(t *testing.T) {
+	data := []byte("name: CI\non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n" +
+		"    steps:\n      - uses: actions/checkout@v4\n")
+	wf, err := ParseWorkflow("ci.yml", data)
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	// The node tree is parsed along with Content, so the linters don't parse again
+	if wf.node == nil {
+		t.Fatal("ParseWorkflow() didn't cache the YAML node")
+	}
+	if wf.Content.Name != "CI" || wf.Content.On != "push" || len(wf.Content.Jobs) != 1 {
+		t.Errorf("ParseWorkflow() Content = %+v", wf.Content)
+	}
+
+	workflowActions, err := wf.FindActions()
+	if err != nil {
+		t.Fatalf("FindActions() error = %v", err)
+	}
+	if len(workflowActions) != 1 || workflowActions[0].Line != 7 {
+		t.Errorf("FindActions() = %+v, want actions/checkout@v4 at line 7", workflowActions)
+	}
+}
+
+func TestWorkflow_SetRawBytes(t *testing.T) {
+	wf, err := ParseWorkflow("ci.yml", []byte("name: CI\non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"))
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+
+	updated := []byte("name: Release\non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n" +
+		"    steps:\n      - uses: actions/checkout@v4\n")
+	if err := wf.SetRawBytes(updated); err != nil {
+		t.Fatalf("SetRawBytes() error = %v", err)
+	}
+	if wf.Content.Name != "Release" || wf.node == nil {
+		t.Errorf("SetRawBytes() Content.Name = %q, cached node = %v; want Release and a cached node",
+			wf.Content.Name, wf.node != nil)
+	}
+	if workflowActions, err := wf.FindActions(); err != nil || len(workflowActions) != 1 {
+		t.Errorf("FindActions() after SetRawBytes = %v, %v, want 1 action", workflowActions, err)
+	}
+
+	// Invalid YAML leaves the workflow unchanged
+	var parseErr *ParseError
+	if err := wf.SetRawBytes([]byte("jobs:\n\tbuild:\n")); !errors.As(err, &parseErr) {
+		t.Errorf("SetRawBytes() with invalid YAML error = %v, want a *ParseError", err)
+	}
+	if string(wf.RawBytes) != string(updated) || wf.Content.Name != "Release" {
+		t.Errorf("SetRawBytes() with invalid YAML changed the workflow:\n%s", wf.RawBytes)
+	}
+}
+
+// benchmarkWorkflow returns a workflow with many jobs and steps.
+func benchmarkWorkflow() []byte {
+	var b strings.Builder
+	b.WriteString("name: Benchmark\non: push\npermissions: read-all\njobs:\n")
+	for j := range 50 {
+		fmt.Fprintf(&b, "  job-%d:\n    runs-on: ubuntu-latest\n    steps:\n", j)
+		fmt.Fprintf(&b, "      - uses: actions/checkout@v4\n")
+		for s := range 20 {
+			fmt.Fprintf(&b, "      - name: Step %d\n        run: echo %d\n", s, s)
+		}
+	}
+	return []byte(b.String())
+}
+
+// BenchmarkParseWorkflow measures loading a workflow and reading its node tree, as every
+// lint run does. "two-parses" decodes the YAML separately for Content and the node tree,
+// which is what ParseWorkflow did before it decoded Content from the nodes.
+func BenchmarkParseWorkflow(b *testing.B) {
+	data := benchmarkWorkflow()
+
+	b.Run("single-parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			wf, err := ParseWorkflow("ci.yml", data)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := wf.getNode(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("two-parses", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var content Content
+			if err := yaml.Unmarshal(data, &content); err != nil {
+				b.Fatal(err)
+			}
+			var node yaml.Node
+			if err := yaml.Unmarshal(data, &node); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Package githubci provides a programmatic API for linting GitHub Actions workflows.
+//
+// It exposes the same linters as the github-ci command-line tool:
+//
+//	issues, err := githubci.Lint(ctx, []string{".github/workflows"}, githubci.Options{
+//		Linters: []string{"permissions", "injection"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, issue := range issues {
+//		fmt.Println(issue)
+//	}
+package githubci
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/reugn/github-ci/internal/config"
+	"github.com/reugn/github-ci/internal/linter"
+	"github.com/reugn/github-ci/internal/workflow"
+)
+
+// Options configures a Lint run. The zero value lints with the configuration
+// resolved the same way as the CLI and all linters enabled by that configuration.
+type Options struct {
+	// ConfigFile is the path to a configuration file. If empty, the configuration is
+	// taken from the GITHUB_CI_CONFIG environment variable, then the nearest
+	// .github-ci.yaml or .github-ci.yml in the current directory or its parents (the
+	// search stops at the repository root, a directory containing .git), then
+	// built-in defaults.
+	ConfigFile string
+	// Linters restricts the run to the named linters (see AvailableLinters).
+	// Settings from the configuration still apply. If empty, the configuration decides.
+	Linters []string
+	// Timeout bounds the whole run. If zero, run.timeout from the configuration is used.
+	Timeout time.Duration
+}
+
+// Issue represents a single problem found in a workflow file.
+type Issue struct {
+	File     string `json:"file"`              // Base name of the workflow file
+	Path     string `json:"path,omitempty"`    // Path of the workflow file as found from paths
+	Line     int    `json:"line"`              // Line number (0 if the issue applies to the whole file)
+	Column   int    `json:"column,omitempty"`  // 1-based byte column of the match on the line (0 if unknown)
+	Linter   string `json:"linter"`            // Name of the linter that reported the issue
+	RuleID   string `json:"rule_id,omitempty"` // Stable ID of the check, e.g., "format/trailing-whitespace"
+	Severity string `json:"severity"`          // Severity level: "error", "warning", or "info"
+	Message  string `json:"message"`           // Human-readable description
+}
+
+// String returns the issue in the "path:line: (linter) message" format used by the CLI,
+// or "path:line:column: (linter) message" if the column is known, with the path relative
+// to the current directory.
+func (i Issue) String() string {
+	return (&linter.Issue{File: i.File, FullPath: i.Path, Line: i.Line, Column: i.Column,
+		Linter: i.Linter, Message: i.Message}).String()
+}
+
+// AvailableLinters returns the names of all linters that can be passed in Options.Linters.
+func AvailableLinters() []string {
+	return config.AllLinters()
+}
+
+// Lint loads the workflows from paths and runs the configured linters on them.
+// Each path can be a directory, a workflow file, or a glob pattern (e.g., "**/*.yml").
+// Workflow files that aren't valid YAML are reported as issues from the "syntax" linter.
+// It returns the issues found, or an error if the configuration, workflows,
+// or a linter could not be processed.
+func Lint(ctx context.Context, paths []string, opts Options) ([]Issue, error) {
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err == nil {
+		err = linter.CheckDisabledRules(cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(opts.Linters) > 0 {
+		cfg.Linters = restrictLinters(cfg.Linters, opts.Linters)
+		if err := cfg.Linters.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options: %w", err)
+		}
+	}
+
+	// Files that aren't valid YAML are reported as syntax issues, as in the CLI
+	workflows, err := workflow.LoadPaths(paths)
+	parseErrs, onlyParse := workflow.ParseErrors(err)
+	if !onlyParse {
+		return nil, fmt.Errorf("failed to load workflows: %w", err)
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = cfg.GetTimeout()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	l := linter.NewWithConfig(ctx, workflows, cfg)
+	l.SetParseErrors(parseErrs)
+	issues, err := l.Lint()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, Issue{
+			File:     issue.File,
+			Path:     issue.FullPath,
+			Line:     issue.Line,
+			Column:   issue.Column,
+			Linter:   issue.Linter,
+			RuleID:   issue.RuleID,
+			Severity: issue.Severity,
+			Message:  issue.Message,
+		})
+	}
+	return result, nil
+}
+
+// restrictLinters returns a copy of lc that enables only the named linters.
+func restrictLinters(lc *config.LinterConfig, names []string) *config.LinterConfig {
+	restricted := &config.LinterConfig{Default: "none", Enable: names}
+	if lc != nil {
+		restricted.Settings = lc.Settings
+	}
+	return restricted
+}
@@ -0,0 +1,165 @@
+package githubci
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reugn/github-ci/internal/testutil"
+)
+
+const testWorkflow = `name: Test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - name: Greet
+        run: echo "${{ github.event.issue.title }}"
+`
+
+func TestLint(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	if err := os.MkdirAll(workflowsDir, 0700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	testutil.CreateWorkflow(t, workflowsDir, "ci.yml", testWorkflow)
+	t.Chdir(tmpDir)
+
+	tests := []struct {
+		name     string
+		paths    []string
+		opts     Options
+		expected map[string]bool // linters expected to report issues
+		wantErr  string
+	}{
+		{
+			name:     "restricted linters",
+			paths:    []string{".github/workflows"},
+			opts:     Options{Linters: []string{"permissions", "injection"}},
+			expected: map[string]bool{"permissions": true, "injection": true},
+		},
+		{
+			name:     "glob pattern",
+			paths:    []string{"**/*.yml"},
+			opts:     Options{Linters: []string{"permissions"}},
+			expected: map[string]bool{"permissions": true},
+		},
+		{
+			name:    "unknown linter",
+			paths:   []string{".github/workflows"},
+			opts:    Options{Linters: []string{"unknown"}},
+			wantErr: `unknown linter "unknown"`,
+		},
+		{
+			name:    "missing path",
+			paths:   []string{"missing"},
+			opts:    Options{Linters: []string{"permissions"}},
+			wantErr: "failed to load workflows",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := Lint(context.Background(), tt.paths, tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Lint() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+
+			got := make(map[string]bool)
+			for _, issue := range issues {
+				if issue.File != "ci.yml" {
+					t.Errorf("issue.File = %q, want %q", issue.File, "ci.yml")
+				}
+				got[issue.Linter] = true
+			}
+			if len(got) != len(tt.expected) {
+				t.Errorf("linters with issues = %v, want %v", got, tt.expected)
+			}
+			for name := range tt.expected {
+				if !got[name] {
+					t.Errorf("expected issues from linter %q, got %v", name, issues)
+				}
+			}
+		})
+	}
+}
+
+func TestLint_ConfigFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := testutil.CreateWorkflow(t, tmpDir, "ci.yml", testWorkflow)
+	configPath := testutil.CreateConfig(t, tmpDir, `linters:
+  default: none
+  enable:
+    - injection
+`)
+
+	issues, err := Lint(context.Background(), []string{path}, Options{ConfigFile: configPath})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(issues) == 0 {
+		t.Fatal("expected injection issues, got none")
+	}
+	for _, issue := range issues {
+		if issue.Linter != "injection" {
+			t.Errorf("issue from linter %q, want only injection", issue.Linter)
+		}
+		if issue.Column == 0 {
+			t.Errorf("injection issue %v has no column", issue)
+		}
+	}
+}
+
+func TestLint_InvalidYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+	testutil.CreateWorkflow(t, tmpDir, "ci.yml", testWorkflow)
+	testutil.CreateWorkflow(t, tmpDir, "broken.yml", "on: push\njobs: [build]\n")
+
+	issues, err := Lint(context.Background(), []string{tmpDir}, Options{Linters: []string{"injection"}})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, issue := range issues {
+		got[issue.File] = issue.Linter
+		if issue.File == "broken.yml" && issue.RuleID != "syntax/invalid-yaml" {
+			t.Errorf("broken.yml issue.RuleID = %q, want syntax/invalid-yaml", issue.RuleID)
+		}
+	}
+	if got["broken.yml"] != "syntax" {
+		t.Errorf("broken.yml issue linter = %q, want syntax (issues: %v)", got["broken.yml"], issues)
+	}
+	if got["ci.yml"] != "injection" {
+		t.Errorf("ci.yml issue linter = %q, want injection (issues: %v)", got["ci.yml"], issues)
+	}
+}
+
+func TestIssue_String(t *testing.T) {
+	issue := Issue{File: "ci.yml", Line: 5, Linter: "style", Message: "Step is missing a name"}
+	if got, want := issue.String(), "ci.yml:5: (style) Step is missing a name"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	issue.Column = 9
+	if got, want := issue.String(), "ci.yml:5:9: (style) Step is missing a name"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	issue.Column = 0
+	issue.Path = filepath.Join("api", ".github", "workflows", "ci.yml")
+	want := filepath.Join("api", ".github", "workflows", "ci.yml") + ":5: (style) Step is missing a name"
+	if got := issue.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}